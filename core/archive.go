@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 	
@@ -131,6 +132,7 @@ func (tm *TodoManager) ArchiveTodo(id string) error {
 		os.Remove(finalPath)
 		return interrors.NewOperationError("remove", "original todo file", "failed to remove original file after archive", err)
 	}
+	tm.cache.refresh(sourcePath)
 
 	return nil
 }
@@ -163,6 +165,119 @@ func (tm *TodoManager) BulkArchiveTodos(ids []string) []BulkResult {
 	return results
 }
 
+// ResolveArchivedTodoPath finds an archived todo file by walking the archive
+func ResolveArchivedTodoPath(basePath, todoID string) (string, error) {
+	archiveRoot := filepath.Join(basePath, ".claude", "archive")
+	var foundPath string
+
+	err := filepath.Walk(archiveRoot, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip errors, continue search
+		}
+		if !info.IsDir() && info.Name() == todoID+".md" {
+			foundPath = path
+			return filepath.SkipAll
+		}
+		return nil
+	})
+
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("error searching archive: %w", err)
+	}
+	if foundPath == "" {
+		return "", os.ErrNotExist
+	}
+	return foundPath, nil
+}
+
+// ReadArchivedTodo reads a todo from the archive by ID
+func (tm *TodoManager) ReadArchivedTodo(id string) (*Todo, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	filename, err := ResolveArchivedTodoPath(tm.basePath, id)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, interrors.NewNotFoundError("archived todo", id)
+		}
+		return nil, interrors.Wrap(err, "failed to resolve archived todo path")
+	}
+
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, interrors.Wrap(err, "failed to read archived todo")
+	}
+
+	return tm.ParseTodoFileContent(id, string(content))
+}
+
+// ReadArchivedTodoContent returns the raw markdown of an archived todo
+func (tm *TodoManager) ReadArchivedTodoContent(id string) (string, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	filename, err := ResolveArchivedTodoPath(tm.basePath, id)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", interrors.NewNotFoundError("archived todo", id)
+		}
+		return "", interrors.Wrap(err, "failed to resolve archived todo path")
+	}
+
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", interrors.Wrap(err, "failed to read archived todo")
+	}
+	return string(content), nil
+}
+
+// ListArchivedTodos returns archived todos completed at or after since,
+// most recently completed first. A zero since returns the whole archive.
+func (tm *TodoManager) ListArchivedTodos(since time.Time) ([]*Todo, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	archiveRoot := filepath.Join(tm.basePath, ".claude", "archive")
+	var todos []*Todo
+
+	err := filepath.Walk(archiveRoot, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip unreadable entries, a missing archive means no todos
+		}
+		if info.IsDir() || !strings.HasSuffix(info.Name(), ".md") {
+			return nil
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		todo, err := parseTodoMarkdown(string(content))
+		if err != nil {
+			return nil // Skip malformed files, as ListTodos does
+		}
+		if todo.ID == "" {
+			todo.ID = strings.TrimSuffix(info.Name(), ".md")
+		}
+		if !since.IsZero() && todo.Completed.Before(since) {
+			return nil
+		}
+		todos = append(todos, todo)
+		return nil
+	})
+	if err != nil {
+		return nil, interrors.Wrap(err, "failed to walk archive")
+	}
+
+	sort.Slice(todos, func(i, j int) bool {
+		if todos[i].Completed.Equal(todos[j].Completed) {
+			return todos[i].ID < todos[j].ID
+		}
+		return todos[i].Completed.After(todos[j].Completed)
+	})
+	return todos, nil
+}
+
 // isArchived checks if a todo is already archived
 func isArchived(basePath, id string) bool {
 	// Try to resolve the todo path
@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -387,3 +388,33 @@ func TestArchiveCreatesDirectoryWithinClaudeStructure(t *testing.T) {
 	t.Logf("Current archive path: %s", currentArchivePath)
 	t.Logf("Desired archive path: %s", desiredArchivePath)
 }
+
+func TestListArchivedTodos(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := NewTodoManager(tempDir)
+
+	recent, _ := manager.CreateTodo("Recently finished", "high", "feature")
+	if err := manager.ArchiveTodo(recent.ID); err != nil {
+		t.Fatalf("Failed to archive: %v", err)
+	}
+
+	// An old archive entry, completed long before the cutoff
+	oldDir := filepath.Join(tempDir, ".claude", "archive", "2020", "03", "01")
+	os.MkdirAll(oldDir, 0755)
+	content := "---\ntodo_id: old-work\nstarted: 2020-03-01T09:00:00Z\ncompleted: 2020-03-02T09:00:00Z\nstatus: completed\npriority: low\ntype: bug\n---\n\n# Task: Old work\n"
+	ioutil.WriteFile(filepath.Join(oldDir, "old-work.md"), []byte(content), 0644)
+
+	all, err := manager.ListArchivedTodos(time.Time{})
+	if err != nil || len(all) != 2 || all[0].ID != recent.ID || all[1].ID != "old-work" {
+		t.Fatalf("Expected both archived todos, newest first, got %v (err %v)", all, err)
+	}
+
+	lastWeek, _ := manager.ListArchivedTodos(time.Now().AddDate(0, 0, -7))
+	if len(lastWeek) != 1 || lastWeek[0].ID != recent.ID || lastWeek[0].Task != "Recently finished" {
+		t.Errorf("Expected only %s since last week, got %v", recent.ID, lastWeek)
+	}
+
+	if content, err := manager.ReadArchivedTodoContent("old-work"); err != nil || !strings.Contains(content, "# Task: Old work") {
+		t.Errorf("Expected archived markdown, got %q (err %v)", content, err)
+	}
+}
@@ -0,0 +1,395 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+// checklistAnchorPattern matches the "^c3" ID at the end of an item
+var checklistAnchorPattern = regexp.MustCompile(`\s\^([A-Za-z0-9][A-Za-z0-9_-]*)$`)
+
+// checklistPositionPattern matches a position such as "2" or "2.1"
+var checklistPositionPattern = regexp.MustCompile(`^[1-9]\d*(\.[1-9]\d*)*$`)
+
+// checklistMarkers maps each checkbox marker to the status it stands for
+var checklistMarkers = map[string]string{
+	"[ ]": "pending",
+	"[x]": "completed",
+	"[X]": "completed",
+	"[>]": "in_progress",
+	"[-]": "in_progress",
+	"[~]": "in_progress",
+}
+
+// checkboxLine is a parsed checkbox line
+type checkboxLine struct {
+	indent string
+	marker string
+	text   string
+	id     string
+}
+
+// parseChecklistLine reads a "- [ ] text ^id" line. Lines without a valid
+// checkbox or without text don't count.
+func parseChecklistLine(line string) (checkboxLine, bool) {
+	trimmed := strings.TrimSpace(line)
+	if len(trimmed) < 5 || !strings.HasPrefix(trimmed, "- ") {
+		return checkboxLine{}, false
+	}
+	marker := trimmed[2:5]
+	if _, ok := checklistMarkers[marker]; !ok {
+		return checkboxLine{}, false
+	}
+
+	item := checkboxLine{
+		indent: line[:len(line)-len(strings.TrimLeft(line, " \t"))],
+		marker: marker,
+		text:   strings.TrimSpace(trimmed[5:]),
+	}
+	if match := checklistAnchorPattern.FindStringSubmatch(item.text); match != nil {
+		item.id = match[1]
+		item.text = strings.TrimSpace(strings.TrimSuffix(item.text, match[0]))
+	}
+	return item, item.text != ""
+}
+
+// render writes the line back with a new marker
+func (l checkboxLine) render(marker string) string {
+	line := l.indent + "- " + marker + " " + l.text
+	if l.id != "" {
+		line += " ^" + l.id
+	}
+	return line
+}
+
+// nextChecklistMarker cycles pending -> in_progress -> completed -> pending
+func nextChecklistMarker(marker string) string {
+	switch checklistMarkers[marker] {
+	case "pending":
+		return "[>]"
+	case "in_progress":
+		return "[x]"
+	default:
+		return "[ ]"
+	}
+}
+
+// indentWidth measures leading whitespace, counting a tab as four spaces
+func indentWidth(indent string) int {
+	return len(strings.ReplaceAll(indent, "\t", "    "))
+}
+
+// ChecklistNode is a checklist item with the items indented below it.
+// Status rolls up from the children: a parent that isn't ticked is
+// "completed" once all of them are and "partial" while only some are
+// started. Progress is the percentage done, averaged over the children.
+type ChecklistNode struct {
+	ID       string           `json:"id,omitempty"`
+	Position string           `json:"position"`
+	Text     string           `json:"text"`
+	Status   string           `json:"status"`
+	Progress int              `json:"progress"`
+	Children []*ChecklistNode `json:"children,omitempty"`
+
+	line   int     // line number in the parsed content
+	indent int     // indent width, to find the parent
+	done   float64 // unrounded Progress, 0 to 1
+}
+
+// ParseChecklistTree parses checklist items into a tree by indentation and
+// rolls up each parent's status and progress
+func ParseChecklistTree(content string) []*ChecklistNode {
+	var roots []*ChecklistNode
+	var stack []*ChecklistNode
+
+	for i, line := range strings.Split(content, "\n") {
+		item, ok := parseChecklistLine(line)
+		if !ok {
+			continue
+		}
+		node := &ChecklistNode{
+			ID:     item.id,
+			Text:   item.text,
+			Status: checklistMarkers[item.marker],
+			line:   i,
+			indent: indentWidth(item.indent),
+		}
+
+		for len(stack) > 0 && stack[len(stack)-1].indent >= node.indent {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			roots = append(roots, node)
+			node.Position = strconv.Itoa(len(roots))
+		} else {
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, node)
+			node.Position = fmt.Sprintf("%s.%d", parent.Position, len(parent.Children))
+		}
+		stack = append(stack, node)
+	}
+
+	for _, root := range roots {
+		root.rollUp()
+	}
+	return roots
+}
+
+// rollUp sets the status and progress of a node from its children
+func (n *ChecklistNode) rollUp() {
+	if n.Status == "completed" {
+		n.done = 1
+	}
+	if len(n.Children) > 0 {
+		sum := 0.0
+		completed, started := 0, 0
+		for _, child := range n.Children {
+			child.rollUp()
+			sum += child.done
+			if child.Status == "completed" {
+				completed++
+			}
+			if child.Status != "pending" {
+				started++
+			}
+		}
+
+		if n.Status != "completed" {
+			n.done = sum / float64(len(n.Children))
+			switch {
+			case completed == len(n.Children):
+				n.Status = "completed"
+			case started > 0:
+				n.Status = "partial"
+			}
+		}
+	}
+	n.Progress = int(math.Round(n.done * 100))
+}
+
+// ChecklistProgress is the percentage of a checklist that is done, rolled
+// up through the nesting
+func ChecklistProgress(nodes []*ChecklistNode) int {
+	if len(nodes) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, node := range nodes {
+		sum += node.done
+	}
+	return int(math.Round(sum / float64(len(nodes)) * 100))
+}
+
+// findChecklistNode finds the item target names: "^c3" by ID, "2.1" by
+// position, anything else by text. Text has to name exactly one item.
+func findChecklistNode(nodes []*ChecklistNode, target string) (*ChecklistNode, error) {
+	target = strings.TrimSpace(target)
+	var matches []*ChecklistNode
+	var walk func(nodes []*ChecklistNode)
+	walk = func(nodes []*ChecklistNode) {
+		for _, node := range nodes {
+			switch {
+			case strings.HasPrefix(target, "^"):
+				if node.ID == target[1:] {
+					matches = append(matches, node)
+				}
+			case checklistPositionPattern.MatchString(target):
+				if node.Position == target {
+					matches = append(matches, node)
+				}
+			case node.Text == target:
+				matches = append(matches, node)
+			}
+			walk(node.Children)
+		}
+	}
+	walk(nodes)
+
+	switch {
+	case len(matches) == 0:
+		return nil, interrors.NewNotFoundError("checklist item", target)
+	case len(matches) > 1 && strings.HasPrefix(target, "^"):
+		return nil, interrors.NewValidationError("checklist", target,
+			fmt.Sprintf("%d items have the ID %s; toggle by position instead", len(matches), target))
+	case len(matches) > 1:
+		positions := make([]string, len(matches))
+		for i, match := range matches {
+			positions[i] = match.Position
+		}
+		return nil, interrors.NewValidationError("checklist", target,
+			fmt.Sprintf("%d items read %q (positions %s); toggle by position or ^id instead", len(matches), target, strings.Join(positions, ", ")))
+	}
+	return matches[0], nil
+}
+
+// toggleChecklist toggles the item target names in content, as
+// findChecklistNode resolves it
+func toggleChecklist(content, target string) (string, error) {
+	node, err := findChecklistNode(ParseChecklistTree(content), target)
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(content, "\n")
+	item, _ := parseChecklistLine(lines[node.line])
+	lines[node.line] = item.render(nextChecklistMarker(item.marker))
+	return strings.Join(lines, "\n"), nil
+}
+
+// sectionBounds finds the lines under header, up to the next "## " heading.
+// start is -1 when the file has no such heading.
+func sectionBounds(lines []string, header string) (start, end int) {
+	start, end = -1, len(lines)
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if start == -1 && trimmed == header {
+			start = i + 1
+		} else if start != -1 && strings.HasPrefix(trimmed, "## ") {
+			end = i
+			break
+		}
+	}
+	return start, end
+}
+
+// toggleChecklistSection toggles an item of a todo file's checklist section.
+// Positions count from the top of the section; a file without a checklist
+// heading is searched whole.
+func toggleChecklistSection(fileContent, target string) (string, error) {
+	header := definedSectionHeader(fileContent, "checklist", "## Checklist")
+	lines := strings.Split(fileContent, "\n")
+
+	start, end := sectionBounds(lines, header)
+	if start == -1 {
+		start = 0
+	}
+
+	section, err := toggleChecklist(strings.Join(lines[start:end], "\n"), target)
+	if err != nil {
+		return "", err
+	}
+	updated := append(append(append([]string{}, lines[:start]...), section), lines[end:]...)
+	return strings.Join(updated, "\n"), nil
+}
+
+// mergeChecklist adds the items of incoming that section lacks. Items match
+// by text among their siblings, so new sub-items land under the existing
+// parent, indented like its other children. Anchors section already uses
+// are dropped from the added items, as a repeated ^id can't be toggled.
+func mergeChecklist(section, incoming string) string {
+	lines := strings.Split(section, "\n")
+	roots := ParseChecklistTree(section)
+	incomingLines := strings.Split(incoming, "\n")
+
+	anchors := make(map[string]bool)
+	var collect func(nodes []*ChecklistNode)
+	collect = func(nodes []*ChecklistNode) {
+		for _, node := range nodes {
+			if node.ID != "" {
+				anchors[node.ID] = true
+			}
+			collect(node.Children)
+		}
+	}
+	collect(roots)
+
+	// Lines to insert before each line of section
+	inserts := make(map[int][]string)
+	var merge func(have []*ChecklistNode, at int, indent string, add []*ChecklistNode)
+	merge = func(have []*ChecklistNode, at int, indent string, add []*ChecklistNode) {
+		added := make(map[string]bool)
+		for _, node := range add {
+			if match := childByText(have, node.Text); match != nil {
+				childIndent := lineIndent(lines, match) + "  "
+				if len(match.Children) > 0 {
+					childIndent = lineIndent(lines, match.Children[0])
+				}
+				merge(match.Children, lastChecklistLine(match)+1, childIndent, node.Children)
+				continue
+			}
+			if added[node.Text] {
+				continue
+			}
+			added[node.Text] = true
+			inserts[at] = append(inserts[at], renderChecklistSubtree(incomingLines, node, indent, anchors)...)
+		}
+	}
+
+	if len(roots) > 0 {
+		merge(roots, lastChecklistLine(roots[len(roots)-1])+1, lineIndent(lines, roots[0]), ParseChecklistTree(incoming))
+	} else {
+		// Nothing to nest into: add after the section's other text
+		at := 0
+		for i, line := range lines {
+			if strings.TrimSpace(line) != "" {
+				at = i + 1
+			}
+		}
+		if at == 0 && len(lines) > 1 {
+			at = 1 // after the blank line under the heading
+		}
+		merge(nil, at, "", ParseChecklistTree(incoming))
+		if at > 1 && len(inserts[at]) > 0 {
+			inserts[at] = append([]string{""}, inserts[at]...)
+		}
+	}
+
+	var merged []string
+	for i, line := range lines {
+		merged = append(merged, inserts[i]...)
+		merged = append(merged, line)
+	}
+	merged = append(merged, inserts[len(lines)]...)
+	return strings.Join(merged, "\n")
+}
+
+// childByText finds the node among nodes with the given text
+func childByText(nodes []*ChecklistNode, text string) *ChecklistNode {
+	for _, node := range nodes {
+		if node.Text == text {
+			return node
+		}
+	}
+	return nil
+}
+
+// lastChecklistLine is the line of the last item nested under node
+func lastChecklistLine(node *ChecklistNode) int {
+	for len(node.Children) > 0 {
+		node = node.Children[len(node.Children)-1]
+	}
+	return node.line
+}
+
+// lineIndent returns the indentation of node's line as written
+func lineIndent(lines []string, node *ChecklistNode) string {
+	item, _ := parseChecklistLine(lines[node.line])
+	return item.indent
+}
+
+// renderChecklistSubtree renders node and its sub-items from lines at a new
+// indent, keeping their relative nesting. Anchors in use are dropped; the
+// rest are claimed.
+func renderChecklistSubtree(lines []string, node *ChecklistNode, indent string, anchors map[string]bool) []string {
+	item, _ := parseChecklistLine(lines[node.line])
+	item.indent = indent
+	if item.id != "" {
+		if anchors[item.id] {
+			item.id = ""
+		} else {
+			anchors[item.id] = true
+		}
+	}
+
+	rendered := []string{item.render(item.marker)}
+	for _, child := range node.Children {
+		childIndent := indent + strings.Repeat(" ", child.indent-node.indent)
+		rendered = append(rendered, renderChecklistSubtree(lines, child, childIndent, anchors)...)
+	}
+	return rendered
+}
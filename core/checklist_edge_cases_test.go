@@ -2,6 +2,8 @@ package core
 
 import (
 	"testing"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
 )
 
 func TestParseChecklistAdvancedEdgeCases(t *testing.T) {
@@ -159,24 +161,25 @@ func TestToggleChecklistItemEdgeCases(t *testing.T) {
 		content  string
 		itemText string
 		expected string
+		notFound bool
 	}{
 		{
 			name:     "item not found",
 			content:  "- [ ] Item one\n- [ ] Item two",
 			itemText: "Item three",
-			expected: "- [ ] Item one\n- [ ] Item two", // No change
+			notFound: true,
 		},
 		{
 			name:     "empty content",
 			content:  "",
 			itemText: "Any item",
-			expected: "",
+			notFound: true,
 		},
 		{
 			name:     "empty item text",
 			content:  "- [ ] Item one",
 			itemText: "",
-			expected: "- [ ] Item one", // No change
+			notFound: true,
 		},
 		{
 			name: "case sensitive matching",
@@ -210,9 +213,18 @@ func TestToggleChecklistItemEdgeCases(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := toggleChecklistItem(tt.content, tt.itemText)
+			result, err := toggleChecklist(tt.content, tt.itemText)
+			if tt.notFound {
+				if !interrors.IsNotFound(err) {
+					t.Errorf("Expected the item not to be found, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("toggleChecklist() failed: %v", err)
+			}
 			if result != tt.expected {
-				t.Errorf("toggleChecklistItem() = %q, want %q", result, tt.expected)
+				t.Errorf("toggleChecklist() = %q, want %q", result, tt.expected)
 			}
 		})
 	}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+const nestedChecklist = `- [ ] Ship export ^c1
+  - [x] CSV writer ^c2
+  - [ ] Upload to bucket
+    - [x] Credentials
+    - [ ] Retry on 503
+- [x] Write docs
+- [ ] Tests
+  - [ ] Unit tests
+  - [ ] Unit tests`
+
+func TestParseChecklistTree(t *testing.T) {
+	tree := ParseChecklistTree(nestedChecklist)
+	if len(tree) != 3 {
+		t.Fatalf("Expected 3 top-level items, got %d", len(tree))
+	}
+
+	export := tree[0]
+	if export.ID != "c1" || export.Text != "Ship export" || export.Status != "partial" || export.Progress != 75 {
+		t.Errorf("Unexpected parent: %+v", export)
+	}
+	upload := export.Children[1]
+	if upload.Position != "1.2" || upload.Status != "partial" || upload.Progress != 50 {
+		t.Errorf("Unexpected nested parent: %+v", upload)
+	}
+	if retry := upload.Children[1]; retry.Position != "1.2.2" || retry.Status != "pending" || retry.Progress != 0 {
+		t.Errorf("Unexpected leaf: %+v", retry)
+	}
+	if tests := tree[2]; tests.Status != "pending" || tests.Progress != 0 {
+		t.Errorf("Expected a parent with nothing started to stay pending: %+v", tests)
+	}
+
+	// (75 + 100 + 0) / 3
+	if progress := ChecklistProgress(tree); progress != 58 {
+		t.Errorf("Expected 58%% overall, got %d", progress)
+	}
+
+	done := ParseChecklistTree("- [ ] Release\n\t- [x] Tag\n\t- [x] Announce")
+	if done[0].Status != "completed" || done[0].Progress != 100 || len(done[0].Children) != 2 {
+		t.Errorf("Expected a parent with all children done to complete, got %+v", done[0])
+	}
+
+	items := ParseChecklist(nestedChecklist)
+	if len(items) != 9 || items[0].ID != "c1" || items[0].Text != "Ship export" || items[3].ID != "" {
+		t.Errorf("Expected the flat list to keep every item with its ID, got %+v", items)
+	}
+}
+
+func TestToggleChecklistTargets(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		want   string
+		errMsg string
+	}{
+		{name: "by ID", target: "^c2", want: "  - [ ] CSV writer ^c2"},
+		{name: "by position", target: "1.2.2", want: "    - [>] Retry on 503"},
+		{name: "by text", target: "Write docs", want: "- [ ] Write docs"},
+		{name: "text ignores the anchor", target: "Ship export", want: "- [>] Ship export ^c1"},
+		{name: "duplicate text", target: "Unit tests", errMsg: "2 items read \"Unit tests\" (positions 3.1, 3.2)"},
+		{name: "unknown ID", target: "^c9", errMsg: "not found"},
+		{name: "position past the end", target: "4", errMsg: "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toggleChecklist(nestedChecklist, tt.target)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("toggleChecklist failed: %v", err)
+			}
+			if !strings.Contains(got, tt.want+"\n") && !strings.HasSuffix(got, tt.want) {
+				t.Errorf("Expected line %q, got:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUpdateTodoToggleNestedChecklist(t *testing.T) {
+	manager := NewTodoManager(t.TempDir())
+	todo, _ := manager.CreateTodo("Ship export", "high", "feature")
+	manager.UpdateTodo(todo.ID, "test_list", "append", "- [ ] Not the checklist", nil)
+	manager.UpdateTodo(todo.ID, "checklist", "append", nestedChecklist, nil)
+
+	// Positions count within the checklist section
+	if err := manager.UpdateTodo(todo.ID, "checklist", "toggle", "1", nil); err != nil {
+		t.Fatalf("Toggle by position failed: %v", err)
+	}
+	if err := manager.UpdateTodo(todo.ID, "checklist", "toggle", "^c2", nil); err != nil {
+		t.Fatalf("Toggle by ID failed: %v", err)
+	}
+	content, _ := manager.ReadTodoContent(todo.ID)
+	if !strings.Contains(content, "- [>] Ship export ^c1\n  - [ ] CSV writer ^c2") || !strings.Contains(content, "- [ ] Not the checklist") {
+		t.Errorf("Unexpected content after toggles:\n%s", content)
+	}
+
+	err := manager.UpdateTodo(todo.ID, "checklist", "toggle", "Unit tests", nil)
+	if !interrors.IsValidation(err) {
+		t.Errorf("Expected an ambiguous toggle to be refused, got %v", err)
+	}
+	if err := manager.UpdateTodo(todo.ID, "checklist", "toggle", "Nothing like this", nil); !interrors.IsNotFound(err) {
+		t.Errorf("Expected a missing item to be reported, got %v", err)
+	}
+}
+
+func TestMergeChecklist(t *testing.T) {
+	survivor := "\n- [ ] Ship export ^c1\n  - [x] CSV writer ^c2\n- [ ] Tests\n"
+	duplicate := "- [x] Ship export ^c7\n    - [ ] CSV writer\n    - [ ] Upload to bucket ^c2\n        - [ ] Retry on 503 ^c3\n- [ ] Write docs ^c1\n- [ ] Write docs"
+
+	want := "\n- [ ] Ship export ^c1\n  - [x] CSV writer ^c2\n  - [ ] Upload to bucket\n      - [ ] Retry on 503 ^c3\n- [ ] Tests\n- [ ] Write docs\n"
+	if got := mergeChecklist(survivor, duplicate); got != want {
+		t.Errorf("Unexpected merge:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := mergeChecklist("\nSee the runbook first.\n", "- [ ] Page ops"); got != "\nSee the runbook first.\n\n- [ ] Page ops\n" {
+		t.Errorf("Expected items after the section's text, got %q", got)
+	}
+	if got := mergeChecklist("\n\n", "- [ ] Page ops\n  - [ ] Find the rota"); got != "\n- [ ] Page ops\n  - [ ] Find the rota\n\n" {
+		t.Errorf("Expected items in an empty section, got %q", got)
+	}
+}
@@ -2,6 +2,8 @@ package core
 
 import (
 	"testing"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
 )
 
 func TestToggleChecklistItem(t *testing.T) {
@@ -55,14 +57,6 @@ func TestToggleChecklistItem(t *testing.T) {
 			itemText: "Uppercase task",
 			expected: `- [ ] Uppercase task`,
 		},
-		{
-			name: "handle item not found",
-			content: `- [ ] Task one
-- [ ] Task two`,
-			itemText: "Task three",
-			expected: `- [ ] Task one
-- [ ] Task two`,
-		},
 		{
 			name: "exact match required",
 			content: `- [ ] Task one
@@ -91,10 +85,19 @@ func TestToggleChecklistItem(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := toggleChecklistItem(tt.content, tt.itemText)
+			result, err := toggleChecklist(tt.content, tt.itemText)
+			if err != nil {
+				t.Fatalf("toggleChecklist() failed: %v", err)
+			}
 			if result != tt.expected {
-				t.Errorf("toggleChecklistItem() = %q, want %q", result, tt.expected)
+				t.Errorf("toggleChecklist() = %q, want %q", result, tt.expected)
 			}
 		})
 	}
 }
+
+func TestToggleChecklistItemNotFound(t *testing.T) {
+	if _, err := toggleChecklist("- [ ] Task one\n- [ ] Task two", "Task three"); !interrors.IsNotFound(err) {
+		t.Errorf("Expected a missing item to be reported, got %v", err)
+	}
+}
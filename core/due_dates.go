@@ -0,0 +1,171 @@
+package core
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+// dueDateFormats are the accepted formats for due and scheduled dates.
+// Date-only values are interpreted in local time.
+var dueDateFormats = []string{
+	"2006-01-02",
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+}
+
+// ParseDueDate parses a due or scheduled date. An empty string or "none"
+// returns the zero time, which clears the field.
+func ParseDueDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.EqualFold(value, "none") {
+		return time.Time{}, nil
+	}
+
+	for _, format := range dueDateFormats {
+		if t, err := time.ParseInLocation(format, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, interrors.NewValidationError("date", value, "expected YYYY-MM-DD, YYYY-MM-DD HH:MM or RFC3339")
+}
+
+// localDate reinterprets a date-only value decoded from YAML, which arrives
+// as UTC midnight, as midnight in local time. Other times are left as is.
+func localDate(t time.Time) time.Time {
+	if t.IsZero() || t.Location() != time.UTC || t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0 || t.Nanosecond() != 0 {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// FormatDueDate formats a due or scheduled date, omitting the time of day
+// when it is midnight
+func FormatDueDate(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+// startOfDay truncates t to midnight in the location of ref
+func startOfDay(t time.Time, ref time.Time) time.Time {
+	t = t.In(ref.Location())
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, ref.Location())
+}
+
+// daysUntil returns the number of calendar days from now until t
+// (negative when t is in the past)
+func daysUntil(t time.Time, now time.Time) int {
+	// Round so DST transitions (23/25 hour days) don't skew the count
+	return int(math.Round(startOfDay(t, now).Sub(startOfDay(now, now)).Hours() / 24))
+}
+
+// HasDue returns true if the todo has a due date
+func (t *Todo) HasDue() bool {
+	return !t.Due.IsZero()
+}
+
+// IsOverdue returns true if an open todo's due date is before today
+func (t *Todo) IsOverdue(now time.Time) bool {
+	return t.HasDue() && !t.IsCompleted() && daysUntil(t.Due, now) < 0
+}
+
+// IsDueWithin returns true if an open todo is due within the next n days,
+// counting today and anything already overdue
+func (t *Todo) IsDueWithin(n int, now time.Time) bool {
+	return t.HasDue() && !t.IsCompleted() && daysUntil(t.Due, now) <= n
+}
+
+// FilterTodosByDue narrows an already-listed set of todos by due date.
+// overdue keeps only overdue todos; dueWithinDays > 0 keeps todos due in
+// that many days. Both filters are skipped when unset.
+func FilterTodosByDue(todos []*Todo, overdue bool, dueWithinDays int, now time.Time) []*Todo {
+	if !overdue && dueWithinDays <= 0 {
+		return todos
+	}
+
+	filtered := make([]*Todo, 0, len(todos))
+	for _, todo := range todos {
+		if overdue && !todo.IsOverdue(now) {
+			continue
+		}
+		if dueWithinDays > 0 && !todo.IsDueWithin(dueWithinDays, now) {
+			continue
+		}
+		filtered = append(filtered, todo)
+	}
+	return filtered
+}
+
+// SortTodosByDue sorts todos by due date, earliest first. Todos without a
+// due date fall back to their scheduled date and then sort last.
+func SortTodosByDue(todos []*Todo) {
+	sort.SliceStable(todos, func(i, j int) bool {
+		a, b := todos[i].agendaDate(), todos[j].agendaDate()
+		if a.IsZero() != b.IsZero() {
+			return !a.IsZero()
+		}
+		return a.Before(b)
+	})
+}
+
+// agendaDate is the date a todo is planned for: its due date, or its
+// scheduled date if it has no deadline
+func (t *Todo) agendaDate() time.Time {
+	if t.HasDue() {
+		return t.Due
+	}
+	return t.Scheduled
+}
+
+// Agenda groups open todos by when they are due
+type Agenda struct {
+	Overdue  []*Todo
+	Today    []*Todo
+	ThisWeek []*Todo // due in the next 7 days, after today
+	Later    []*Todo
+	Undated  int // open todos with neither due nor scheduled date
+}
+
+// BuildAgenda groups open todos into overdue, today, this week and later.
+// Scheduled-only todos are never overdue: past scheduled dates show as today.
+func BuildAgenda(todos []*Todo, now time.Time) *Agenda {
+	agenda := &Agenda{}
+
+	open := make([]*Todo, 0, len(todos))
+	for _, todo := range todos {
+		if !todo.IsCompleted() {
+			open = append(open, todo)
+		}
+	}
+	SortTodosByDue(open)
+
+	for _, todo := range open {
+		date := todo.agendaDate()
+		if date.IsZero() {
+			agenda.Undated++
+			continue
+		}
+
+		days := daysUntil(date, now)
+		switch {
+		case days < 0 && todo.HasDue():
+			agenda.Overdue = append(agenda.Overdue, todo)
+		case days <= 0:
+			agenda.Today = append(agenda.Today, todo)
+		case days <= 7:
+			agenda.ThisWeek = append(agenda.ThisWeek, todo)
+		default:
+			agenda.Later = append(agenda.Later, todo)
+		}
+	}
+
+	return agenda
+}
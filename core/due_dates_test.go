@@ -0,0 +1,171 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDueDate(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"2026-10-20", time.Date(2026, 10, 20, 0, 0, 0, 0, time.Local), false},
+		{"2026-10-20 17:30", time.Date(2026, 10, 20, 17, 30, 0, 0, time.Local), false},
+		{"2026-10-20T09:00:00Z", time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC), false},
+		{"", time.Time{}, false},
+		{"none", time.Time{}, false},
+		{"next tuesday", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDueDate(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDueDate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseDueDate(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestDueDateFiltersAndAgenda(t *testing.T) {
+	now := time.Date(2026, 10, 16, 10, 0, 0, 0, time.Local)
+	day := func(offset int) time.Time {
+		return time.Date(2026, 10, 16+offset, 0, 0, 0, 0, time.Local)
+	}
+
+	todos := []*Todo{
+		{ID: "later", Status: "pending", Due: day(30)},
+		{ID: "overdue", Status: "in_progress", Due: day(-2)},
+		{ID: "done-overdue", Status: "completed", Due: day(-5)},
+		{ID: "today", Status: "pending", Due: day(0)},
+		{ID: "week", Status: "pending", Due: day(3)},
+		{ID: "scheduled-past", Status: "pending", Scheduled: day(-1)},
+		{ID: "undated", Status: "pending"},
+	}
+
+	overdue := FilterTodosByDue(todos, true, 0, now)
+	if len(overdue) != 1 || overdue[0].ID != "overdue" {
+		t.Errorf("Expected only 'overdue', got %v", todoIDs(overdue))
+	}
+
+	dueSoon := FilterTodosByDue(todos, false, 3, now)
+	if got := todoIDs(dueSoon); len(got) != 3 || got[0] != "overdue" || got[1] != "today" || got[2] != "week" {
+		t.Errorf("Expected overdue, today and week, got %v", got)
+	}
+
+	if got := FilterTodosByDue(todos, false, 0, now); len(got) != len(todos) {
+		t.Errorf("Expected unfiltered list, got %d todos", len(got))
+	}
+
+	sorted := append([]*Todo(nil), todos...)
+	SortTodosByDue(sorted)
+	if got := todoIDs(sorted); got[0] != "done-overdue" || got[len(got)-1] != "undated" {
+		t.Errorf("Unexpected due order: %v", got)
+	}
+
+	agenda := BuildAgenda(todos, now)
+	checks := map[string][]*Todo{
+		"overdue":        agenda.Overdue,
+		"today":          agenda.Today,
+		"week":           agenda.ThisWeek,
+		"later":          agenda.Later,
+		"scheduled-past": agenda.Today,
+	}
+	for id, bucket := range checks {
+		found := false
+		for _, todo := range bucket {
+			if todo.ID == id {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected %s in its agenda group", id)
+		}
+	}
+	if len(agenda.Overdue) != 1 {
+		t.Errorf("Completed todos should not appear in the agenda, got %v", todoIDs(agenda.Overdue))
+	}
+	if agenda.Undated != 1 {
+		t.Errorf("Expected 1 undated todo, got %d", agenda.Undated)
+	}
+}
+
+func TestUpdateTodoDueDates(t *testing.T) {
+	manager, _, cleanup := SetupTestTodoManager(t)
+	defer cleanup()
+
+	todo, err := manager.CreateTodo("Ship release notes", "high", "feature")
+	if err != nil {
+		t.Fatalf("Failed to create todo: %v", err)
+	}
+
+	err = manager.UpdateTodo(todo.ID, "", "", "", map[string]string{
+		"due":       "2026-10-20",
+		"scheduled": "2026-10-18 09:00",
+	})
+	if err != nil {
+		t.Fatalf("Failed to set dates: %v", err)
+	}
+
+	updated, err := manager.ReadTodo(todo.ID)
+	if err != nil {
+		t.Fatalf("Failed to read todo: %v", err)
+	}
+	if FormatDueDate(updated.Due) != "2026-10-20" {
+		t.Errorf("Expected due 2026-10-20, got %s", FormatDueDate(updated.Due))
+	}
+	if FormatDueDate(updated.Scheduled) != "2026-10-18 09:00" {
+		t.Errorf("Expected scheduled 2026-10-18 09:00, got %s", FormatDueDate(updated.Scheduled))
+	}
+
+	// "none" clears the deadline
+	if err := manager.UpdateTodo(todo.ID, "", "", "", map[string]string{"due": "none"}); err != nil {
+		t.Fatalf("Failed to clear due date: %v", err)
+	}
+	updated, _ = manager.ReadTodo(todo.ID)
+	if updated.HasDue() {
+		t.Errorf("Expected due date to be cleared, got %v", updated.Due)
+	}
+
+	if err := manager.UpdateTodo(todo.ID, "", "", "", map[string]string{"due": "soon"}); err == nil {
+		t.Error("Expected error for invalid due date")
+	}
+}
+
+func TestHandEditedDueDateIsLocal(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	time.Local = newYork
+
+	todo, err := parseTodoMarkdown("---\ntodo_id: pay-invoice\nstatus: in_progress\ndue: 2026-03-01\nscheduled: 2026-02-27\n---\n\n# Task: Pay invoice\n")
+	if err != nil {
+		t.Fatalf("Failed to parse todo: %v", err)
+	}
+
+	if want := time.Date(2026, 3, 1, 0, 0, 0, 0, newYork); !todo.Due.Equal(want) {
+		t.Errorf("Expected due %v, got %v", want, todo.Due)
+	}
+	if FormatDueDate(todo.Scheduled) != "2026-02-27" {
+		t.Errorf("Expected scheduled 2026-02-27, got %v", todo.Scheduled)
+	}
+
+	// The evening before the due date is not overdue yet
+	if todo.IsOverdue(time.Date(2026, 2, 28, 20, 0, 0, 0, newYork)) {
+		t.Error("Todo should not be overdue before its due date")
+	}
+}
+
+func todoIDs(todos []*Todo) []string {
+	ids := make([]string, len(todos))
+	for i, todo := range todos {
+		ids[i] = todo.ID
+	}
+	return ids
+}
@@ -0,0 +1,335 @@
+package core
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"unicode"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+// DefaultDuplicateThreshold is the similarity at which two todos count as
+// near-duplicates
+const DefaultDuplicateThreshold = 0.6
+
+// Title similarity outweighs body similarity when both todos have a body
+const (
+	duplicateTitleWeight = 0.6
+	duplicateBodyWeight  = 0.4
+)
+
+// duplicateStopWords carry no meaning for duplicate detection
+var duplicateStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "in": true, "is": true,
+	"it": true, "of": true, "on": true, "or": true, "so": true, "the": true,
+	"this": true, "to": true, "was": true, "with": true,
+}
+
+// DuplicatePair is two todos that look like the same work
+type DuplicatePair struct {
+	First  string
+	Second string
+	Score  float64
+}
+
+// MergeResult describes what MergeTodos changed
+type MergeResult struct {
+	Survivor   string
+	Merged     []string
+	Reparented []string
+}
+
+// tokenSet is the distinct, meaningful words of a piece of text
+type tokenSet map[string]bool
+
+// FindNearDuplicates compares every pair of active todos by the token-set
+// (Jaccard) similarity of their titles and bodies and returns the pairs
+// scoring at least threshold, best first.
+func (tm *TodoManager) FindNearDuplicates(threshold float64) ([]DuplicatePair, error) {
+	todos, err := tm.ListTodos("", "", 0)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(todos, func(i, j int) bool {
+		return todos[i].ID < todos[j].ID
+	})
+
+	titles := make([]tokenSet, len(todos))
+	bodies := make([]tokenSet, len(todos))
+	for i, todo := range todos {
+		titles[i] = tokenize(todo.Task)
+		if content, err := tm.ReadTodoContent(todo.ID); err == nil {
+			bodies[i] = tokenize(todoBody(content))
+		}
+	}
+
+	var pairs []DuplicatePair
+	for i := range todos {
+		for j := i + 1; j < len(todos); j++ {
+			score := duplicateScore(titles[i], titles[j], bodies[i], bodies[j])
+			if score >= threshold {
+				pairs = append(pairs, DuplicatePair{First: todos[i].ID, Second: todos[j].ID, Score: score})
+			}
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Score > pairs[j].Score
+	})
+	return pairs, nil
+}
+
+// duplicateScore blends title and body similarity. A fresh todo with an
+// empty body is judged on its title alone.
+func duplicateScore(titleA, titleB, bodyA, bodyB tokenSet) float64 {
+	title := jaccard(titleA, titleB)
+	if len(bodyA) == 0 || len(bodyB) == 0 {
+		return title
+	}
+	return duplicateTitleWeight*title + duplicateBodyWeight*jaccard(bodyA, bodyB)
+}
+
+// jaccard is the size of the intersection over the size of the union
+func jaccard(a, b tokenSet) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for token := range a {
+		if b[token] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// tokenize lowercases text and keeps its distinct words, dropping stop
+// words, single characters and bare numbers such as timestamps
+func tokenize(text string) tokenSet {
+	tokens := make(tokenSet)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if len(word) < 2 || duplicateStopWords[word] || strings.IndexFunc(word, unicode.IsLetter) < 0 {
+			continue
+		}
+		tokens[word] = true
+	}
+	return tokens
+}
+
+// todoBody returns the markdown after the frontmatter, without the task
+// heading or section headings, so empty templates compare as empty
+func todoBody(content string) string {
+	if parts := strings.SplitN(content, "---\n", 3); len(parts) == 3 {
+		content = parts[2]
+	}
+	var body []string
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		body = append(body, line)
+	}
+	return strings.Join(body, "\n")
+}
+
+// markdownSection is one "## " section of a todo body
+type markdownSection struct {
+	Header  string
+	Content string
+}
+
+// splitSections returns a todo's "## " sections in file order
+func splitSections(content string) []markdownSection {
+	var sections []markdownSection
+	var current *markdownSection
+	var lines []string
+	flush := func() {
+		if current != nil {
+			current.Content = strings.TrimSpace(strings.Join(lines, "\n"))
+			sections = append(sections, *current)
+		}
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "## ") {
+			flush()
+			current = &markdownSection{Header: trimmed}
+			lines = nil
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return sections
+}
+
+// sectionContent returns the content under an exact section header
+func sectionContent(content, header string) string {
+	for _, section := range splitSections(content) {
+		if section.Header == header {
+			return section.Content
+		}
+	}
+	return ""
+}
+
+// MergeTodos folds duplicates into survivor. Each duplicate's sections are
+// appended to the survivor's matching sections, checklist items are unioned
+// by text, children are re-pointed at the survivor, and the duplicate is
+// archived with merged_into set.
+func (tm *TodoManager) MergeTodos(survivor string, duplicates []string) (*MergeResult, error) {
+	if len(duplicates) == 0 {
+		return nil, interrors.NewValidationError("duplicates", "", "at least one duplicate is required")
+	}
+	survivorContent, err := tm.ReadTodoContent(survivor)
+	if err != nil {
+		return nil, interrors.NewNotFoundError("todo", survivor)
+	}
+	// Naming a duplicate twice merges it once
+	merging := make(map[string]*Todo)
+	var unique []string
+	for _, id := range duplicates {
+		if id == survivor {
+			return nil, interrors.NewValidationError("duplicates", id, "a todo cannot be merged into itself")
+		}
+		if _, seen := merging[id]; seen {
+			continue
+		}
+		todo, err := tm.ReadTodo(id)
+		if err != nil {
+			return nil, interrors.NewNotFoundError("todo", id)
+		}
+		merging[id] = todo
+		unique = append(unique, id)
+	}
+	duplicates = unique
+
+	result := &MergeResult{Survivor: survivor}
+	for _, id := range duplicates {
+		content, err := tm.ReadTodoContent(id)
+		if err != nil {
+			return result, interrors.Wrap(err, fmt.Sprintf("failed to read %s", id))
+		}
+		survivorContent = mergeTodoContent(survivorContent, content, id)
+	}
+	if err := tm.writeTodoContent(survivor, survivorContent); err != nil {
+		return result, err
+	}
+
+	for _, id := range duplicates {
+		children, _ := tm.GetChildren(id)
+		for _, child := range children {
+			parent := survivor
+			if child.ID == survivor {
+				// The survivor takes the duplicate's place under its parent
+				parent = mergedParent(merging, id, survivor)
+			}
+			err := tm.UpdateTodoFrontmatter(child.ID, func(todo *Todo) error {
+				todo.ParentID = parent
+				return nil
+			})
+			if err != nil {
+				return result, interrors.Wrap(err, fmt.Sprintf("failed to re-parent %s", child.ID))
+			}
+			if child.ID != survivor {
+				result.Reparented = append(result.Reparented, child.ID)
+			}
+		}
+
+		err := tm.UpdateTodoFrontmatter(id, func(todo *Todo) error {
+			todo.MergedInto = survivor
+			return nil
+		})
+		if err != nil {
+			return result, err
+		}
+		if err := tm.ArchiveTodo(id); err != nil {
+			return result, interrors.Wrap(err, fmt.Sprintf("failed to archive %s", id))
+		}
+		result.Merged = append(result.Merged, id)
+	}
+
+	return result, nil
+}
+
+// mergedParent is the parent id inherits once the duplicates being merged
+// are gone: the nearest ancestor that isn't one of them, or none if that
+// would be the survivor itself
+func mergedParent(merging map[string]*Todo, id, survivor string) string {
+	parent := merging[id].ParentID
+	seen := map[string]bool{id: true}
+	for merging[parent] != nil && !seen[parent] {
+		seen[parent] = true
+		parent = merging[parent].ParentID
+	}
+	if parent == survivor || merging[parent] != nil {
+		return ""
+	}
+	return parent
+}
+
+// mergeTodoContent appends a duplicate's sections to the survivor's content.
+// Checklist items the survivor already has (by text) and sections whose
+// content it already contains are skipped.
+func mergeTodoContent(survivorContent, duplicateContent, duplicateID string) string {
+	body := duplicateContent
+	if parts := strings.SplitN(body, "---\n", 3); len(parts) == 3 {
+		body = parts[2]
+	}
+
+	for _, section := range splitSections(body) {
+		if section.Content == "" {
+			continue
+		}
+		existing := sectionContent(survivorContent, section.Header)
+
+		if section.Header == "## Checklist" {
+			survivorContent = mergeChecklistSection(survivorContent, section.Header, section.Content)
+			continue
+		}
+
+		if strings.Contains(existing, section.Content) {
+			continue
+		}
+		merged := fmt.Sprintf("_Merged from %s:_\n\n%s", duplicateID, section.Content)
+		survivorContent = appendUnderHeading(survivorContent, section.Header, merged)
+	}
+	return survivorContent
+}
+
+// mergeChecklistSection merges checklist items into the section under
+// header, creating the section if the file has none
+func mergeChecklistSection(fileContent, header, items string) string {
+	lines := strings.Split(fileContent, "\n")
+	start, end := sectionBounds(lines, header)
+	if start == -1 {
+		return appendUnderHeading(fileContent, header, strings.Trim(mergeChecklist("", items), "\n"))
+	}
+
+	section := mergeChecklist(strings.Join(lines[start:end], "\n"), items)
+	merged := append(append(append([]string{}, lines[:start]...), section), lines[end:]...)
+	return strings.Join(merged, "\n")
+}
+
+// writeTodoContent replaces a todo file's raw content
+func (tm *TodoManager) writeTodoContent(id, content string) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	filename, err := ResolveTodoPath(tm.basePath, id)
+	if err != nil {
+		return interrors.Wrap(err, "failed to resolve todo path")
+	}
+	if err := ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+		return interrors.NewOperationError("write", "todo file", "failed to save merged todo", err)
+	}
+	tm.cache.refresh(filename)
+	return nil
+}
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindNearDuplicates(t *testing.T) {
+	manager := NewTodoManager(t.TempDir())
+
+	for _, task := range []string{"Fix login timeout bug", "Fix the login timeout", "Add dark mode toggle", "Login page redesign"} {
+		if _, err := manager.CreateTodo(task, "high", "bug"); err != nil {
+			t.Fatalf("Failed to create %q: %v", task, err)
+		}
+	}
+
+	pairs, err := manager.FindNearDuplicates(DefaultDuplicateThreshold)
+	if err != nil {
+		t.Fatalf("FindNearDuplicates failed: %v", err)
+	}
+	if len(pairs) != 1 {
+		t.Fatalf("Expected one near-duplicate pair, got %+v", pairs)
+	}
+	if pairs[0].First != "fix-login-timeout-bug" || pairs[0].Second != "fix-the-login-timeout" || pairs[0].Score != 0.75 {
+		t.Errorf("Unexpected pair %+v", pairs[0])
+	}
+
+	// Diverging bodies pull the score down
+	manager.UpdateTodo("fix-login-timeout-bug", "findings", "append", "Auth client times out after 2s on mobile networks", nil)
+	manager.UpdateTodo("fix-the-login-timeout", "findings", "append", "Session cookie is dropped by the load balancer", nil)
+	pairs, _ = manager.FindNearDuplicates(DefaultDuplicateThreshold)
+	if len(pairs) != 0 {
+		t.Errorf("Expected different bodies to fall below the threshold, got %+v", pairs)
+	}
+	pairs, _ = manager.FindNearDuplicates(0.4)
+	if len(pairs) != 1 || pairs[0].Score >= 0.75 {
+		t.Errorf("Expected a lower-scoring pair at a looser threshold, got %+v", pairs)
+	}
+}
+
+func TestMergeTodos(t *testing.T) {
+	manager := NewTodoManager(t.TempDir())
+
+	manager.CreateTodo("Fix login timeout bug", "high", "bug")
+	manager.CreateTodo("Fix the login timeout", "high", "bug")
+	manager.CreateTodoWithParent("Reproduce on mobile", "medium", "subtask", "fix-the-login-timeout")
+
+	manager.UpdateTodo("fix-login-timeout-bug", "findings", "append", "Auth client times out after 2s", nil)
+	manager.UpdateTodo("fix-login-timeout-bug", "checklist", "append", "- [ ] Raise timeout\n- [x] Add logging", nil)
+	manager.UpdateTodo("fix-the-login-timeout", "findings", "append", "Only happens on mobile networks", nil)
+	manager.UpdateTodo("fix-the-login-timeout", "checklist", "append", "- [x] Raise timeout\n- [ ] Add a regression test", nil)
+
+	result, err := manager.MergeTodos("fix-login-timeout-bug", []string{"fix-the-login-timeout"})
+	if err != nil {
+		t.Fatalf("MergeTodos failed: %v", err)
+	}
+	if len(result.Merged) != 1 || len(result.Reparented) != 1 || result.Reparented[0] != "reproduce-on-mobile" {
+		t.Errorf("Unexpected merge result %+v", result)
+	}
+
+	content, _ := manager.ReadTodoContent("fix-login-timeout-bug")
+	findings := sectionContent(content, "## Findings & Research")
+	if !strings.Contains(findings, "Auth client times out after 2s") ||
+		!strings.Contains(findings, "_Merged from fix-the-login-timeout:_") ||
+		!strings.Contains(findings, "Only happens on mobile networks") {
+		t.Errorf("Expected findings from both todos, got:\n%s", findings)
+	}
+	checklist := ParseChecklist(sectionContent(content, "## Checklist"))
+	if len(checklist) != 3 || checklist[0].Text != "Raise timeout" || checklist[0].Status != "pending" || checklist[2].Text != "Add a regression test" {
+		t.Errorf("Expected the union of both checklists, got %+v", checklist)
+	}
+
+	manager.UpdateTodo("fix-login-timeout-bug", "checklist", "append", "- [ ] Fix the client ^c1\n  - [ ] Raise the timeout", nil)
+	manager.CreateTodo("Fix login timeout again", "high", "bug")
+	manager.UpdateTodo("fix-login-timeout-again", "checklist", "append", "- [ ] Fix the client ^c1\n  - [ ] Retry once ^c1", nil)
+	if _, err := manager.MergeTodos("fix-login-timeout-bug", []string{"fix-login-timeout-again"}); err != nil {
+		t.Fatalf("Second merge failed: %v", err)
+	}
+	content, _ = manager.ReadTodoContent("fix-login-timeout-bug")
+	if !strings.Contains(content, "- [ ] Fix the client ^c1\n  - [ ] Raise the timeout\n  - [ ] Retry once\n") {
+		t.Errorf("Expected the sub-item nested under its parent without the clashing anchor, got:\n%s", sectionContent(content, "## Checklist"))
+	}
+
+	child, err := manager.ReadTodo("reproduce-on-mobile")
+	if err != nil || child.ParentID != "fix-login-timeout-bug" {
+		t.Errorf("Expected the child re-pointed at the survivor, got %+v (err %v)", child, err)
+	}
+
+	if _, err := manager.ReadTodo("fix-the-login-timeout"); err == nil {
+		t.Error("Expected the duplicate to be archived")
+	}
+	archived, err := manager.ReadArchivedTodo("fix-the-login-timeout")
+	if err != nil || archived.MergedInto != "fix-login-timeout-bug" {
+		t.Errorf("Expected merged_into on the archived duplicate, got %+v (err %v)", archived, err)
+	}
+
+	if _, err := manager.MergeTodos("fix-login-timeout-bug", []string{"fix-login-timeout-bug"}); err == nil {
+		t.Error("Expected merging a todo into itself to fail")
+	}
+}
+
+func TestMergeTodosIntoChildOfDuplicate(t *testing.T) {
+	manager := NewTodoManager(t.TempDir())
+	release, _ := manager.CreateTodo("Release 2.0", "high", "feature")
+	export, _ := manager.CreateTodoWithParent("Ship export", "high", "feature", release.ID)
+	csv, _ := manager.CreateTodoWithParent("Export CSV", "high", "subtask", export.ID)
+
+	result, err := manager.MergeTodos(csv.ID, []string{export.ID, export.ID})
+	if err != nil {
+		t.Fatalf("MergeTodos failed: %v", err)
+	}
+	if len(result.Merged) != 1 || len(result.Reparented) != 0 {
+		t.Errorf("Expected one merge and no re-parented children, got %+v", result)
+	}
+
+	survivor, err := manager.ReadTodo(csv.ID)
+	if err != nil || survivor.ParentID != release.ID {
+		t.Errorf("Expected the survivor to take the duplicate's parent, got %+v (err %v)", survivor, err)
+	}
+}
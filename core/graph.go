@@ -0,0 +1,133 @@
+package core
+
+import (
+	"sort"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+// TodoGraph is a set of todos to render as a diagram together with
+// the parent/child and dependency links between them
+type TodoGraph struct {
+	Todos    []*Todo
+	Archived map[string]bool // IDs of todos that were read from the archive
+}
+
+// NewTodoGraph creates a graph from todos, ordered parents-first so the
+// rendered diagram follows the hierarchy
+func NewTodoGraph(todos []*Todo) *TodoGraph {
+	roots, orphans := BuildTodoHierarchy(todos)
+	ordered := append(FlattenHierarchy(roots), orphans...)
+
+	// Todos hanging off a circular parent chain aren't reachable from the
+	// roots; keep them so every todo still gets a node
+	included := make(map[string]bool, len(ordered))
+	for _, todo := range ordered {
+		included[todo.ID] = true
+	}
+	for _, todo := range todos {
+		if !included[todo.ID] {
+			included[todo.ID] = true
+			ordered = append(ordered, todo)
+		}
+	}
+
+	return &TodoGraph{
+		Todos:    ordered,
+		Archived: make(map[string]bool),
+	}
+}
+
+// BuildTodoGraph collects the todos to draw for rootID: its ancestors and all
+// of its descendants. An empty rootID graphs every active todo. When
+// includeArchived is set, ancestors that have already been archived are
+// looked up in the archive so the tree isn't cut off at them.
+func (tm *TodoManager) BuildTodoGraph(rootID string, includeArchived bool) (*TodoGraph, error) {
+	todos, err := tm.ListTodos("", "", 0)
+	if err != nil {
+		return nil, interrors.Wrap(err, "failed to list todos")
+	}
+
+	todoMap := make(map[string]*Todo, len(todos))
+	for _, todo := range todos {
+		todoMap[todo.ID] = todo
+	}
+	archived := make(map[string]bool)
+
+	// lookup finds a todo among active todos, falling back to the archive
+	lookup := func(id string) *Todo {
+		if todo, ok := todoMap[id]; ok {
+			return todo
+		}
+		if !includeArchived {
+			return nil
+		}
+		todo, err := tm.ReadArchivedTodo(id)
+		if err != nil {
+			return nil
+		}
+		todoMap[id] = todo
+		archived[id] = true
+		return todo
+	}
+
+	// addAncestors pulls in the parent chain of todo, stopping at cycles
+	addAncestors := func(todo *Todo, selected map[string]*Todo) {
+		seen := map[string]bool{todo.ID: true}
+		for todo.ParentID != "" && !seen[todo.ParentID] {
+			parent := lookup(todo.ParentID)
+			if parent == nil {
+				return
+			}
+			seen[parent.ID] = true
+			selected[parent.ID] = parent
+			todo = parent
+		}
+	}
+
+	selected := make(map[string]*Todo)
+	if rootID == "" {
+		for _, todo := range todos {
+			selected[todo.ID] = todo
+		}
+		for _, todo := range todos {
+			addAncestors(todo, selected)
+		}
+	} else {
+		root := lookup(rootID)
+		if root == nil {
+			return nil, interrors.NewNotFoundError("todo", rootID)
+		}
+		selected[root.ID] = root
+		addAncestors(root, selected)
+
+		// Walk down the tree breadth-first
+		queue := []string{root.ID}
+		for len(queue) > 0 {
+			parentID := queue[0]
+			queue = queue[1:]
+			for _, todo := range todos {
+				if todo.ParentID == parentID && selected[todo.ID] == nil {
+					selected[todo.ID] = todo
+					queue = append(queue, todo.ID)
+				}
+			}
+		}
+	}
+
+	result := make([]*Todo, 0, len(selected))
+	for _, todo := range selected {
+		result = append(result, todo)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	graph := NewTodoGraph(result)
+	for id := range selected {
+		if archived[id] {
+			graph.Archived[id] = true
+		}
+	}
+	return graph, nil
+}
@@ -0,0 +1,241 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Graph output formats
+const (
+	GraphFormatMermaid = "mermaid"
+	GraphFormatDOT     = "dot"
+)
+
+// graphStatusColors are the node fill colours by status
+var graphStatusColors = map[string]string{
+	"pending":     "#eeeeee",
+	"in_progress": "#bbdefb",
+	"blocked":     "#ffcdd2",
+	"completed":   "#c8e6c9",
+}
+
+// graphPriorityStrokes are the node border colour and width by priority
+var graphPriorityStrokes = map[string]struct {
+	Color string
+	Width int
+}{
+	"high":   {"#d32f2f", 3},
+	"medium": {"#f57c00", 2},
+	"low":    {"#9e9e9e", 1},
+}
+
+var mermaidIDPattern = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// GraphFormatter renders a TodoGraph as a Mermaid or Graphviz DOT diagram
+type GraphFormatter struct {
+	// MaxLabelLength truncates task text in node labels (0 = no limit)
+	MaxLabelLength int
+}
+
+// NewGraphFormatter creates a new graph formatter with default settings
+func NewGraphFormatter() *GraphFormatter {
+	return &GraphFormatter{
+		MaxLabelLength: 50,
+	}
+}
+
+// IsValidGraphFormat reports whether format is a supported graph format
+func IsValidGraphFormat(format string) bool {
+	return format == GraphFormatMermaid || format == GraphFormatDOT
+}
+
+// Format renders the graph in the given format
+func (gf *GraphFormatter) Format(graph *TodoGraph, format string) (string, error) {
+	switch format {
+	case GraphFormatMermaid:
+		return gf.FormatMermaid(graph), nil
+	case GraphFormatDOT:
+		return gf.FormatDOT(graph), nil
+	}
+	return "", fmt.Errorf("unsupported graph format: %s", format)
+}
+
+// FormatMermaid renders the graph as a Mermaid "graph TD" block
+func (gf *GraphFormatter) FormatMermaid(graph *TodoGraph) string {
+	var result strings.Builder
+	result.WriteString("graph TD\n")
+
+	nodeIDs := gf.mermaidNodeIDs(graph.Todos)
+
+	// Nodes
+	for _, todo := range graph.Todos {
+		label := strings.ReplaceAll(gf.nodeLabel(todo, graph.Archived[todo.ID]), `"`, "#quot;")
+		result.WriteString(fmt.Sprintf("    %s[\"%s\"]\n", nodeIDs[todo.ID], strings.ReplaceAll(label, "\n", "<br/>")))
+	}
+
+	// Edges
+	for _, edge := range graphEdges(graph.Todos) {
+		from, to := nodeIDs[edge.From], nodeIDs[edge.To]
+		switch edge.Kind {
+		case "parent-child":
+			result.WriteString(fmt.Sprintf("    %s --> %s\n", from, to))
+		case "blocks":
+			result.WriteString(fmt.Sprintf("    %s -. blocks .-> %s\n", from, to))
+		case "relates-to":
+			result.WriteString(fmt.Sprintf("    %s -. relates-to .- %s\n", from, to))
+		}
+	}
+
+	// Styles
+	for _, todo := range graph.Todos {
+		fill, stroke, width := nodeColors(todo)
+		style := fmt.Sprintf("fill:%s,stroke:%s,stroke-width:%dpx", fill, stroke, width)
+		if graph.Archived[todo.ID] {
+			style += ",stroke-dasharray:5 5"
+		}
+		result.WriteString(fmt.Sprintf("    style %s %s\n", nodeIDs[todo.ID], style))
+	}
+
+	return result.String()
+}
+
+// FormatDOT renders the graph as a Graphviz DOT document
+func (gf *GraphFormatter) FormatDOT(graph *TodoGraph) string {
+	var result strings.Builder
+	result.WriteString("digraph todos {\n")
+	result.WriteString("    rankdir=TB;\n")
+	result.WriteString("    node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+
+	// Nodes
+	for _, todo := range graph.Todos {
+		fill, stroke, width := nodeColors(todo)
+		style := "rounded,filled"
+		if graph.Archived[todo.ID] {
+			style += ",dashed"
+		}
+		result.WriteString(fmt.Sprintf("    %s [label=%s, style=\"%s\", fillcolor=\"%s\", color=\"%s\", penwidth=%d];\n",
+			dotQuote(todo.ID), dotQuote(gf.nodeLabel(todo, graph.Archived[todo.ID])), style, fill, stroke, width))
+	}
+
+	// Edges
+	for _, edge := range graphEdges(graph.Todos) {
+		from, to := dotQuote(edge.From), dotQuote(edge.To)
+		switch edge.Kind {
+		case "parent-child":
+			result.WriteString(fmt.Sprintf("    %s -> %s;\n", from, to))
+		case "blocks":
+			result.WriteString(fmt.Sprintf("    %s -> %s [style=dashed, color=\"#d32f2f\", label=\"blocks\"];\n", from, to))
+		case "relates-to":
+			result.WriteString(fmt.Sprintf("    %s -> %s [style=dotted, dir=none, label=\"relates-to\"];\n", from, to))
+		}
+	}
+
+	result.WriteString("}\n")
+	return result.String()
+}
+
+// nodeLabel builds the two-line label for a todo node
+func (gf *GraphFormatter) nodeLabel(todo *Todo, archived bool) string {
+	task := todo.Task
+	if gf.MaxLabelLength > 0 && len([]rune(task)) > gf.MaxLabelLength {
+		task = string([]rune(task)[:gf.MaxLabelLength-3]) + "..."
+	}
+
+	label := todo.ID + "\n" + task
+	if archived {
+		label += "\n(archived)"
+	}
+	return label
+}
+
+// mermaidNodeIDs maps todo IDs to identifiers that are safe in Mermaid syntax
+func (gf *GraphFormatter) mermaidNodeIDs(todos []*Todo) map[string]string {
+	ids := make(map[string]string, len(todos))
+	used := make(map[string]bool, len(todos))
+	for _, todo := range todos {
+		base := "t_" + mermaidIDPattern.ReplaceAllString(todo.ID, "_")
+		id := base
+		for n := 2; used[id]; n++ {
+			id = fmt.Sprintf("%s_%d", base, n)
+		}
+		used[id] = true
+		ids[todo.ID] = id
+	}
+	return ids
+}
+
+// graphEdge is a directed link between two todos in a graph
+type graphEdge struct {
+	From string
+	To   string
+	Kind string // parent-child, blocks or relates-to
+}
+
+// graphEdges collects the links between todos that are both in the graph.
+// Blocks edges come from the blocker's side and relates-to edges are emitted
+// once per pair.
+func graphEdges(todos []*Todo) []graphEdge {
+	present := make(map[string]bool, len(todos))
+	for _, todo := range todos {
+		present[todo.ID] = true
+	}
+
+	var edges []graphEdge
+	for _, todo := range todos {
+		if todo.ParentID != "" && present[todo.ParentID] {
+			edges = append(edges, graphEdge{From: todo.ParentID, To: todo.ID, Kind: "parent-child"})
+		}
+	}
+
+	for _, todo := range todos {
+		for _, blockedID := range todo.Blocks {
+			if present[blockedID] {
+				edges = append(edges, graphEdge{From: todo.ID, To: blockedID, Kind: "blocks"})
+			}
+		}
+	}
+
+	seenRelated := make(map[string]bool)
+	for _, todo := range todos {
+		for _, relatedID := range todo.Related {
+			if !present[relatedID] {
+				continue
+			}
+			key := todo.ID + "\x00" + relatedID
+			if relatedID < todo.ID {
+				key = relatedID + "\x00" + todo.ID
+			}
+			if seenRelated[key] {
+				continue
+			}
+			seenRelated[key] = true
+			edges = append(edges, graphEdge{From: todo.ID, To: relatedID, Kind: "relates-to"})
+		}
+	}
+
+	return edges
+}
+
+// nodeColors returns fill colour (status) and border colour/width (priority)
+func nodeColors(todo *Todo) (fill, stroke string, width int) {
+	fill, ok := graphStatusColors[todo.Status]
+	if !ok {
+		fill = "#ffffff"
+	}
+
+	priorityStroke, ok := graphPriorityStrokes[todo.Priority]
+	if !ok {
+		priorityStroke = graphPriorityStrokes["medium"]
+	}
+
+	return fill, priorityStroke.Color, priorityStroke.Width
+}
+
+// dotQuote quotes a string for use as a DOT ID or label
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
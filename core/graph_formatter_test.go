@@ -0,0 +1,182 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func graphTestTodos() []*Todo {
+	return []*Todo{
+		{
+			ID:       "api-project",
+			Task:     "Build the \"v2\" API",
+			Status:   "in_progress",
+			Priority: "high",
+			Type:     "multi-phase",
+			Started:  time.Now(),
+		},
+		{
+			ID:       "design-api",
+			Task:     "Design API",
+			Status:   "completed",
+			Priority: "medium",
+			Type:     "phase",
+			ParentID: "api-project",
+			Blocks:   []string{"implement-endpoints"},
+			Started:  time.Now(),
+		},
+		{
+			ID:        "implement-endpoints",
+			Task:      "Implement endpoints",
+			Status:    "pending",
+			Priority:  "low",
+			Type:      "phase",
+			ParentID:  "api-project",
+			BlockedBy: []string{"design-api"},
+			Related:   []string{"write-docs"},
+			Started:   time.Now(),
+		},
+		{
+			ID:       "write-docs",
+			Task:     "Write docs",
+			Status:   "blocked",
+			Priority: "medium",
+			Type:     "feature",
+			Related:  []string{"implement-endpoints"},
+			Started:  time.Now(),
+		},
+	}
+}
+
+func TestGraphFormatterMermaid(t *testing.T) {
+	graph := NewTodoGraph(graphTestTodos())
+	output := NewGraphFormatter().FormatMermaid(graph)
+
+	t.Logf("Mermaid output:\n%s", output)
+
+	if !strings.HasPrefix(output, "graph TD\n") {
+		t.Error("Mermaid output should start with 'graph TD'")
+	}
+
+	expected := []string{
+		`t_api_project["api-project<br/>Build the #quot;v2#quot; API"]`,
+		"t_api_project --> t_design_api",
+		"t_api_project --> t_implement_endpoints",
+		"t_design_api -. blocks .-> t_implement_endpoints",
+		"style t_api_project fill:#bbdefb,stroke:#d32f2f,stroke-width:3px",
+		"style t_write_docs fill:#ffcdd2",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Mermaid output should contain %q", want)
+		}
+	}
+
+	// Related links are symmetric but drawn once
+	if count := strings.Count(output, "relates-to"); count != 1 {
+		t.Errorf("Expected 1 relates-to edge, got %d", count)
+	}
+}
+
+func TestGraphFormatterDOT(t *testing.T) {
+	graph := NewTodoGraph(graphTestTodos())
+	graph.Archived["api-project"] = true
+	output := NewGraphFormatter().FormatDOT(graph)
+
+	t.Logf("DOT output:\n%s", output)
+
+	expected := []string{
+		"digraph todos {",
+		`"api-project" [label="api-project\nBuild the \"v2\" API\n(archived)", style="rounded,filled,dashed"`,
+		`"api-project" -> "design-api";`,
+		`"design-api" -> "implement-endpoints" [style=dashed`,
+		`[style=dotted, dir=none, label="relates-to"]`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("DOT output should contain %q", want)
+		}
+	}
+
+	if !strings.HasSuffix(output, "}\n") {
+		t.Error("DOT output should close the digraph")
+	}
+}
+
+func TestGraphFormatterUnsupportedFormat(t *testing.T) {
+	_, err := NewGraphFormatter().Format(NewTodoGraph(graphTestTodos()), "svg")
+	if err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestBuildTodoGraphWithArchivedAncestors(t *testing.T) {
+	manager, _, cleanup := SetupTestTodoManager(t)
+	defer cleanup()
+
+	parent, err := manager.CreateTodo("Parent project", "high", "multi-phase")
+	if err != nil {
+		t.Fatalf("Failed to create parent: %v", err)
+	}
+	child, err := manager.CreateTodoWithParent("Child phase", "medium", "phase", parent.ID)
+	if err != nil {
+		t.Fatalf("Failed to create child: %v", err)
+	}
+	grandchild, err := manager.CreateTodoWithParent("Grandchild task", "low", "subtask", child.ID)
+	if err != nil {
+		t.Fatalf("Failed to create grandchild: %v", err)
+	}
+	unrelated, err := manager.CreateTodo("Unrelated work", "low", "feature")
+	if err != nil {
+		t.Fatalf("Failed to create unrelated todo: %v", err)
+	}
+
+	// Finished phases can stay active after their parent is archived
+	for _, id := range []string{grandchild.ID, child.ID} {
+		if err := manager.UpdateTodo(id, "", "", "", map[string]string{"status": "completed"}); err != nil {
+			t.Fatalf("Failed to complete %s: %v", id, err)
+		}
+	}
+	if err := manager.ArchiveTodo(parent.ID); err != nil {
+		t.Fatalf("Failed to archive parent: %v", err)
+	}
+
+	// Without archived ancestors the tree starts at the child
+	graph, err := manager.BuildTodoGraph(child.ID, false)
+	if err != nil {
+		t.Fatalf("Failed to build graph: %v", err)
+	}
+	ids := graphIDs(graph)
+	if !ids[child.ID] || !ids[grandchild.ID] || ids[parent.ID] || ids[unrelated.ID] {
+		t.Errorf("Unexpected graph nodes: %v", ids)
+	}
+
+	// With archived ancestors the parent is pulled back in and marked
+	graph, err = manager.BuildTodoGraph(grandchild.ID, true)
+	if err != nil {
+		t.Fatalf("Failed to build graph: %v", err)
+	}
+	ids = graphIDs(graph)
+	if !ids[parent.ID] || !ids[child.ID] || !ids[grandchild.ID] {
+		t.Errorf("Expected archived ancestors in graph, got: %v", ids)
+	}
+	if !graph.Archived[parent.ID] || graph.Archived[child.ID] {
+		t.Errorf("Expected only the parent to be marked archived, got: %v", graph.Archived)
+	}
+	if graph.Todos[0].ID != parent.ID {
+		t.Errorf("Expected archived root first, got %s", graph.Todos[0].ID)
+	}
+
+	if _, err := manager.BuildTodoGraph("missing-todo", true); err == nil {
+		t.Error("Expected error for missing root todo")
+	}
+}
+
+func graphIDs(graph *TodoGraph) map[string]bool {
+	ids := make(map[string]bool)
+	for _, todo := range graph.Todos {
+		ids[todo.ID] = true
+	}
+	return ids
+}
@@ -1,10 +1,11 @@
 package core
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"strings"
+	"sort"
 	
 	interrors "github.com/user/mcp-todo-server/internal/errors"
 )
@@ -40,19 +41,198 @@ func (tl *TodoLinker) LinkTodos(parentID, childID, linkType string) error {
 		return interrors.Wrap(err, "failed to read child todo")
 	}
 
-	// For parent-child link, update the child's parent_id
-	if linkType == "parent-child" {
+	switch linkType {
+	case "parent-child":
+		// For parent-child link, update the child's parent_id
 		metadata := map[string]string{
 			"parent_id": parentID,
 		}
 		return tl.manager.UpdateTodo(childID, "", "", "", metadata)
+	case "blocks":
+		// parentID blocks childID: the child can't start until the parent is done
+		return tl.linkBlocks(parentID, childID)
+	case "relates-to":
+		return tl.linkRelated(parentID, childID)
 	}
 
-	// For other link types, could store in a separate links file or in metadata
-	// For now, only support parent-child
 	return interrors.NewValidationError("linkType", linkType, "unsupported link type")
 }
 
+// linkBlocks records that blockerID blocks blockedID on both todos
+func (tl *TodoLinker) linkBlocks(blockerID, blockedID string) error {
+	if blockerID == blockedID {
+		return interrors.NewValidationError("child_id", blockedID, "a todo cannot block itself")
+	}
+
+	// Reject links that would close a dependency cycle
+	if tl.manager.blocksTransitively(blockedID, blockerID) {
+		return interrors.NewValidationError("child_id", blockedID,
+			fmt.Sprintf("circular dependency: %s already blocks %s", blockedID, blockerID))
+	}
+
+	added := false
+	err := tl.manager.UpdateTodoFrontmatter(blockerID, func(todo *Todo) error {
+		before := len(todo.Blocks)
+		todo.Blocks = appendUniqueID(todo.Blocks, blockedID)
+		added = len(todo.Blocks) > before
+		return nil
+	})
+	if err != nil {
+		return interrors.Wrap(err, "failed to update blocking todo")
+	}
+
+	err = tl.manager.UpdateTodoFrontmatter(blockedID, func(todo *Todo) error {
+		todo.BlockedBy = appendUniqueID(todo.BlockedBy, blockerID)
+		return nil
+	})
+	if err != nil {
+		// Don't leave the link recorded on one side only
+		if added {
+			tl.manager.UpdateTodoFrontmatter(blockerID, func(todo *Todo) error {
+				todo.Blocks = removeID(todo.Blocks, blockedID)
+				return nil
+			})
+		}
+		return interrors.Wrap(err, "failed to update blocked todo")
+	}
+
+	return nil
+}
+
+// linkRelated records a symmetric relates-to link between two todos
+func (tl *TodoLinker) linkRelated(firstID, secondID string) error {
+	if firstID == secondID {
+		return interrors.NewValidationError("child_id", secondID, "a todo cannot relate to itself")
+	}
+
+	added := false
+	err := tl.manager.UpdateTodoFrontmatter(firstID, func(todo *Todo) error {
+		before := len(todo.Related)
+		todo.Related = appendUniqueID(todo.Related, secondID)
+		added = len(todo.Related) > before
+		return nil
+	})
+	if err != nil {
+		return interrors.Wrap(err, "failed to update related todo")
+	}
+
+	err = tl.manager.UpdateTodoFrontmatter(secondID, func(todo *Todo) error {
+		todo.Related = appendUniqueID(todo.Related, firstID)
+		return nil
+	})
+	if err != nil {
+		// Don't leave the link recorded on one side only
+		if added {
+			tl.manager.UpdateTodoFrontmatter(firstID, func(todo *Todo) error {
+				todo.Related = removeID(todo.Related, secondID)
+				return nil
+			})
+		}
+		return interrors.Wrap(err, "failed to update related todo")
+	}
+
+	return nil
+}
+
+// appendUniqueID appends id to ids unless it is already present
+func appendUniqueID(ids []string, id string) []string {
+	for _, existing := range ids {
+		if existing == id {
+			return ids
+		}
+	}
+	return append(ids, id)
+}
+
+// removeID returns ids without id
+func removeID(ids []string, id string) []string {
+	var kept []string
+	for _, existing := range ids {
+		if existing != id {
+			kept = append(kept, existing)
+		}
+	}
+	return kept
+}
+
+// blocksTransitively reports whether fromID blocks toID directly or through
+// a chain of blocks links. Todos that can no longer be read are skipped.
+func (tm *TodoManager) blocksTransitively(fromID, toID string) bool {
+	visited := map[string]bool{}
+	queue := []string{fromID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		todo, err := tm.ReadTodo(current)
+		if err != nil {
+			continue
+		}
+		for _, next := range todo.Blocks {
+			if next == toID {
+				return true
+			}
+			queue = append(queue, next)
+		}
+	}
+	return false
+}
+
+// GetOpenBlockers returns the IDs of todos blocking id that are still open.
+// Blockers that are completed, archived or missing count as resolved.
+func (tm *TodoManager) GetOpenBlockers(id string) ([]string, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	filename, err := ResolveTodoPath(tm.basePath, id)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, interrors.NewNotFoundError("todo", id)
+		}
+		return nil, interrors.Wrap(err, "failed to resolve todo path")
+	}
+
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, interrors.Wrap(err, "failed to read todo")
+	}
+
+	todo, err := tm.parseTodoFile(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	return tm.openBlockers(todo), nil
+}
+
+// openBlockers checks each blocked_by entry without taking the manager lock,
+// so it can be called from inside UpdateTodo.
+func (tm *TodoManager) openBlockers(todo *Todo) []string {
+	var open []string
+	for _, blockerID := range todo.BlockedBy {
+		filename, err := ResolveTodoPath(tm.basePath, blockerID)
+		if err != nil {
+			continue
+		}
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			continue
+		}
+		blocker, err := tm.parseTodoFile(string(content))
+		if err != nil {
+			continue
+		}
+		if !blocker.IsCompleted() {
+			open = append(open, blockerID)
+		}
+	}
+	return open
+}
+
 // CreateTodoWithParent creates a new todo with a parent reference
 func (tm *TodoManager) CreateTodoWithParent(task, priority, todoType, parentID string) (*Todo, error) {
 	// Validate parent exists
@@ -80,7 +260,9 @@ func (tm *TodoManager) CreateTodoWithParent(task, priority, todoType, parentID s
 		err = tm.UpdateTodo(todo.ID, "", "", "", metadata)
 		if err != nil {
 			// Clean up the created todo on failure
-			os.Remove(filepath.Join(tm.basePath, ".claude", "todos", todo.ID+".md"))
+			todoPath := filepath.Join(tm.basePath, ".claude", "todos", todo.ID+".md")
+			os.Remove(todoPath)
+			tm.cache.refresh(todoPath)
 			return nil, interrors.Wrap(err, "failed to set parent_id")
 		}
 		todo.ParentID = parentID
@@ -89,38 +271,17 @@ func (tm *TodoManager) CreateTodoWithParent(task, priority, todoType, parentID s
 	return todo, nil
 }
 
-// GetChildren returns all todos that have the given parent_id
+// GetChildren returns all todos that have the given parent_id, ordered by ID
 func (tm *TodoManager) GetChildren(parentID string) ([]*Todo, error) {
-	// Read all todos in the directory
-	todosDir := filepath.Join(tm.basePath, ".claude", "todos")
-	files, err := ioutil.ReadDir(todosDir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			// No todos directory exists yet
-			return []*Todo{}, nil
-		}
-		return nil, interrors.Wrap(err, "failed to read todos directory")
-	}
-
 	var children []*Todo
-	for _, file := range files {
-		if !file.IsDir() && strings.HasSuffix(file.Name(), ".md") {
-			// Extract ID from filename
-			todoID := strings.TrimSuffix(file.Name(), ".md")
-
-			// Read the todo
-			todo, err := tm.ReadTodo(todoID)
-			if err != nil {
-				// Skip files that can't be read as todos
-				continue
-			}
-
-			// Check if it's a child of the requested parent
-			if todo.ParentID == parentID {
-				children = append(children, todo)
-			}
+	for _, todo := range tm.cache.todos() {
+		if todo.ParentID == parentID {
+			children = append(children, todo)
 		}
 	}
 
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].ID < children[j].ID
+	})
 	return children, nil
 }
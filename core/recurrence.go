@@ -0,0 +1,311 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+// Recurrence frequencies (RRULE FREQ values)
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+)
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// occurrenceSuffix matches the date suffix added to recurring todo IDs
+var occurrenceSuffix = regexp.MustCompile(`-\d{4}-\d{2}-\d{2}$`)
+
+// RecurrenceRule is the supported subset of an iCalendar RRULE:
+//
+//	FREQ=DAILY                  every day
+//	FREQ=DAILY;INTERVAL=3       every 3 days
+//	FREQ=WEEKLY;BYDAY=MO,TH     weekly on Monday and Thursday
+//	FREQ=MONTHLY;BYMONTHDAY=15  monthly on the 15th (-1 = last day)
+type RecurrenceRule struct {
+	Freq       string
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay int // 0 = same day of month as the previous occurrence
+}
+
+// ParseRecurrence parses a recurrence rule. An optional "RRULE:" prefix is
+// accepted, and a bare "daily", "weekly" or "monthly" is shorthand for FREQ.
+func ParseRecurrence(value string) (*RecurrenceRule, error) {
+	rule := strings.ToUpper(strings.TrimSpace(value))
+	rule = strings.TrimPrefix(rule, "RRULE:")
+	if rule == FreqDaily || rule == FreqWeekly || rule == FreqMonthly {
+		rule = "FREQ=" + rule
+	}
+
+	r := &RecurrenceRule{Interval: 1}
+	for _, part := range strings.Split(rule, ";") {
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, interrors.NewValidationError("recurrence", value, fmt.Sprintf("malformed rule part %q", part))
+		}
+
+		switch kv[0] {
+		case "FREQ":
+			if kv[1] != FreqDaily && kv[1] != FreqWeekly && kv[1] != FreqMonthly {
+				return nil, interrors.NewValidationError("recurrence", value, "FREQ must be DAILY, WEEKLY or MONTHLY")
+			}
+			r.Freq = kv[1]
+		case "INTERVAL":
+			n, err := strconv.Atoi(kv[1])
+			if err != nil || n < 1 {
+				return nil, interrors.NewValidationError("recurrence", value, "INTERVAL must be a positive number")
+			}
+			r.Interval = n
+		case "BYDAY":
+			for _, day := range strings.Split(kv[1], ",") {
+				weekday, ok := rruleWeekdays[day]
+				if !ok {
+					return nil, interrors.NewValidationError("recurrence", value, fmt.Sprintf("unknown BYDAY value %q", day))
+				}
+				r.ByDay = append(r.ByDay, weekday)
+			}
+			sort.Slice(r.ByDay, func(i, j int) bool { return r.ByDay[i] < r.ByDay[j] })
+		case "BYMONTHDAY":
+			n, err := strconv.Atoi(kv[1])
+			if err != nil || n == 0 || n < -31 || n > 31 {
+				return nil, interrors.NewValidationError("recurrence", value, "BYMONTHDAY must be 1..31 or -31..-1")
+			}
+			r.ByMonthDay = n
+		default:
+			return nil, interrors.NewValidationError("recurrence", value, fmt.Sprintf("unsupported rule part %s", kv[0]))
+		}
+	}
+
+	if r.Freq == "" {
+		return nil, interrors.NewValidationError("recurrence", value, "FREQ is required")
+	}
+	if len(r.ByDay) > 0 && r.Freq != FreqWeekly {
+		return nil, interrors.NewValidationError("recurrence", value, "BYDAY is only supported with FREQ=WEEKLY")
+	}
+	if r.ByMonthDay != 0 && r.Freq != FreqMonthly {
+		return nil, interrors.NewValidationError("recurrence", value, "BYMONTHDAY is only supported with FREQ=MONTHLY")
+	}
+
+	return r, nil
+}
+
+// String returns the rule in normalized RRULE form
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, weekday := range r.ByDay {
+			for name, wd := range rruleWeekdays {
+				if wd == weekday {
+					days[i] = name
+				}
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.ByMonthDay != 0 {
+		parts = append(parts, fmt.Sprintf("BYMONTHDAY=%d", r.ByMonthDay))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence strictly after the given time, keeping
+// its time of day
+func (r *RecurrenceRule) Next(after time.Time) time.Time {
+	switch r.Freq {
+	case FreqDaily:
+		return after.AddDate(0, 0, r.Interval)
+
+	case FreqWeekly:
+		if len(r.ByDay) == 0 {
+			return after.AddDate(0, 0, 7*r.Interval)
+		}
+		// Step through the days after `after`, only accepting weeks that are
+		// a multiple of the interval away from the starting week
+		weekStart := after.AddDate(0, 0, -int(after.Weekday()))
+		for i := 1; i <= 7*(r.Interval+1); i++ {
+			candidate := after.AddDate(0, 0, i)
+			weeks := daysUntil(candidate, weekStart) / 7
+			if weeks%r.Interval != 0 {
+				continue
+			}
+			for _, weekday := range r.ByDay {
+				if candidate.Weekday() == weekday {
+					return candidate
+				}
+			}
+		}
+		return after.AddDate(0, 0, 7*r.Interval)
+
+	case FreqMonthly:
+		if r.ByMonthDay != 0 {
+			// Later this month still counts as the next occurrence
+			if candidate := monthDay(after, 0, r.ByMonthDay); candidate.After(after) {
+				return candidate
+			}
+			return monthDay(after, r.Interval, r.ByMonthDay)
+		}
+		return monthDay(after, r.Interval, after.Day())
+	}
+
+	return after
+}
+
+// monthDay returns t moved forward by months with the day of month set to
+// day, clamped to the length of that month. Negative days count from the end.
+func monthDay(t time.Time, months, day int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	if day < 0 {
+		day = lastDay + day + 1
+	}
+	if day < 1 {
+		day = 1
+	}
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+// nextOccurrenceDate advances from base until the occurrence isn't in the
+// past, so todos completed late don't spawn an already-overdue instance
+func (r *RecurrenceRule) nextOccurrenceDate(base, now time.Time) time.Time {
+	next := r.Next(base)
+	for daysUntil(next, now) < 0 {
+		next = r.Next(next)
+	}
+	return next
+}
+
+// CreateNextOccurrence creates the next instance of a recurring todo. It keeps
+// the task, tags, section layout and checklist (unchecked), moves the due and
+// scheduled dates forward and links back through previous_occurrence.
+func (tm *TodoManager) CreateNextOccurrence(prev *Todo, prevContent string) (*Todo, error) {
+	rule, err := ParseRecurrence(prev.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	next := &Todo{
+		Task:               prev.Task,
+		Started:            now,
+		Status:             "in_progress",
+		Priority:           prev.Priority,
+		Type:               prev.Type,
+		ParentID:           prev.ParentID,
+		Tags:               prev.Tags,
+		Recurrence:         rule.String(),
+		PreviousOccurrence: prev.ID,
+		Sections:           prev.Sections,
+	}
+
+	// Advance whichever dates the previous occurrence had
+	switch {
+	case prev.HasDue():
+		next.Due = rule.nextOccurrenceDate(prev.Due, now)
+		if !prev.Scheduled.IsZero() {
+			next.Scheduled = prev.Scheduled.Add(next.Due.Sub(prev.Due))
+		}
+	case !prev.Scheduled.IsZero():
+		next.Scheduled = rule.nextOccurrenceDate(prev.Scheduled, now)
+	default:
+		next.Due = rule.Next(startOfDay(now, now))
+	}
+
+	occurrenceDate := next.Due
+	if occurrenceDate.IsZero() {
+		occurrenceDate = next.Scheduled
+	}
+
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	// ID is the series base plus the occurrence date, e.g. dependency-audit-2026-10-23
+	baseID := occurrenceSuffix.ReplaceAllString(prev.ID, "") + "-" + occurrenceDate.Format("2006-01-02")
+	next.ID = baseID
+	for n := 2; tm.todoFileExists(next.ID); n++ {
+		next.ID = fmt.Sprintf("%s-%d", baseID, n)
+	}
+
+	if err := tm.writeTodoWithContent(next, recurrenceBody(prev, prevContent)); err != nil {
+		return nil, interrors.Wrap(err, "failed to write next occurrence")
+	}
+
+	return next, nil
+}
+
+// todoFileExists reports whether an active or archived todo already uses id
+func (tm *TodoManager) todoFileExists(id string) bool {
+	if _, err := ResolveTodoPath(tm.basePath, id); err == nil {
+		return true
+	}
+	_, err := ResolveArchivedTodoPath(tm.basePath, id)
+	return err == nil
+}
+
+// recurrenceBody rebuilds the markdown body for the next occurrence: section
+// headings are kept, checklist items are kept but unchecked, and everything
+// else (findings, results, notes) starts empty
+func recurrenceBody(prev *Todo, content string) string {
+	parts := strings.SplitN(content, "---", 3)
+	body := content
+	if len(parts) == 3 {
+		body = parts[2]
+	}
+
+	// Checklist sections are found by schema, with the standard titles as fallback
+	checklistTitles := map[string]bool{"Checklist": true, "Test List": true}
+	for _, section := range prev.Sections {
+		if section != nil && section.Schema == SchemaChecklist {
+			checklistTitles[strings.TrimSpace(strings.TrimPrefix(section.Title, "## "))] = true
+		}
+	}
+
+	var result strings.Builder
+	inChecklist := false
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "## ") {
+			if result.Len() > 0 {
+				result.WriteString("\n")
+			}
+			result.WriteString(trimmed + "\n\n")
+			inChecklist = checklistTitles[strings.TrimSpace(strings.TrimPrefix(trimmed, "## "))]
+			continue
+		}
+
+		if inChecklist && checklistItemPattern.MatchString(line) {
+			result.WriteString(checklistItemPattern.ReplaceAllString(line, "${1}- [ ] ") + "\n")
+		}
+	}
+
+	return result.String()
+}
+
+// checklistItemPattern matches a checklist item prefix in any state
+var checklistItemPattern = regexp.MustCompile(`^(\s*)- \[[ xX>~-]\] `)
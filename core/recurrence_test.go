@@ -0,0 +1,144 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"FREQ=DAILY", "FREQ=DAILY", false},
+		{"daily", "FREQ=DAILY", false},
+		{"RRULE:FREQ=WEEKLY;BYDAY=TH,MO", "FREQ=WEEKLY;BYDAY=MO,TH", false},
+		{"freq=daily;interval=3", "FREQ=DAILY;INTERVAL=3", false},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1", "FREQ=MONTHLY;BYMONTHDAY=-1", false},
+		{"FREQ=YEARLY", "", true},
+		{"FREQ=DAILY;BYDAY=MO", "", true},
+		{"FREQ=WEEKLY;BYDAY=XX", "", true},
+		{"FREQ=DAILY;INTERVAL=0", "", true},
+		{"INTERVAL=2", "", true},
+	}
+
+	for _, tt := range tests {
+		rule, err := ParseRecurrence(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRecurrence(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if err == nil && rule.String() != tt.want {
+			t.Errorf("ParseRecurrence(%q) = %s, want %s", tt.input, rule.String(), tt.want)
+		}
+	}
+}
+
+func TestRecurrenceRuleNext(t *testing.T) {
+	// 2026-10-15 is a Thursday
+	thursday := time.Date(2026, 10, 15, 9, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		rule  string
+		after time.Time
+		want  time.Time
+	}{
+		{"FREQ=DAILY;INTERVAL=3", thursday, time.Date(2026, 10, 18, 9, 0, 0, 0, time.Local)},
+		{"FREQ=WEEKLY", thursday, time.Date(2026, 10, 22, 9, 0, 0, 0, time.Local)},
+		{"FREQ=WEEKLY;BYDAY=MO,TH", thursday, time.Date(2026, 10, 19, 9, 0, 0, 0, time.Local)},
+		{"FREQ=WEEKLY;BYDAY=FR", thursday, time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", thursday, time.Date(2026, 10, 26, 9, 0, 0, 0, time.Local)},
+		{"FREQ=MONTHLY", thursday, time.Date(2026, 11, 15, 9, 0, 0, 0, time.Local)},
+		{"FREQ=MONTHLY;BYMONTHDAY=20", thursday, time.Date(2026, 10, 20, 9, 0, 0, 0, time.Local)},
+		{"FREQ=MONTHLY;BYMONTHDAY=1", thursday, time.Date(2026, 11, 1, 9, 0, 0, 0, time.Local)},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1", time.Date(2026, 10, 31, 0, 0, 0, 0, time.Local), time.Date(2026, 11, 30, 0, 0, 0, 0, time.Local)},
+		{"FREQ=MONTHLY;BYMONTHDAY=31", time.Date(2027, 1, 31, 0, 0, 0, 0, time.Local), time.Date(2027, 2, 28, 0, 0, 0, 0, time.Local)},
+	}
+
+	for _, tt := range tests {
+		rule, err := ParseRecurrence(tt.rule)
+		if err != nil {
+			t.Fatalf("ParseRecurrence(%q) failed: %v", tt.rule, err)
+		}
+		if got := rule.Next(tt.after); !got.Equal(tt.want) {
+			t.Errorf("%s after %s = %s, want %s", tt.rule, tt.after.Format("Mon 2006-01-02"), got.Format("Mon 2006-01-02"), tt.want.Format("Mon 2006-01-02"))
+		}
+	}
+}
+
+func TestCreateNextOccurrence(t *testing.T) {
+	manager, _, cleanup := SetupTestTodoManager(t)
+	defer cleanup()
+
+	todo, err := manager.CreateTodo("Weekly dependency audit", "medium", "chore")
+	if err != nil {
+		t.Fatalf("Failed to create todo: %v", err)
+	}
+
+	due := startOfDay(time.Now(), time.Now()).AddDate(0, 0, 1)
+	err = manager.UpdateTodo(todo.ID, "", "", "", map[string]string{
+		"due":        FormatDueDate(due),
+		"recurrence": "weekly",
+	})
+	if err != nil {
+		t.Fatalf("Failed to set recurrence: %v", err)
+	}
+	if err := manager.UpdateTodo(todo.ID, "checklist", "replace", "- [x] Run audit\n- [>] Bump versions\n  - [x] Update lockfile", nil); err != nil {
+		t.Fatalf("Failed to update checklist: %v", err)
+	}
+	if err := manager.UpdateTodo(todo.ID, "findings", "replace", "Two outdated packages", nil); err != nil {
+		t.Fatalf("Failed to update findings: %v", err)
+	}
+
+	prev, err := manager.ReadTodo(todo.ID)
+	if err != nil {
+		t.Fatalf("Failed to read todo: %v", err)
+	}
+	if prev.Recurrence != "FREQ=WEEKLY" {
+		t.Errorf("Expected normalized recurrence, got %q", prev.Recurrence)
+	}
+	content, err := manager.ReadTodoContent(todo.ID)
+	if err != nil {
+		t.Fatalf("Failed to read content: %v", err)
+	}
+
+	next, err := manager.CreateNextOccurrence(prev, content)
+	if err != nil {
+		t.Fatalf("Failed to create next occurrence: %v", err)
+	}
+
+	wantDue := due.AddDate(0, 0, 7)
+	if next.ID != todo.ID+"-"+wantDue.Format("2006-01-02") {
+		t.Errorf("Unexpected next ID: %s", next.ID)
+	}
+	if !next.Due.Equal(wantDue) {
+		t.Errorf("Expected due %s, got %s", FormatDueDate(wantDue), FormatDueDate(next.Due))
+	}
+	if next.PreviousOccurrence != todo.ID || next.Recurrence != "FREQ=WEEKLY" {
+		t.Errorf("Expected series fields to carry over, got previous=%q recurrence=%q", next.PreviousOccurrence, next.Recurrence)
+	}
+
+	nextContent, err := manager.ReadTodoContent(next.ID)
+	if err != nil {
+		t.Fatalf("Failed to read next occurrence: %v", err)
+	}
+	for _, want := range []string{"- [ ] Run audit", "- [ ] Bump versions", "  - [ ] Update lockfile", "## Findings & Research"} {
+		if !strings.Contains(nextContent, want) {
+			t.Errorf("Next occurrence should contain %q, got:\n%s", want, nextContent)
+		}
+	}
+	if strings.Contains(nextContent, "- [x]") || strings.Contains(nextContent, "Two outdated packages") {
+		t.Errorf("Next occurrence should start fresh, got:\n%s", nextContent)
+	}
+
+	// A second spawn for the same date gets a numeric suffix
+	again, err := manager.CreateNextOccurrence(prev, content)
+	if err != nil {
+		t.Fatalf("Failed to create second occurrence: %v", err)
+	}
+	if again.ID != next.ID+"-2" {
+		t.Errorf("Expected %s-2, got %s", next.ID, again.ID)
+	}
+}
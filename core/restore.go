@@ -0,0 +1,139 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+// RestoreTodo moves an archived todo back into the date-based todos tree and
+// reopens it as in_progress. The completed timestamp is cleared unless
+// keepCompleted is set.
+func (tm *TodoManager) RestoreTodo(id string, keepCompleted bool) (*Todo, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	sourcePath, err := ResolveArchivedTodoPath(tm.basePath, id)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, interrors.NewNotFoundError("archived todo", id)
+		}
+		return nil, interrors.Wrap(err, "failed to resolve archived todo path")
+	}
+
+	// Restoring over an active todo would lose one of them
+	if _, err := ResolveTodoPath(tm.basePath, id); err == nil {
+		return nil, interrors.NewConflictError("todo", id, "an active todo with this ID already exists")
+	}
+
+	content, err := ioutil.ReadFile(sourcePath)
+	if err != nil {
+		return nil, interrors.Wrap(err, "failed to read archived todo")
+	}
+
+	parts := strings.SplitN(string(content), "---\n", 3)
+	if len(parts) < 3 {
+		return nil, interrors.NewValidationError("content", string(content), "invalid markdown format: missing frontmatter delimiters")
+	}
+
+	var frontmatter map[string]interface{}
+	if err := yaml.Unmarshal([]byte(parts[1]), &frontmatter); err != nil {
+		return nil, interrors.Wrap(err, "failed to parse YAML frontmatter")
+	}
+	if frontmatter == nil {
+		frontmatter = make(map[string]interface{})
+	}
+
+	frontmatter["status"] = "in_progress"
+	if !keepCompleted {
+		delete(frontmatter, "completed")
+	}
+
+	yamlData, err := yaml.Marshal(frontmatter)
+	if err != nil {
+		return nil, interrors.Wrap(err, "failed to marshal YAML")
+	}
+	updatedContent := "---\n" + string(yamlData) + "---\n" + parts[2]
+
+	todo, err := tm.ParseTodoFileContent(id, updatedContent)
+	if err != nil {
+		return nil, interrors.Wrap(err, "failed to parse restored todo")
+	}
+
+	// Back to the day folder it was started in, like a freshly created todo
+	started := todo.Started
+	if started.IsZero() {
+		started = time.Now()
+	}
+	destPath := GetDateBasedTodoPath(tm.basePath, id, started)
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return nil, interrors.NewOperationError("create", "todo directory", "failed to create todo directory", err)
+	}
+
+	tempPath := destPath + ".tmp"
+	if err := ioutil.WriteFile(tempPath, []byte(updatedContent), 0644); err != nil {
+		return nil, interrors.NewOperationError("write", "temp todo file", "failed to write temp file", err)
+	}
+	if err := os.Rename(tempPath, destPath); err != nil {
+		os.Remove(tempPath)
+		return nil, interrors.NewOperationError("rename", "todo file", "failed to finalize restore", err)
+	}
+
+	// Remove the archived copy only once the active one is in place
+	if err := os.Remove(sourcePath); err != nil {
+		os.Remove(destPath)
+		return nil, interrors.NewOperationError("remove", "archived todo file", "failed to remove archived file after restore", err)
+	}
+
+	globalPathCache.Set(id, destPath)
+	tm.cache.refresh(destPath)
+
+	return todo, nil
+}
+
+// RestoreTodoWithCascade restores a todo and, with cascade, every archived
+// descendant. Restored todos are returned parent first.
+func (tm *TodoManager) RestoreTodoWithCascade(id string, keepCompleted bool, cascade bool) ([]*Todo, error) {
+	todo, err := tm.RestoreTodo(id, keepCompleted)
+	if err != nil {
+		return nil, err
+	}
+	restored := []*Todo{todo}
+	if !cascade {
+		return restored, nil
+	}
+
+	archived, err := tm.ListArchivedTodos(time.Time{})
+	if err != nil {
+		return restored, interrors.Wrap(err, "failed to list archived children")
+	}
+
+	// Walk down the archived tree breadth-first
+	queue := []string{id}
+	visited := map[string]bool{id: true}
+	for len(queue) > 0 {
+		parentID := queue[0]
+		queue = queue[1:]
+		for _, child := range archived {
+			if child.ParentID != parentID || visited[child.ID] {
+				continue
+			}
+			visited[child.ID] = true
+
+			restoredChild, err := tm.RestoreTodo(child.ID, keepCompleted)
+			if err != nil {
+				return restored, interrors.Wrapf(err, "failed to restore child %s", child.ID)
+			}
+			restored = append(restored, restoredChild)
+			queue = append(queue, child.ID)
+		}
+	}
+
+	return restored, nil
+}
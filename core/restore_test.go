@@ -0,0 +1,102 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRestoreTodo(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := NewTodoManager(tempDir)
+
+	todo, _ := manager.CreateTodo("Reopened bug", "high", "bug")
+	if err := manager.ArchiveTodo(todo.ID); err != nil {
+		t.Fatalf("Failed to archive: %v", err)
+	}
+
+	restored, err := manager.RestoreTodo(todo.ID, false)
+	if err != nil {
+		t.Fatalf("Failed to restore: %v", err)
+	}
+	if restored.Status != "in_progress" || !restored.Completed.IsZero() {
+		t.Errorf("Expected in_progress with no completed time, got %s / %v", restored.Status, restored.Completed)
+	}
+
+	expected := GetDateBasedTodoPath(tempDir, todo.ID, todo.Started)
+	if path, err := ResolveTodoPath(tempDir, todo.ID); err != nil || path != expected {
+		t.Errorf("Expected todo back at %s, got %s (err %v)", expected, path, err)
+	}
+	if _, err := ResolveArchivedTodoPath(tempDir, todo.ID); !os.IsNotExist(err) {
+		t.Errorf("Expected archived copy to be gone, got %v", err)
+	}
+	if todos, _ := manager.ListTodos("", "", 0); len(todos) != 1 {
+		t.Errorf("Expected restored todo to be listed, got %d todos", len(todos))
+	}
+
+	// Nothing left to restore
+	if _, err := manager.RestoreTodo(todo.ID, false); err == nil {
+		t.Error("Expected restoring an active todo to fail")
+	}
+
+	// keep_completed preserves the timestamp
+	manager.ArchiveTodo(todo.ID)
+	restored, err = manager.RestoreTodo(todo.ID, true)
+	if err != nil || restored.Completed.IsZero() || restored.Status != "in_progress" {
+		t.Errorf("Expected completed time to be kept, got %+v (err %v)", restored, err)
+	}
+}
+
+func TestRestoreTodoConflict(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := NewTodoManager(tempDir)
+
+	todo, _ := manager.CreateTodo("Duplicate id", "medium", "feature")
+	manager.ArchiveTodo(todo.ID)
+
+	// A fresh manager (e.g. after a restart) hands out the same ID again
+	if again, _ := NewTodoManager(tempDir).CreateTodo("Duplicate id", "medium", "feature"); again.ID != todo.ID {
+		t.Fatalf("Expected ID %s to be reused, got %s", todo.ID, again.ID)
+	}
+
+	if _, err := manager.RestoreTodo(todo.ID, false); err == nil {
+		t.Fatal("Expected conflict with the active todo of the same ID")
+	}
+	if _, err := ResolveArchivedTodoPath(tempDir, todo.ID); err != nil {
+		t.Errorf("Archived copy should be left alone on conflict: %v", err)
+	}
+}
+
+func TestRestoreTodoWithCascade(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := NewTodoManager(tempDir)
+
+	parent, _ := manager.CreateTodo("Release", "high", "multi-phase")
+	child, _ := manager.CreateTodoWithParent("Build", "high", "phase", parent.ID)
+	grandchild, _ := manager.CreateTodoWithParent("Compile", "low", "subtask", child.ID)
+	for _, id := range []string{grandchild.ID, child.ID, parent.ID} {
+		manager.UpdateTodo(id, "", "", "", map[string]string{"status": "completed"})
+		if err := manager.ArchiveTodo(id); err != nil {
+			t.Fatalf("Failed to archive %s: %v", id, err)
+		}
+	}
+
+	restored, err := manager.RestoreTodoWithCascade(parent.ID, false, true)
+	if err != nil {
+		t.Fatalf("Failed to restore: %v", err)
+	}
+	if len(restored) != 3 || restored[0].ID != parent.ID {
+		t.Fatalf("Expected parent and two descendants, got %v", restored)
+	}
+	archiveRoot := filepath.Join(tempDir, ".claude", "archive")
+	for _, id := range []string{parent.ID, child.ID, grandchild.ID} {
+		if _, err := ResolveArchivedTodoPath(tempDir, id); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be gone from %s", id, archiveRoot)
+		}
+	}
+
+	children, _ := manager.GetChildren(parent.ID)
+	if len(children) != 1 || children[0].ID != child.ID {
+		t.Errorf("Expected %s restored as child, got %v", child.ID, children)
+	}
+}
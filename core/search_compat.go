@@ -2,6 +2,7 @@ package core
 
 import (
 	"github.com/user/mcp-todo-server/internal/domain"
+	domainSearch "github.com/user/mcp-todo-server/internal/domain/search"
 	"github.com/user/mcp-todo-server/internal/search"
 )
 
@@ -22,8 +23,18 @@ func NewSearchEngine(indexPath, todosPath string) (*SearchAdapter, error) {
 
 // IndexTodo indexes a single todo (for updates)
 func (a *SearchAdapter) IndexTodo(todo *Todo, content string) error {
-	// Convert core.Todo to domain.Todo
-	domainTodo := &domain.Todo{
+	return a.engine.Index(toDomainTodo(todo), content)
+}
+
+// IndexArchivedTodo keeps an archived todo in the index, flagged as archived
+// with its location relative to the project
+func (a *SearchAdapter) IndexArchivedTodo(todo *Todo, content, archivePath string) error {
+	return a.engine.IndexArchived(toDomainTodo(todo), content, archivePath)
+}
+
+// toDomainTodo converts core.Todo to domain.Todo
+func toDomainTodo(todo *Todo) *domain.Todo {
+	return &domain.Todo{
 		ID:        todo.ID,
 		Task:      todo.Task,
 		Started:   todo.Started,
@@ -34,7 +45,6 @@ func (a *SearchAdapter) IndexTodo(todo *Todo, content string) error {
 		ParentID:  todo.ParentID,
 		Tags:      todo.Tags,
 	}
-	return a.engine.Index(domainTodo, content)
 }
 
 // DeleteTodo removes a todo from the index
@@ -48,8 +58,44 @@ func (a *SearchAdapter) SearchTodos(queryStr string, filters map[string]string,
 	if err != nil {
 		return nil, err
 	}
+	return toSearchResults(results), nil
+}
+
+// SearchTodosWithFacets searches like SearchTodos and also reports the total
+// number of matches and facet counts over all of them
+func (a *SearchAdapter) SearchTodosWithFacets(queryStr string, filters map[string]string, limit int) (*SearchResponse, error) {
+	response, err := a.engine.SearchWithFacets(queryStr, filters, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchResponse{
+		Results: toSearchResults(response.Results),
+		Total:   response.Total,
+		Facets:  response.Facets,
+	}, nil
+}
+
+// SimilarTodos returns the todos most like the given one, archived included,
+// scored by cosine similarity
+func (a *SearchAdapter) SimilarTodos(id string, limit int) ([]SearchResult, error) {
+	results, err := a.engine.SimilarTodos(id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toSearchResults(results), nil
+}
 
-	// Convert domain search results to core search results
+// SimilarToText returns the todos most like free text, archived included
+func (a *SearchAdapter) SimilarToText(text string, limit int) ([]SearchResult, error) {
+	results, err := a.engine.SimilarToText(text, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toSearchResults(results), nil
+}
+
+// toSearchResults converts domain search results to core search results
+func toSearchResults(results []domainSearch.Result) []SearchResult {
 	coreResults := make([]SearchResult, len(results))
 	for i, r := range results {
 		coreResults[i] = SearchResult{
@@ -57,10 +103,14 @@ func (a *SearchAdapter) SearchTodos(queryStr string, filters map[string]string,
 			Task:    r.Task,
 			Score:   r.Score,
 			Snippet: r.Snippet,
+
+			Archived:    r.Archived,
+			ArchivePath: r.ArchivePath,
+
+			Highlights: r.Highlights,
 		}
 	}
-
-	return coreResults, nil
+	return coreResults
 }
 
 // Close closes the search index
@@ -73,5 +123,27 @@ func (a *SearchAdapter) GetIndexedCount() (uint64, error) {
 	return a.engine.GetIndexedCount()
 }
 
+// IndexHealth reports documents indexed against files on disk, stale
+// entries and the circuit breaker state
+func (a *SearchAdapter) IndexHealth() IndexHealth {
+	return a.engine.Health()
+}
+
+// StartReindex re-indexes in the background (full, incremental or rebuild);
+// the returned channel closes when it is done
+func (a *SearchAdapter) StartReindex(mode string, progress func(done, total int)) (<-chan struct{}, error) {
+	return a.engine.StartReindex(mode, progress)
+}
+
+// LastReindex returns the running or most recent reindex, or nil
+func (a *SearchAdapter) LastReindex() *ReindexStatus {
+	return a.engine.LastReindex()
+}
+
+// CompactIndex merges the index segments into one
+func (a *SearchAdapter) CompactIndex() error {
+	return a.engine.Compact()
+}
+
 // SearchEngine is a type alias for backward compatibility
 type SearchEngine = SearchAdapter
\ No newline at end of file
@@ -1,16 +1,51 @@
 package core
 
 import (
+	domainSearch "github.com/user/mcp-todo-server/internal/domain/search"
 	"github.com/user/mcp-todo-server/internal/search"
 )
 
 // TodoDocument is a type alias for backward compatibility
 type TodoDocument = search.Document
 
+// SearchHighlight is a matched fragment and the section it came from
+type SearchHighlight = domainSearch.Highlight
+
 // SearchResult represents a search result (kept in core for compatibility)
 type SearchResult struct {
 	ID      string
 	Task    string
 	Score   float64
 	Snippet string
-}
\ No newline at end of file
+
+	Archived    bool
+	ArchivePath string // relative to the project, set for archived todos
+
+	Highlights []SearchHighlight
+}
+
+// SearchFacet counts matching todos by the values of one field
+type SearchFacet = domainSearch.Facet
+
+// SearchFacetValue is one facet value with its count
+type SearchFacetValue = domainSearch.FacetValue
+
+// SearchResponse is a page of search results with totals and facet counts
+type SearchResponse struct {
+	Results []SearchResult
+	Total   uint64
+	Facets  []SearchFacet
+}
+
+// Reindex modes accepted by StartReindex
+const (
+	ReindexFull        = search.ReindexFull
+	ReindexIncremental = search.ReindexIncremental
+	ReindexRebuild     = search.ReindexRebuild
+)
+
+// IndexHealth compares the search index with the todo files on disk
+type IndexHealth = domainSearch.IndexHealth
+
+// ReindexStatus tracks a background reindex
+type ReindexStatus = domainSearch.ReindexStatus
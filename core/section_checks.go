@@ -0,0 +1,134 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+// ProjectSettingsFile holds per-project options, under the project's .claude
+// directory
+const ProjectSettingsFile = "todo-settings.yaml"
+
+// ProjectSettings are the options a project can set in ProjectSettingsFile
+type ProjectSettings struct {
+	// Strict rejects section content that doesn't match the section's
+	// schema instead of saving it with a warning
+	Strict bool `yaml:"strict"`
+}
+
+// resultsTimestampPattern matches the stamp formatWithTimestamp puts on
+// results entries
+var resultsTimestampPattern = regexp.MustCompile(`^\[\d{4}-\d{2}-\d{2} \d{2}:\d{2}(:\d{2})?\]`)
+
+// LoadProjectSettings reads the settings for the project at basePath. A
+// missing file means the defaults.
+func LoadProjectSettings(basePath string) (*ProjectSettings, error) {
+	settings := &ProjectSettings{}
+	data, err := ioutil.ReadFile(filepath.Join(basePath, ".claude", ProjectSettingsFile))
+	if os.IsNotExist(err) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, interrors.Wrap(err, "failed to read project settings")
+	}
+	if err := yaml.Unmarshal(data, settings); err != nil {
+		return nil, interrors.NewValidationError(ProjectSettingsFile, string(data), fmt.Sprintf("invalid settings: %v", err))
+	}
+	return settings, nil
+}
+
+// checkSectionContent returns what is wrong with content written to a
+// section, judged by the section's validator
+func checkSectionContent(validator SectionValidator, schema SectionSchema, content string) []string {
+	var problems []string
+	if validator != nil {
+		if err := validator.Validate(content); err != nil {
+			problem := err.Error()
+			var validationErr *interrors.ValidationError
+			if errors.As(err, &validationErr) {
+				problem = validationErr.Message
+				if line, ok := validationErr.Value.(string); ok && line != "" {
+					problem += fmt.Sprintf(": %q", line)
+				}
+			}
+			problems = append(problems, problem)
+		}
+	}
+
+	// The results validator accepts anything because appends to test_results
+	// are stamped automatically; other writes must bring their own stamps
+	if schema == SchemaResults {
+		for _, entry := range resultsEntries(content) {
+			if !resultsTimestampPattern.MatchString(entry) {
+				problems = append(problems, fmt.Sprintf("results entry has no [YYYY-MM-DD HH:MM:SS] timestamp: %q", entry))
+			}
+		}
+	}
+	return problems
+}
+
+// resultsEntries returns the first line of each blank-line separated entry,
+// skipping the inside of code fences
+func resultsEntries(content string) []string {
+	var entries []string
+	inFence := false
+	startOfEntry := true
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			startOfEntry = !inFence
+			continue
+		}
+		if startOfEntry {
+			entries = append(entries, trimmed)
+			startOfEntry = false
+		}
+		if strings.Count(trimmed, "```")%2 == 1 {
+			inFence = !inFence
+		}
+	}
+	return entries
+}
+
+// sectionDefinitionFor returns a section's definition, falling back to the
+// standard sections for todos without section metadata
+func sectionDefinitionFor(sections map[string]*SectionDefinition, key string) *SectionDefinition {
+	if def, ok := sections[key]; ok && def != nil {
+		return def
+	}
+	return getDefaultSections()[key]
+}
+
+// emptyRequiredSections lists the required sections of a todo file that
+// have no content, in section order
+func emptyRequiredSections(sections map[string]*SectionDefinition, content string) []string {
+	bodies := SectionBodies(sections, content)
+
+	var empty []string
+	for _, section := range GetOrderedSections(sections) {
+		if section.Definition.Required && sectionIsEmpty(section.Definition, bodies[section.Key]) {
+			empty = append(empty, section.Key)
+		}
+	}
+	return empty
+}
+
+// sectionIsEmpty reports whether a section body has no content; a table
+// with only its header row is empty
+func sectionIsEmpty(def *SectionDefinition, body string) bool {
+	if def != nil && def.Schema == SchemaTable {
+		if header, rows, stray := parseTable(body); stray == "" && header != nil {
+			return len(rows) == 0
+		}
+	}
+	return strings.TrimSpace(body) == ""
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+func TestUpdateTodoSectionSchemaWarnings(t *testing.T) {
+	manager := NewTodoManager(t.TempDir())
+	todo, _ := manager.CreateTodo("Tune cache eviction", "high", "feature")
+
+	warnings, err := manager.UpdateTodoSection(todo.ID, "checklist", "append", "- [ ] Measure hit rate\nAsk ops about limits")
+	if err != nil {
+		t.Fatalf("UpdateTodoSection failed: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0] != `checklist (checklist): non-checklist content found: "Ask ops about limits"` {
+		t.Errorf("Expected a checklist warning, got %v", warnings)
+	}
+	content, _ := manager.ReadTodoContent(todo.ID)
+	if !strings.Contains(content, "Ask ops about limits") {
+		t.Error("Expected the content to be saved despite the warning")
+	}
+
+	// Appends to test_results are stamped; replacements must carry stamps
+	if warnings, _ := manager.UpdateTodoSection(todo.ID, "test_results", "append", "All green"); len(warnings) != 0 {
+		t.Errorf("Expected a stamped append to pass, got %v", warnings)
+	}
+	warnings, _ = manager.UpdateTodoSection(todo.ID, "test_results", "replace", "[2026-01-02 10:00:00] 3 failures\n\nRetried: all green")
+	if len(warnings) != 1 || !strings.Contains(warnings[0], `no [YYYY-MM-DD HH:MM:SS] timestamp: "Retried: all green"`) {
+		t.Errorf("Expected the unstamped entry to be reported, got %v", warnings)
+	}
+
+	if warnings, _ := manager.UpdateTodoSection(todo.ID, "findings", "append", "Anything goes here"); len(warnings) != 0 {
+		t.Errorf("Expected research content to pass, got %v", warnings)
+	}
+}
+
+func TestUpdateTodoSectionStrict(t *testing.T) {
+	basePath := t.TempDir()
+	manager := NewTodoManager(basePath)
+	todo, _ := manager.CreateTodo("Tune cache eviction", "high", "feature")
+
+	os.WriteFile(filepath.Join(basePath, ".claude", ProjectSettingsFile), []byte("strict: true\n"), 0644)
+
+	if _, err := manager.UpdateTodoSection(todo.ID, "checklist", "append", "Ask ops about limits"); !interrors.IsValidation(err) {
+		t.Errorf("Expected strict mode to reject non-checklist content, got %v", err)
+	}
+	if err := manager.UpdateTodo(todo.ID, "tests", "append", "should evict oldest first", nil); !interrors.IsValidation(err) {
+		t.Errorf("Expected UpdateTodo to enforce strict mode too, got %v", err)
+	}
+	content, _ := manager.ReadTodoContent(todo.ID)
+	if strings.Contains(content, "Ask ops") || strings.Contains(content, "evict oldest") {
+		t.Errorf("Expected rejected content not to be written, got:\n%s", content)
+	}
+
+	if warnings, err := manager.UpdateTodoSection(todo.ID, "checklist", "append", "- [ ] Ask ops about limits"); err != nil || len(warnings) != 0 {
+		t.Errorf("Expected valid content to be accepted, got %v (err %v)", warnings, err)
+	}
+
+	os.WriteFile(filepath.Join(basePath, ".claude", ProjectSettingsFile), []byte("strict: [yes\n"), 0644)
+	if _, err := manager.UpdateTodoSection(todo.ID, "checklist", "append", "Not a box"); err == nil {
+		t.Error("Expected unreadable settings to be reported")
+	}
+}
+
+func TestCompletingRequiresRequiredSections(t *testing.T) {
+	manager := NewTodoManager(t.TempDir())
+	todo, _ := manager.CreateTodo("Write incident review", "high", "research")
+	manager.UpdateTodoFrontmatter(todo.ID, func(todo *Todo) error {
+		todo.Sections["findings"].Required = true
+		todo.Sections["checklist"].Required = true
+		return nil
+	})
+	manager.UpdateTodo(todo.ID, "checklist", "append", "- [x] Timeline", nil)
+
+	err := manager.UpdateTodo(todo.ID, "", "", "", map[string]string{"status": "completed"})
+	if !interrors.IsValidation(err) || !strings.Contains(err.Error(), "required sections are still empty: findings") {
+		t.Fatalf("Expected completion to be refused, got %v", err)
+	}
+
+	manager.UpdateTodo(todo.ID, "findings", "append", "Root cause was a stale DNS entry", nil)
+	if err := manager.UpdateTodo(todo.ID, "", "", "", map[string]string{"status": "completed"}); err != nil {
+		t.Errorf("Expected completion once required sections have content, got %v", err)
+	}
+}
@@ -19,6 +19,9 @@ const (
 	SchemaTestCases SectionSchema = "test_cases" // Code blocks
 	SchemaResults   SectionSchema = "results"    // Timestamped logs
 	SchemaFreeform  SectionSchema = "freeform"   // No validation
+	SchemaDecisions SectionSchema = "decisions"  // Dated ADR-style entries
+	SchemaTable     SectionSchema = "table"      // Markdown table
+	SchemaLinks     SectionSchema = "links"      // URLs, file paths and todo references
 )
 
 // SectionDefinition represents metadata for a todo section
@@ -71,7 +74,8 @@ func ParseSectionDefinitions(yamlData []byte) (map[string]*SectionDefinition, er
 // isValidSchema checks if a schema type is valid
 func isValidSchema(schema SectionSchema) bool {
 	switch schema {
-	case SchemaResearch, SchemaStrategy, SchemaChecklist, SchemaTestCases, SchemaResults, SchemaFreeform:
+	case SchemaResearch, SchemaStrategy, SchemaChecklist, SchemaTestCases, SchemaResults, SchemaFreeform,
+		SchemaDecisions, SchemaTable, SchemaLinks:
 		return true
 	default:
 		return false
@@ -93,6 +97,12 @@ func GetValidator(schema SectionSchema) SectionValidator {
 		return &ResultsValidator{}
 	case SchemaFreeform:
 		return &FreeformValidator{}
+	case SchemaDecisions:
+		return &DecisionsValidator{}
+	case SchemaTable:
+		return &TableValidator{}
+	case SchemaLinks:
+		return &LinksValidator{}
 	default:
 		return nil
 	}
@@ -292,6 +302,10 @@ var standardSectionMappings = map[string]struct {
 	"## Test Results Log":         {Key: "test_results", Schema: SchemaResults},
 	"## Checklist":                {Key: "checklist", Schema: SchemaChecklist},
 	"## Working Scratchpad":       {Key: "scratchpad", Schema: SchemaFreeform},
+	"## Decisions":                {Key: "decisions", Schema: SchemaDecisions},
+	"## Decision Log":             {Key: "decisions", Schema: SchemaDecisions},
+	"## Links":                    {Key: "links", Schema: SchemaLinks},
+	"## References":               {Key: "links", Schema: SchemaLinks},
 }
 
 // InferSectionsFromMarkdown analyzes markdown content to infer section definitions
@@ -331,6 +345,21 @@ func InferSectionsFromMarkdown(content string) map[string]*SectionDefinition {
 		}
 	}
 
+	// Custom sections holding a table, decision log or link list get
+	// that schema
+	_, blocks := splitTodoBody(content)
+	for _, block := range blocks {
+		def := sections[generateSectionKey(block.heading)]
+		if def == nil || !def.Custom || def.Title != block.heading {
+			continue
+		}
+		schema, columns := inferSchemaFromBody(block.body)
+		def.Schema = schema
+		if columns != nil {
+			def.Metadata = map[string]interface{}{"columns": columns}
+		}
+	}
+
 	return sections
 }
 
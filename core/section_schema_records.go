@@ -0,0 +1,399 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+// decisionHeadingPattern matches "### 2026-03-02 Title" and captures the date
+var decisionHeadingPattern = regexp.MustCompile(`^###\s+(\d{4}-\d{2}-\d{2})\b`)
+
+// decisionFieldPattern matches "Decision: ...", "- **Rationale:** ..." and
+// similar field lines
+var decisionFieldPattern = regexp.MustCompile(`(?i)^(?:[-*]\s+)?(?:\*\*)?(decision|alternatives|rationale)(?:\*\*)?\s*:(?:\*\*)?\s*(.*)$`)
+
+// tableSeparatorCell matches a cell of the row under a table header
+var tableSeparatorCell = regexp.MustCompile(`^:?-{3,}:?$`)
+
+// listMarkerPattern matches the marker of a markdown list item
+var listMarkerPattern = regexp.MustCompile(`^[-*+]\s+`)
+
+// fileNamePattern matches a bare file name with an extension
+var fileNamePattern = regexp.MustCompile(`^[\w.-]+\.[A-Za-z0-9]+(:\d+(-\d+)?)?$`)
+
+// lineSuffixPattern matches a ":12" or ":12-20" line reference on a path
+var lineSuffixPattern = regexp.MustCompile(`:\d+(-\d+)?$`)
+
+// decisionFields are the fields every decision entry needs
+var decisionFields = []string{"decision", "alternatives", "rationale"}
+
+// DecisionsValidator validates a decision log: entries headed
+// "### YYYY-MM-DD title", each with Decision, Alternatives and Rationale
+type DecisionsValidator struct{}
+
+// decisionEntry is one parsed decision log entry
+type decisionEntry struct {
+	heading string
+	date    string
+	fields  map[string]bool // field name to whether it has text
+}
+
+// parseDecisions splits a decision log into entries. Text before the first
+// entry heading is returned as stray.
+func parseDecisions(content string) ([]decisionEntry, string) {
+	var entries []decisionEntry
+	stray := ""
+	field := ""
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "### ") {
+			entry := decisionEntry{heading: strings.TrimSpace(strings.TrimPrefix(trimmed, "### ")), fields: make(map[string]bool)}
+			if match := decisionHeadingPattern.FindStringSubmatch(trimmed); match != nil {
+				entry.date = match[1]
+			}
+			entries = append(entries, entry)
+			field = ""
+			continue
+		}
+		if len(entries) == 0 {
+			if stray == "" {
+				stray = trimmed
+			}
+			continue
+		}
+
+		entry := entries[len(entries)-1]
+		if match := decisionFieldPattern.FindStringSubmatch(trimmed); match != nil {
+			field = strings.ToLower(match[1])
+			entry.fields[field] = entry.fields[field] || match[2] != ""
+		} else if field != "" {
+			// Text under a field belongs to it
+			entry.fields[field] = true
+		}
+	}
+	return entries, stray
+}
+
+func (v *DecisionsValidator) Validate(content string) error {
+	entries, stray := parseDecisions(content)
+	if stray != "" {
+		return interrors.NewValidationError("decisions", stray, "decision entries start with a '### YYYY-MM-DD title' heading")
+	}
+	for _, entry := range entries {
+		if entry.date == "" {
+			return interrors.NewValidationError("decisions", entry.heading, "decision heading needs a YYYY-MM-DD date")
+		}
+		var missing []string
+		for _, field := range decisionFields {
+			if !entry.fields[field] {
+				missing = append(missing, field)
+			}
+		}
+		if len(missing) > 0 {
+			return interrors.NewValidationError("decisions", entry.heading,
+				fmt.Sprintf("decision is missing %s", strings.Join(missing, ", ")))
+		}
+	}
+	return nil
+}
+
+func (v *DecisionsValidator) GetMetrics(content string) map[string]interface{} {
+	entries, _ := parseDecisions(content)
+	latest := ""
+	for _, entry := range entries {
+		if entry.date > latest {
+			latest = entry.date
+		}
+	}
+	return map[string]interface{}{
+		"decisions": len(entries),
+		"latest":    latest,
+	}
+}
+
+// TableValidator validates a markdown table. With Columns set the header
+// must name them, and every row must have that many cells.
+type TableValidator struct {
+	Columns []string
+}
+
+// splitTableRow returns the cells of a "| a | b |" row
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	line = strings.ReplaceAll(line, `\|`, "\x00")
+
+	cells := strings.Split(line, "|")
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(strings.ReplaceAll(cell, "\x00", `\|`))
+	}
+	return cells
+}
+
+// isTableSeparator reports whether cells form a header separator row
+func isTableSeparator(cells []string) bool {
+	for _, cell := range cells {
+		if !tableSeparatorCell.MatchString(cell) {
+			return false
+		}
+	}
+	return len(cells) > 0
+}
+
+// parseTable returns a table's header (nil when the content is rows only,
+// as appended rows are) and data rows
+func parseTable(content string) (header []string, rows [][]string, stray string) {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			if !strings.HasPrefix(trimmed, "|") {
+				return nil, nil, trimmed
+			}
+			lines = append(lines, trimmed)
+		}
+	}
+	if len(lines) >= 2 && isTableSeparator(splitTableRow(lines[1])) {
+		header = splitTableRow(lines[0])
+		lines = lines[2:]
+	}
+	for _, line := range lines {
+		rows = append(rows, splitTableRow(line))
+	}
+	return header, rows, ""
+}
+
+func (v *TableValidator) Validate(content string) error {
+	header, rows, stray := parseTable(content)
+	if stray != "" {
+		return interrors.NewValidationError("table", stray, "non-table content found")
+	}
+
+	width := len(v.Columns)
+	if header != nil {
+		if width > 0 && !strings.EqualFold(strings.Join(header, "|"), strings.Join(v.Columns, "|")) {
+			return interrors.NewValidationError("table", strings.Join(header, " | "),
+				fmt.Sprintf("table columns should be: %s", strings.Join(v.Columns, " | ")))
+		}
+		width = len(header)
+	}
+	for i, row := range rows {
+		if width == 0 {
+			width = len(row)
+		}
+		if isTableSeparator(row) {
+			return interrors.NewValidationError("table", strings.Join(row, " | "), "separator row without a header above it")
+		}
+		if len(row) != width {
+			return interrors.NewValidationError("table", strings.Join(row, " | "),
+				fmt.Sprintf("row %d has %d cells, expected %d", i+1, len(row), width))
+		}
+	}
+	return nil
+}
+
+func (v *TableValidator) GetMetrics(content string) map[string]interface{} {
+	header, rows, _ := parseTable(content)
+	columns := len(v.Columns)
+	if header != nil {
+		columns = len(header)
+	}
+	return map[string]interface{}{
+		"rows":    len(rows),
+		"columns": columns,
+	}
+}
+
+// tableColumns reads the columns declared in a section's metadata
+func tableColumns(def *SectionDefinition) []string {
+	if def == nil {
+		return nil
+	}
+	var columns []string
+	switch value := def.Metadata["columns"].(type) {
+	case []string:
+		columns = value
+	case []interface{}:
+		for _, column := range value {
+			columns = append(columns, fmt.Sprint(column))
+		}
+	case string:
+		for _, column := range strings.Split(value, ",") {
+			columns = append(columns, strings.TrimSpace(column))
+		}
+	}
+	return columns
+}
+
+// LinksValidator validates a list of references: URLs, file paths and
+// todo:<id> entries. TodoExists, when set, is used to reject links to todos
+// that don't exist; Root, when set, is where file references are looked up
+// for the dangling_files metric.
+type LinksValidator struct {
+	TodoExists func(id string) bool
+	Root       string
+}
+
+// linkRefs are the references in a link list, by kind
+type linkRefs struct {
+	urls, files, todos []string
+	invalid            string
+}
+
+// parseLinks reads one reference per line, optionally a list item. An item
+// is a markdown link, or a reference optionally followed by a description.
+func parseLinks(content string) linkRefs {
+	var refs linkRefs
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		item := listMarkerPattern.ReplaceAllString(trimmed, "")
+
+		target := ""
+		if start := strings.Index(item, "]("); strings.HasPrefix(item, "[") && start != -1 {
+			if end := strings.Index(item[start:], ")"); end != -1 {
+				target = item[start+2 : start+end]
+			}
+		} else if fields := strings.Fields(item); len(fields) > 0 {
+			target = strings.Trim(fields[0], "`<>")
+		}
+
+		switch {
+		case strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://"):
+			refs.urls = append(refs.urls, target)
+		case strings.HasPrefix(target, "todo:") && len(target) > len("todo:"):
+			refs.todos = append(refs.todos, strings.TrimPrefix(target, "todo:"))
+		case !strings.Contains(target, "://") && (strings.Contains(target, "/") || fileNamePattern.MatchString(target)):
+			refs.files = append(refs.files, lineSuffixPattern.ReplaceAllString(target, ""))
+		default:
+			refs.invalid = trimmed
+			return refs
+		}
+	}
+	return refs
+}
+
+func (v *LinksValidator) Validate(content string) error {
+	refs := parseLinks(content)
+	if refs.invalid != "" {
+		return interrors.NewValidationError("links", refs.invalid, "expected a URL, file path or todo:<id> reference per list item")
+	}
+	if missing := v.missingTodos(refs.todos); len(missing) > 0 {
+		return interrors.NewValidationError("links", missing,
+			fmt.Sprintf("links to todos that don't exist: %s", strings.Join(missing, ", ")))
+	}
+	return nil
+}
+
+func (v *LinksValidator) GetMetrics(content string) map[string]interface{} {
+	refs := parseLinks(content)
+	metrics := map[string]interface{}{
+		"urls":  len(refs.urls),
+		"files": len(refs.files),
+		"todos": len(refs.todos),
+	}
+	if v.TodoExists != nil {
+		metrics["missing_todos"] = v.missingTodos(refs.todos)
+	}
+	if v.Root != "" {
+		dangling := []string{}
+		for _, file := range refs.files {
+			path := file
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(v.Root, path)
+			}
+			if _, err := os.Stat(path); err != nil {
+				dangling = append(dangling, file)
+			}
+		}
+		metrics["dangling_files"] = dangling
+	}
+	return metrics
+}
+
+// missingTodos returns the referenced todos that don't exist, when the
+// validator can check
+func (v *LinksValidator) missingTodos(ids []string) []string {
+	missing := []string{}
+	if v.TodoExists == nil {
+		return missing
+	}
+	for _, id := range ids {
+		if !v.TodoExists(id) {
+			missing = append(missing, id)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// GetValidatorFor returns the validator for a section, set up with what the
+// section declares, such as a table's columns
+func GetValidatorFor(def *SectionDefinition) SectionValidator {
+	if def == nil {
+		return nil
+	}
+	if def.Schema == SchemaTable {
+		return &TableValidator{Columns: tableColumns(def)}
+	}
+	return GetValidator(def.Schema)
+}
+
+// sectionValidator is GetValidatorFor with link lists checked against this
+// project's todos and files
+func (tm *TodoManager) sectionValidator(def *SectionDefinition) SectionValidator {
+	if def != nil && def.Schema == SchemaLinks {
+		return &LinksValidator{
+			TodoExists: func(id string) bool {
+				if _, err := ResolveTodoPath(tm.basePath, id); err == nil {
+					return true
+				}
+				_, err := ResolveArchivedTodoPath(tm.basePath, id)
+				return err == nil
+			},
+			Root: tm.basePath,
+		}
+	}
+	return GetValidatorFor(def)
+}
+
+// SectionMetrics returns the metrics of each section of a todo file, keyed
+// by section
+func (tm *TodoManager) SectionMetrics(todo *Todo, content string) map[string]map[string]interface{} {
+	bodies := SectionBodies(todo.Sections, content)
+	metrics := make(map[string]map[string]interface{})
+	for key, def := range todo.Sections {
+		if validator := tm.sectionValidator(def); validator != nil {
+			metrics[key] = validator.GetMetrics(bodies[key])
+		}
+	}
+	return metrics
+}
+
+// inferSchemaFromBody recognises a custom section's content as a table,
+// decision log or link list. It returns the table's columns for tables.
+func inferSchemaFromBody(body string) (SectionSchema, []string) {
+	if strings.TrimSpace(body) == "" {
+		return SchemaFreeform, nil
+	}
+	if header, _, stray := parseTable(body); stray == "" && header != nil {
+		return SchemaTable, header
+	}
+	if entries, stray := parseDecisions(body); stray == "" && len(entries) > 0 && (&DecisionsValidator{}).Validate(body) == nil {
+		return SchemaDecisions, nil
+	}
+	if refs := parseLinks(body); refs.invalid == "" && len(refs.urls)+len(refs.files)+len(refs.todos) > 0 {
+		return SchemaLinks, nil
+	}
+	return SchemaFreeform, nil
+}
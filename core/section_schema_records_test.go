@@ -0,0 +1,164 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRecordSchemaValidation(t *testing.T) {
+	decisions := "### 2026-03-02 Use SQLite for the index\nDecision: SQLite with FTS5\nAlternatives: Bleve, Postgres\nRationale: No server to run\n\n" +
+		"### 2026-03-09 Keep the cache\n- **Decision:** Keep it\n- **Alternatives:** Drop it\n- **Rationale:**\n  Reads dominate"
+
+	tests := []struct {
+		name      string
+		validator SectionValidator
+		content   string
+		errMsg    string
+	}{
+		{"decision log", &DecisionsValidator{}, decisions, ""},
+		{"decision without date", &DecisionsValidator{}, "### Use SQLite\nDecision: yes\nAlternatives: no\nRationale: fast", "needs a YYYY-MM-DD date"},
+		{"decision missing fields", &DecisionsValidator{}, "### 2026-03-02 Use SQLite\nDecision: yes", "decision is missing alternatives, rationale"},
+		{"text before the first decision", &DecisionsValidator{}, "We decided things\n### 2026-03-02 Use SQLite", "start with a '### YYYY-MM-DD title' heading"},
+
+		{"table", &TableValidator{}, "| Name | Owner |\n| --- | :---: |\n| cache | ops |", ""},
+		{"table with declared columns", &TableValidator{Columns: []string{"Name", "Owner"}}, "| name | owner |\n|---|---|\n| cache | ops |", ""},
+		{"appended rows", &TableValidator{Columns: []string{"Name", "Owner"}}, "| api | web |\n| db | data |", ""},
+		{"wrong columns", &TableValidator{Columns: []string{"Name", "Owner"}}, "| Name | Team |\n|---|---|", "table columns should be: Name | Owner"},
+		{"short row", &TableValidator{}, "| Name | Owner |\n|---|---|\n| cache |", "row 1 has 1 cells, expected 2"},
+		{"escaped pipe", &TableValidator{Columns: []string{"Expr", "Meaning"}}, `| a \| b | either |`, ""},
+		{"text in table", &TableValidator{}, "| Name |\n|---|\nSee above", "non-table content found"},
+
+		{"links", &LinksValidator{}, "- https://example.com/rfc\n- [Design](docs/design.md)\n- `core/search.go:40-60` where ranking happens\n- todo:fix-cache", ""},
+		{"unknown reference", &LinksValidator{}, "- https://example.com\n- ask Dana", "expected a URL, file path or todo:<id> reference"},
+		{"missing todo", &LinksValidator{TodoExists: func(id string) bool { return id == "fix-cache" }}, "todo:fix-cache\ntodo:gone\ntodo:also-gone", "links to todos that don't exist: also-gone, gone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validator.Validate(tt.content)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("Expected valid content, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestRecordSchemaMetrics(t *testing.T) {
+	decisions := (&DecisionsValidator{}).GetMetrics("### 2026-03-09 Keep the cache\nDecision: keep\n\n### 2026-04-01 Drop v1\nDecision: drop")
+	if decisions["decisions"] != 2 || decisions["latest"] != "2026-04-01" {
+		t.Errorf("Unexpected decision metrics: %v", decisions)
+	}
+
+	table := (&TableValidator{}).GetMetrics("| Name | Owner | Since |\n|---|---|---|\n| cache | ops | 2025 |\n| api | web | 2026 |")
+	if table["rows"] != 2 || table["columns"] != 3 {
+		t.Errorf("Unexpected table metrics: %v", table)
+	}
+
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "docs"), 0755)
+	os.WriteFile(filepath.Join(root, "docs", "design.md"), []byte("# Design"), 0644)
+	links := (&LinksValidator{Root: root}).GetMetrics("- docs/design.md:3\n- docs/gone.md\n- https://example.com\n- todo:fix-cache")
+	want := map[string]interface{}{"urls": 1, "files": 2, "todos": 1, "dangling_files": []string{"docs/gone.md"}}
+	if !reflect.DeepEqual(links, want) {
+		t.Errorf("Expected link metrics %v, got %v", want, links)
+	}
+}
+
+func TestRecordSectionsOnTodos(t *testing.T) {
+	basePath := t.TempDir()
+	manager := NewTodoManager(basePath)
+	target, _ := manager.CreateTodo("Fix cache", "high", "bug")
+	todo, _ := manager.CreateTodo("Plan storage", "high", "feature")
+
+	if _, err := manager.AddSection(todo.ID, "owners", &SectionDefinition{Schema: SchemaTable, Metadata: map[string]interface{}{"columns": []string{"Area", "Owner"}}}, 0); err != nil {
+		t.Fatalf("AddSection failed: %v", err)
+	}
+	manager.AddSection(todo.ID, "links", &SectionDefinition{Schema: SchemaLinks}, 0)
+
+	// Rows appended to a table continue it
+	if warnings, err := manager.UpdateTodoSection(todo.ID, "owners", "append", "| cache | ops |"); err != nil || len(warnings) != 0 {
+		t.Fatalf("Expected the row to be accepted, got %v (err %v)", warnings, err)
+	}
+	manager.UpdateTodoSection(todo.ID, "owners", "append", "| api | web |")
+	content, _ := manager.ReadTodoContent(todo.ID)
+	if !strings.Contains(content, "## Owners\n\n| Area | Owner |\n| --- | --- |\n| cache | ops |\n| api | web |") {
+		t.Errorf("Expected the rows under the header, got:\n%s", content)
+	}
+	warnings, _ := manager.UpdateTodoSection(todo.ID, "owners", "append", "| db |")
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "owners (table): row 1 has 1 cells, expected 2") {
+		t.Errorf("Expected a short row warning, got %v", warnings)
+	}
+
+	// Links to todos are checked against the project
+	warnings, _ = manager.UpdateTodoSection(todo.ID, "links", "append", "- todo:"+target.ID+"\n- todo:no-such-todo")
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "links to todos that don't exist: no-such-todo") {
+		t.Errorf("Expected a missing todo warning, got %v", warnings)
+	}
+
+	updated, content, _ := manager.ReadTodoWithContent(todo.ID)
+	metrics := manager.SectionMetrics(updated, content)
+	if metrics["owners"]["rows"] != 3 {
+		t.Errorf("Expected 3 rows, got %v", metrics["owners"])
+	}
+	if !reflect.DeepEqual(metrics["links"]["missing_todos"], []string{"no-such-todo"}) {
+		t.Errorf("Expected the missing todo in the metrics, got %v", metrics["links"])
+	}
+
+	// A table with only its header is empty
+	manager.AddSection(todo.ID, "costs", &SectionDefinition{Schema: SchemaTable, Metadata: map[string]interface{}{"columns": "Item, Cost"}}, 0)
+	if _, err := manager.RemoveSection(todo.ID, "costs"); err != nil {
+		t.Errorf("Expected a header-only table to be removable, got %v", err)
+	}
+}
+
+func TestInferRecordSections(t *testing.T) {
+	content := `# Task: Plan storage
+
+## Decision Log
+
+### 2026-03-02 Use SQLite
+Decision: SQLite
+Alternatives: Postgres
+Rationale: Nothing to run
+
+## Owners
+
+| Area | Owner |
+|------|-------|
+| cache | ops |
+
+## Reading
+
+- https://sqlite.org/fts5.html
+- core/search.go
+
+## Notes
+
+Plain text here.
+`
+	sections := InferSectionsFromMarkdown(content)
+
+	expected := map[string]SectionSchema{
+		"decisions": SchemaDecisions,
+		"owners":    SchemaTable,
+		"reading":   SchemaLinks,
+		"notes":     SchemaFreeform,
+	}
+	for key, schema := range expected {
+		if sections[key] == nil || sections[key].Schema != schema {
+			t.Errorf("Expected %s to be inferred as %s, got %+v", key, schema, sections[key])
+		}
+	}
+	if columns := tableColumns(sections["owners"]); !reflect.DeepEqual(columns, []string{"Area", "Owner"}) {
+		t.Errorf("Expected the table's columns to be kept, got %v", columns)
+	}
+}
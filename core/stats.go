@@ -3,13 +3,10 @@ package core
 import (
 	"io/ioutil"
 	"math"
-	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
-	
-	interrors "github.com/user/mcp-todo-server/internal/errors"
 )
 
 // TodoStats represents aggregated statistics about todos
@@ -20,6 +17,7 @@ type TodoStats struct {
 	BlockedTodos          int
 	TodosByType           map[string]int
 	TodosByPriority       map[string]int
+	TodosByTag            map[string]int
 	CompletionRates       map[string]float64
 	AverageCompletionTime time.Duration
 }
@@ -243,6 +241,7 @@ func (se *StatsEngine) generateStatsFromTodos(todos []*Todo) (*TodoStats, error)
 	stats := &TodoStats{
 		TodosByType:     make(map[string]int),
 		TodosByPriority: make(map[string]int),
+		TodosByTag:      make(map[string]int),
 		CompletionRates: make(map[string]float64),
 	}
 
@@ -273,6 +272,11 @@ func (se *StatsEngine) generateStatsFromTodos(todos []*Todo) (*TodoStats, error)
 			priority = "medium"
 		}
 		stats.TodosByPriority[priority]++
+
+		// Count by tag; untagged todos aren't counted
+		for _, tag := range todo.Tags {
+			stats.TodosByTag[strings.ToLower(tag)]++
+		}
 	}
 
 	// Calculate completion rates only from filtered todos
@@ -320,37 +324,9 @@ func (se *StatsEngine) generateStatsFromTodos(todos []*Todo) (*TodoStats, error)
 	return stats, nil
 }
 
-// Helper to get all todos
+// Helper to get all todos, served from the manager's metadata cache
 func (se *StatsEngine) getAllTodos() ([]*Todo, error) {
-	// Read all .md files in the todos directory
-	todosDir := filepath.Join(se.manager.basePath, ".claude", "todos")
-	files, err := ioutil.ReadDir(todosDir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			// No todos directory exists yet, return empty slice
-			return []*Todo{}, nil
-		}
-		return nil, interrors.Wrap(err, "failed to read todos directory")
-	}
-
-	var todos []*Todo
-	for _, file := range files {
-		if !file.IsDir() && strings.HasSuffix(file.Name(), ".md") {
-			// Extract ID from filename
-			todoID := strings.TrimSuffix(file.Name(), ".md")
-
-			// Read the todo
-			todo, err := se.manager.ReadTodo(todoID)
-			if err != nil {
-				// Skip files that can't be read as todos
-				continue
-			}
-
-			todos = append(todos, todo)
-		}
-	}
-
-	return todos, nil
+	return se.manager.cache.todos(), nil
 }
 
 // Helper to extract a section from markdown content
@@ -0,0 +1,162 @@
+package core
+
+import (
+	"strings"
+	"unicode"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+// NormalizeTag lowercases and trims a tag. Tags may contain letters, digits
+// and - _ / : . so "area/api" or "sprint:42" work as namespaces.
+func NormalizeTag(tag string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(tag), "#")))
+	if normalized == "" {
+		return "", interrors.NewValidationError("tags", tag, "tag cannot be empty")
+	}
+
+	for _, r := range normalized {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-_/:.", r) {
+			continue
+		}
+		return "", interrors.NewValidationError("tags", tag, "tags may only contain letters, digits and - _ / : .")
+	}
+
+	return normalized, nil
+}
+
+// NormalizeTags normalizes a list of tags, dropping duplicates while keeping
+// the original order
+func NormalizeTags(tags []string) ([]string, error) {
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		normalized, err := NormalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[normalized] {
+			seen[normalized] = true
+			result = append(result, normalized)
+		}
+	}
+	return result, nil
+}
+
+// ParseTagList splits a comma-separated tag list and normalizes it
+func ParseTagList(value string) ([]string, error) {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if strings.TrimSpace(tag) != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return NormalizeTags(tags)
+}
+
+// HasTag reports whether the todo carries the tag (case-insensitive)
+func (t *Todo) HasTag(tag string) bool {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	for _, existing := range t.Tags {
+		if strings.ToLower(existing) == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTagMetadata handles the tag keys of a metadata update: "tags"
+// replaces the set (empty clears it), "add_tags" and "remove_tags" edit it.
+// Each value is a comma-separated list.
+func applyTagMetadata(todo *Todo, metadata map[string]string) error {
+	replaceValue, hasReplace := metadata["tags"]
+	addValue, hasAdd := metadata["add_tags"]
+	removeValue, hasRemove := metadata["remove_tags"]
+	if !hasReplace && !hasAdd && !hasRemove {
+		return nil
+	}
+
+	var replace []string
+	if hasReplace {
+		parsed, err := ParseTagList(replaceValue)
+		if err != nil {
+			return err
+		}
+		replace = parsed
+	}
+	add, err := ParseTagList(addValue)
+	if err != nil {
+		return err
+	}
+	remove, err := ParseTagList(removeValue)
+	if err != nil {
+		return err
+	}
+
+	todo.Tags = applyTagUpdate(todo.Tags, replace, add, remove)
+	return nil
+}
+
+// applyTagUpdate adds and removes tags, or replaces the whole set when
+// replace is non-nil. The result keeps existing order with additions last.
+func applyTagUpdate(current, replace, add, remove []string) []string {
+	tags := current
+	if replace != nil {
+		tags = replace
+	}
+
+	removed := make(map[string]bool)
+	for _, tag := range remove {
+		removed[tag] = true
+	}
+
+	result := make([]string, 0, len(tags)+len(add))
+	seen := make(map[string]bool)
+	for _, tag := range append(append([]string{}, tags...), add...) {
+		tag = strings.ToLower(tag)
+		if removed[tag] || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// FilterTodosByTags keeps todos that carry every tag in all and at least one
+// tag in any. Empty lists skip that check.
+func FilterTodosByTags(todos []*Todo, all, any []string) []*Todo {
+	if len(all) == 0 && len(any) == 0 {
+		return todos
+	}
+
+	filtered := make([]*Todo, 0, len(todos))
+	for _, todo := range todos {
+		if matchesTags(todo, all, any) {
+			filtered = append(filtered, todo)
+		}
+	}
+	return filtered
+}
+
+func matchesTags(todo *Todo, all, any []string) bool {
+	for _, tag := range all {
+		if !todo.HasTag(tag) {
+			return false
+		}
+	}
+
+	if len(any) == 0 {
+		return true
+	}
+	for _, tag := range any {
+		if todo.HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
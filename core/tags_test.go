@@ -0,0 +1,87 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := NormalizeTags([]string{" Backend", "#area/auth", "backend", "sprint:42"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"backend", "area/auth", "sprint:42"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("Expected %v, got %v", want, tags)
+	}
+
+	for _, bad := range []string{"", "two words", "a,b"} {
+		if _, err := NormalizeTag(bad); err == nil {
+			t.Errorf("Expected error for tag %q", bad)
+		}
+	}
+}
+
+func TestUpdateTodoTags(t *testing.T) {
+	manager, _, cleanup := SetupTestTodoManager(t)
+	defer cleanup()
+
+	todo, err := manager.CreateTodo("Add token refresh", "high", "feature")
+	if err != nil {
+		t.Fatalf("Failed to create todo: %v", err)
+	}
+
+	steps := []struct {
+		metadata map[string]string
+		want     []string
+	}{
+		{map[string]string{"tags": "Backend,area/auth"}, []string{"backend", "area/auth"}},
+		{map[string]string{"add_tags": "security,backend"}, []string{"backend", "area/auth", "security"}},
+		{map[string]string{"remove_tags": "area/auth"}, []string{"backend", "security"}},
+		{map[string]string{"tags": "api", "add_tags": "v2"}, []string{"api", "v2"}},
+		{map[string]string{"tags": ""}, nil},
+	}
+
+	for i, step := range steps {
+		if err := manager.UpdateTodo(todo.ID, "", "", "", step.metadata); err != nil {
+			t.Fatalf("Step %d: update failed: %v", i, err)
+		}
+		updated, err := manager.ReadTodo(todo.ID)
+		if err != nil {
+			t.Fatalf("Step %d: read failed: %v", i, err)
+		}
+		if !reflect.DeepEqual(updated.Tags, step.want) {
+			t.Errorf("Step %d: expected tags %v, got %v", i, step.want, updated.Tags)
+		}
+	}
+
+	if err := manager.UpdateTodo(todo.ID, "", "", "", map[string]string{"add_tags": "not valid"}); err == nil {
+		t.Error("Expected error for invalid tag")
+	}
+}
+
+func TestFilterTodosByTagsAndStats(t *testing.T) {
+	todos := []*Todo{
+		{ID: "auth-api", Type: "feature", Tags: []string{"backend", "area/auth"}},
+		{ID: "login-page", Type: "feature", Tags: []string{"frontend", "area/auth"}},
+		{ID: "metrics", Type: "feature", Tags: []string{"backend"}},
+		{ID: "untagged", Type: "bug"},
+	}
+
+	if got := todoIDs(FilterTodosByTags(todos, []string{"backend", "area/auth"}, nil)); !reflect.DeepEqual(got, []string{"auth-api"}) {
+		t.Errorf("All-tags filter returned %v", got)
+	}
+	if got := todoIDs(FilterTodosByTags(todos, nil, []string{"frontend", "BACKEND"})); len(got) != 3 {
+		t.Errorf("Any-tags filter returned %v", got)
+	}
+	if got := FilterTodosByTags(todos, nil, nil); len(got) != len(todos) {
+		t.Errorf("Expected unfiltered list, got %d todos", len(got))
+	}
+
+	stats, err := (&StatsEngine{}).generateStatsFromTodos(todos)
+	if err != nil {
+		t.Fatalf("Failed to generate stats: %v", err)
+	}
+	if want := map[string]int{"backend": 2, "area/auth": 2, "frontend": 1}; !reflect.DeepEqual(stats.TodosByTag, want) {
+		t.Errorf("Expected TodosByTag %v, got %v", want, stats.TodosByTag)
+	}
+}
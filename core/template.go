@@ -16,15 +16,28 @@ import (
 
 // Template represents a todo template with metadata and content
 type Template struct {
-	Name        string   `yaml:"template_name"`
-	Description string   `yaml:"description"`
-	Variables   []string `yaml:"variables"`
-	Content     string   // The template content after frontmatter
+	Name        string            `yaml:"template_name"`
+	Description string            `yaml:"description"`
+	Variables   []string          `yaml:"variables"`
+	Defaults    map[string]string `yaml:"defaults,omitempty"` // values for variables the caller leaves out
+
+	// Sections for the todo's frontmatter; inferred from the rendered
+	// headings when the template doesn't define them
+	Sections map[string]*SectionDefinition `yaml:"sections,omitempty"`
+
+	// Phases or subtasks created under the todo, in order
+	Children []TemplateChild `yaml:"children,omitempty"`
+
+	Content string `yaml:"-"` // The template content after frontmatter
 }
 
+// builtinTemplateVariables are always filled in from the todo being created
+var builtinTemplateVariables = []string{"task", "priority", "type", "date"}
+
 // TemplateManager handles loading and managing todo templates
 type TemplateManager struct {
 	templatesDir string
+	todos        *TodoManager // where instantiated templates are saved
 }
 
 // NewTemplateManager creates a new template manager
@@ -34,6 +47,15 @@ func NewTemplateManager(templatesDir string) *TemplateManager {
 	}
 }
 
+// NewTemplateManagerWithTodos creates a template manager that saves the todos
+// it instantiates through todos
+func NewTemplateManagerWithTodos(templatesDir string, todos *TodoManager) *TemplateManager {
+	return &TemplateManager{
+		templatesDir: templatesDir,
+		todos:        todos,
+	}
+}
+
 // LoadTemplate loads a template by name from the templates directory
 func (tm *TemplateManager) LoadTemplate(name string) (*Template, error) {
 	// Construct template file path
@@ -52,7 +74,7 @@ func (tm *TemplateManager) LoadTemplate(name string) (*Template, error) {
 
 	// Parse template file
 	contentStr := string(content)
-	parts := strings.Split(contentStr, "---\n")
+	parts := strings.SplitN(contentStr, "---\n", 3)
 	if len(parts) < 3 {
 		return nil, interrors.NewValidationError("template", name, "invalid template format: missing frontmatter delimiters")
 	}
@@ -100,41 +122,95 @@ func (tm *TemplateManager) ListTemplates() ([]string, error) {
 	return templates, nil
 }
 
-// ExecuteTemplate processes a template with the given variables
 // CreateFromTemplate creates a new todo from a template
 func (tm *TemplateManager) CreateFromTemplate(templateName, task, priority, todoType string) (*Todo, error) {
-	// Load template
-	tmpl, err := tm.LoadTemplate(templateName)
+	return tm.CreateFromTemplateWithVariables(templateName, task, priority, todoType, nil)
+}
+
+// CreateFromTemplateWithVariables renders a template and saves the result as
+// a new todo. Declared variables come from variables, then the template's
+// defaults; any still unset are reported together. Children the template
+// declares are created under it; see CreateTreeFromTemplate.
+func (tm *TemplateManager) CreateFromTemplateWithVariables(templateName, task, priority, todoType string, variables map[string]string) (*Todo, error) {
+	parent, _, err := tm.CreateTreeFromTemplate(templateName, task, priority, todoType, variables)
+	return parent, err
+}
+
+// renderTodo executes a template for a single todo and works out its sections
+func (tm *TemplateManager) renderTodo(tmpl *Template, task, priority, todoType string, variables map[string]string) (*RenderedTodo, map[string]interface{}, error) {
+	vars, err := tmpl.resolveVariables(task, priority, todoType, variables)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	content, err := tm.ExecuteTemplate(tmpl, vars)
+	if err != nil {
+		return nil, nil, err
+	}
+	content = stripTaskHeading(content)
+
+	sections := tmpl.Sections
+	if len(sections) == 0 {
+		sections = inferTemplateSections(content)
 	}
+	return &RenderedTodo{
+		Task:     task,
+		Priority: priority,
+		Type:     todoType,
+		Content:  content,
+		Sections: sections,
+	}, vars, nil
+}
 
-	// Prepare variables
+// resolveVariables builds the values a template is executed with
+func (t *Template) resolveVariables(task, priority, todoType string, variables map[string]string) (map[string]interface{}, error) {
 	vars := map[string]interface{}{
 		"task":     task,
 		"priority": priority,
 		"type":     todoType,
+		"date":     time.Now().Format("2006-01-02"),
+	}
+	for name, value := range t.Defaults {
+		vars[name] = value
+	}
+	for name, value := range variables {
+		vars[name] = value
 	}
 
-	// Execute template
-	_, err = tm.ExecuteTemplate(tmpl, vars)
-	if err != nil {
-		return nil, err
+	var missing []string
+	for _, name := range t.Variables {
+		if _, ok := vars[name]; !ok {
+			missing = append(missing, name)
+		}
 	}
+	if len(missing) > 0 {
+		return nil, interrors.NewValidationError("variables", strings.Join(missing, ", "),
+			fmt.Sprintf("template '%s' needs values for: %s", t.Name, strings.Join(missing, ", ")))
+	}
+	return vars, nil
+}
 
-	// Create todo with template content
-	todo := &Todo{
-		ID:       generateBaseID(task),
-		Task:     task,
-		Started:  time.Now(),
-		Status:   "in_progress",
-		Priority: priority,
-		Type:     todoType,
+// stripTaskHeading drops a leading "# Task:" line, which the todo file
+// writes itself
+func stripTaskHeading(content string) string {
+	content = strings.TrimLeft(content, "\n")
+	if strings.HasPrefix(content, "# Task:") {
+		if idx := strings.Index(content, "\n"); idx != -1 {
+			return strings.TrimLeft(content[idx+1:], "\n")
+		}
+		return ""
 	}
+	return content
+}
 
-	// Note: In a real implementation, we'd write the todo with the template content
-	// For now, just return the todo object
-	return todo, nil
+// inferTemplateSections derives section definitions from the rendered
+// headings, titled the way new todos title theirs
+func inferTemplateSections(content string) map[string]*SectionDefinition {
+	sections := InferSectionsFromMarkdown(content)
+	for _, def := range sections {
+		def.Title = strings.TrimPrefix(def.Title, "## ")
+	}
+	return sections
 }
 
 func (tm *TemplateManager) ExecuteTemplate(tmpl *Template, vars map[string]interface{}) (string, error) {
@@ -0,0 +1,262 @@
+package core
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"gopkg.in/yaml.v3"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+// TemplateInfo describes a template for listings; Error is set when the
+// file can't be loaded
+type TemplateInfo struct {
+	Name        string
+	Description string
+	Variables   []string
+	Error       string
+}
+
+// templateNamePattern keeps template names usable as file names
+var templateNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// checkedBoxPattern matches a ticked checklist box
+var checkedBoxPattern = regexp.MustCompile(`(?m)^(\s*[-*] )\[[xX]\]`)
+
+// ListTemplateInfo returns every template with its description and variables
+func (tm *TemplateManager) ListTemplateInfo() ([]TemplateInfo, error) {
+	names, err := tm.ListTemplates()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	infos := make([]TemplateInfo, 0, len(names))
+	for _, name := range names {
+		tmpl, err := tm.LoadTemplate(name)
+		if err != nil {
+			infos = append(infos, TemplateInfo{Name: name, Error: err.Error()})
+			continue
+		}
+		infos = append(infos, TemplateInfo{
+			Name:        name,
+			Description: tmpl.Description,
+			Variables:   tmpl.Variables,
+		})
+	}
+	return infos, nil
+}
+
+// PreviewTemplate renders a template, and any children it declares, the way
+// CreateTreeFromTemplate would, without saving anything
+func (tm *TemplateManager) PreviewTemplate(templateName, task, priority, todoType string, variables map[string]string) (*RenderedTodo, error) {
+	tmpl, err := tm.LoadTemplate(templateName)
+	if err != nil {
+		return nil, err
+	}
+	return tm.render(tmpl, task, priority, todoType, variables)
+}
+
+// SaveTemplateFromTodo writes a new template built from a todo's content:
+// the task text becomes {{.task}}, checked boxes are cleared and the todo's
+// section definitions are kept
+func (tm *TemplateManager) SaveTemplateFromTodo(name, description string, todo *Todo, content string) (*Template, error) {
+	if !templateNamePattern.MatchString(name) {
+		return nil, interrors.NewValidationError("template", name, "template names may only contain lowercase letters, digits, '-' and '_'")
+	}
+	templatePath := filepath.Join(tm.templatesDir, name+".md")
+	if _, err := os.Stat(templatePath); err == nil {
+		return nil, interrors.NewConflictError("template", name, "template already exists")
+	}
+
+	if description == "" {
+		description = fmt.Sprintf("Based on todo %s", todo.ID)
+	}
+	tmpl := &Template{
+		Name:        name,
+		Description: description,
+		Variables:   []string{},
+		Sections:    todo.Sections,
+		Content:     generalizeTodoBody(todo.Task, content),
+	}
+
+	frontmatter, err := yaml.Marshal(tmpl)
+	if err != nil {
+		return nil, interrors.Wrap(err, "failed to marshal template frontmatter")
+	}
+	if err := os.MkdirAll(tm.templatesDir, 0755); err != nil {
+		return nil, interrors.NewOperationError("create", "templates directory", "failed to create directory", err)
+	}
+	file := "---\n" + string(frontmatter) + "---\n\n" + tmpl.Content
+	if err := ioutil.WriteFile(templatePath, []byte(file), 0644); err != nil {
+		return nil, interrors.NewOperationError("write", "template file", "failed to save template", err)
+	}
+	return tmpl, nil
+}
+
+// generalizeTodoBody turns a todo file into template content
+func generalizeTodoBody(task, content string) string {
+	body := content
+	if strings.HasPrefix(body, "---\n") {
+		if end := strings.Index(body[4:], "\n---\n"); end != -1 {
+			body = body[4+end+5:]
+		}
+	}
+	body = stripTaskHeading(body)
+
+	// Literal braces in the todo must survive template execution
+	body = strings.ReplaceAll(body, "{{", `{{"{{"}}`)
+	if task != "" {
+		body = strings.ReplaceAll(body, task, "{{.task}}")
+	}
+	body = checkedBoxPattern.ReplaceAllString(body, "$1[ ]")
+	return "# Task: {{.task}}\n\n" + body
+}
+
+// ValidateTemplate checks a template file and returns what is wrong with it;
+// an empty list means it is valid. Only a missing or unreadable file is an
+// error.
+func (tm *TemplateManager) ValidateTemplate(name string) ([]string, error) {
+	templatePath := filepath.Join(tm.templatesDir, name+".md")
+	raw, err := ioutil.ReadFile(templatePath)
+	if os.IsNotExist(err) {
+		return nil, interrors.NewNotFoundError("template", name)
+	}
+	if err != nil {
+		return nil, interrors.Wrap(err, "failed to read template")
+	}
+
+	parts := strings.SplitN(string(raw), "---\n", 3)
+	if len(parts) < 3 {
+		return []string{"missing frontmatter delimiters"}, nil
+	}
+
+	var tmpl Template
+	if err := yaml.Unmarshal([]byte(parts[1]), &tmpl); err != nil {
+		return []string{fmt.Sprintf("invalid frontmatter: %v", err)}, nil
+	}
+
+	var problems []string
+	if tmpl.Name != name {
+		problems = append(problems, fmt.Sprintf("template_name '%s' does not match the file name '%s'", tmpl.Name, name))
+	}
+
+	declared := make(map[string]bool)
+	for _, v := range builtinTemplateVariables {
+		declared[v] = true
+	}
+	for _, v := range tmpl.Variables {
+		declared[v] = true
+	}
+	for v := range tmpl.Defaults {
+		if !declared[v] {
+			problems = append(problems, fmt.Sprintf("default given for undeclared variable '%s'", v))
+		}
+	}
+
+	parsed, err := template.New(name).Parse(parts[2])
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("template does not parse: %v", err))
+	} else {
+		for _, v := range templateFields(parsed.Tree.Root) {
+			if !declared[v] {
+				problems = append(problems, fmt.Sprintf("variable '%s' is used but not declared in variables", v))
+			}
+		}
+	}
+
+	for i, child := range tmpl.Children {
+		for _, text := range append([]string{child.Task}, child.Checklist...) {
+			parsed, err := template.New(name).Parse(text)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("child %d does not parse: %v", i+1, err))
+				continue
+			}
+			for _, v := range templateFields(parsed.Tree.Root) {
+				if !declared[v] {
+					problems = append(problems, fmt.Sprintf("child %d uses variable '%s' that is not declared in variables", i+1, v))
+				}
+			}
+		}
+		if strings.TrimSpace(child.Task) == "" {
+			problems = append(problems, fmt.Sprintf("child %d has no task", i+1))
+		}
+		if child.Template != "" {
+			if _, err := os.Stat(filepath.Join(tm.templatesDir, child.Template+".md")); err != nil {
+				problems = append(problems, fmt.Sprintf("child %d uses template '%s', which does not exist", i+1, child.Template))
+			}
+		}
+	}
+
+	for _, section := range GetOrderedSections(tmpl.Sections) {
+		if section.Definition == nil || section.Definition.Title == "" {
+			problems = append(problems, fmt.Sprintf("section '%s' has no title", section.Key))
+			continue
+		}
+		if !isValidSchema(section.Definition.Schema) {
+			problems = append(problems, fmt.Sprintf("section '%s' has unknown schema '%s'", section.Key, section.Definition.Schema))
+		}
+	}
+	return problems, nil
+}
+
+// templateFields lists the top-level fields ({{.name}}) a template refers
+// to, in order of first use
+func templateFields(root parse.Node) []string {
+	var fields []string
+	seen := make(map[string]bool)
+
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			// Fields inside range and with refer to the new dot
+			walk(n.Pipe)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.ElseList)
+		case *parse.TemplateNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, cmd := range n.Cmds {
+				walk(cmd)
+			}
+		case *parse.CommandNode:
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *parse.FieldNode:
+			if name := n.Ident[0]; !seen[name] {
+				seen[name] = true
+				fields = append(fields, name)
+			}
+		}
+	}
+	walk(root)
+	return fields
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+func TestSaveTemplateFromTodo(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := NewTodoManager(tempDir)
+	tm := NewTemplateManagerWithTodos(filepath.Join(tempDir, ".claude", "templates"), manager)
+
+	todo, err := manager.CreateTodo("Upgrade postgres driver", "high", "refactor")
+	if err != nil {
+		t.Fatalf("Failed to create todo: %v", err)
+	}
+	manager.UpdateTodo(todo.ID, "checklist", "append", "- [x] Bump Upgrade postgres driver in go.mod\n- [ ] Run {{integration}} tests", nil)
+	content, _ := manager.ReadTodoContent(todo.ID)
+
+	tmpl, err := tm.SaveTemplateFromTodo("dependency-upgrade", "", todo, content)
+	if err != nil {
+		t.Fatalf("SaveTemplateFromTodo failed: %v", err)
+	}
+	if !strings.HasPrefix(tmpl.Content, "# Task: {{.task}}") || strings.Contains(tmpl.Content, "Upgrade postgres driver") ||
+		strings.Contains(tmpl.Content, "[x]") || len(tmpl.Sections) != len(todo.Sections) {
+		t.Errorf("Expected a generalised template, got:\n%s", tmpl.Content)
+	}
+	if _, err := tm.SaveTemplateFromTodo("dependency-upgrade", "", todo, content); !interrors.IsConflict(err) {
+		t.Errorf("Expected a conflict saving over an existing template, got %v", err)
+	}
+	if _, err := tm.SaveTemplateFromTodo("../escape", "", todo, content); !interrors.IsValidation(err) {
+		t.Errorf("Expected an invalid name to be rejected, got %v", err)
+	}
+
+	if problems, err := tm.ValidateTemplate("dependency-upgrade"); err != nil || len(problems) != 0 {
+		t.Errorf("Expected the saved template to be valid, got %v (err %v)", problems, err)
+	}
+
+	preview, err := tm.PreviewTemplate("dependency-upgrade", "Upgrade redis client", "medium", "refactor", nil)
+	if err != nil {
+		t.Fatalf("PreviewTemplate failed: %v", err)
+	}
+	if !strings.Contains(preview.Content, "- [ ] Bump Upgrade redis client in go.mod") || !strings.Contains(preview.Content, "Run {{integration}} tests") ||
+		preview.Sections["checklist"] == nil {
+		t.Errorf("Unexpected preview:\n%s", preview.Content)
+	}
+	if todos, _ := manager.ListTodos("", "", 0); len(todos) != 1 {
+		t.Errorf("Expected preview not to create a todo, got %d todos", len(todos))
+	}
+
+	infos, err := tm.ListTemplateInfo()
+	if err != nil || len(infos) != 1 || infos[0].Description != "Based on todo "+todo.ID {
+		t.Errorf("Expected the saved template to be listed, got %+v (err %v)", infos, err)
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	templatesDir := t.TempDir()
+	tm := NewTemplateManager(templatesDir)
+
+	os.WriteFile(filepath.Join(templatesDir, "release.md"), []byte(`---
+template_name: release-checklist
+description: Release
+variables:
+  - version
+defaults:
+  channel: stable
+sections:
+  steps:
+    title: Steps
+    order: 1
+    schema: checklist
+  notes:
+    title: Notes
+    order: 2
+    schema: prose
+---
+
+## Steps
+- [ ] Tag {{.version}} on {{.branch}}
+{{range .items}}{{.name}}{{end}}
+{{if .task}}{{.priority}}{{end}}
+`), 0644)
+
+	problems, err := tm.ValidateTemplate("release")
+	if err != nil {
+		t.Fatalf("ValidateTemplate failed: %v", err)
+	}
+	expected := []string{
+		"template_name 'release-checklist' does not match the file name 'release'",
+		"default given for undeclared variable 'channel'",
+		"variable 'branch' is used but not declared in variables",
+		"variable 'items' is used but not declared in variables",
+		"section 'notes' has unknown schema 'prose'",
+	}
+	if strings.Join(problems, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected problems:\n%s", strings.Join(problems, "\n"))
+	}
+
+	if _, err := tm.ValidateTemplate("missing"); !interrors.IsNotFound(err) {
+		t.Errorf("Expected not found for a missing template, got %v", err)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
 )
 
 // Test 20: Templates should load from .claude/templates directory
@@ -654,7 +656,7 @@ variables:
 	}
 
 	// Create template manager
-	tm := NewTemplateManager(templatesDir)
+	tm := NewTemplateManagerWithTodos(templatesDir, NewTodoManager(tempDir))
 
 	tests := []struct {
 		name         string
@@ -836,7 +838,7 @@ Type: {{.type}}
 	templatePath := filepath.Join(templatesDir, "test-vars.md")
 	ioutil.WriteFile(templatePath, []byte(templateContent), 0644)
 
-	tm := NewTemplateManager(templatesDir)
+	tm := NewTemplateManagerWithTodos(templatesDir, NewTodoManager(tempDir))
 
 	// Test variable substitution
 	_, err = tm.CreateFromTemplate("test-vars", "Test Task", "high", "feature")
@@ -894,7 +896,7 @@ variables:
 	templatePath := filepath.Join(templatesDir, "simple.md")
 	ioutil.WriteFile(templatePath, []byte(templateContent), 0644)
 
-	tm := NewTemplateManager(templatesDir)
+	tm := NewTemplateManagerWithTodos(templatesDir, NewTodoManager(tempDir))
 
 	tests := []struct {
 		task       string
@@ -937,3 +939,94 @@ variables:
 		})
 	}
 }
+
+// TestCreateFromTemplateWithVariables tests that templates are rendered into a saved todo
+func TestCreateFromTemplateWithVariables(t *testing.T) {
+	tempDir := t.TempDir()
+	templatesDir := filepath.Join(tempDir, "templates")
+	os.MkdirAll(templatesDir, 0755)
+
+	templates := map[string]string{
+		"incident": `---
+template_name: incident
+description: Production incident
+variables:
+  - service
+  - severity
+defaults:
+  severity: sev2
+---
+
+# Task: {{.task}}
+
+## Impact
+{{.service}} degraded ({{.severity}}), reported {{.date}}
+
+## Timeline
+- [ ] Detected
+- [ ] Mitigated
+`,
+		"spike": `---
+template_name: spike
+description: Time-boxed investigation
+sections:
+  question:
+    title: Question
+    order: 1
+    schema: freeform
+    required: true
+  findings:
+    title: Findings & Research
+    order: 2
+    schema: research
+---
+
+## Question
+{{.task}}
+
+## Findings & Research
+`,
+	}
+	for name, content := range templates {
+		ioutil.WriteFile(filepath.Join(templatesDir, name+".md"), []byte(content), 0644)
+	}
+
+	manager := NewTodoManager(tempDir)
+	tm := NewTemplateManagerWithTodos(templatesDir, manager)
+
+	_, err := tm.CreateFromTemplateWithVariables("incident", "Checkout outage", "high", "bug", nil)
+	if !interrors.IsValidation(err) || !strings.Contains(err.Error(), "service") {
+		t.Fatalf("Expected a validation error naming the missing variable, got %v", err)
+	}
+
+	todo, err := tm.CreateFromTemplateWithVariables("incident", "Checkout outage", "high", "bug",
+		map[string]string{"service": "payments"})
+	if err != nil {
+		t.Fatalf("Failed to create from template: %v", err)
+	}
+
+	content, err := manager.ReadTodoContent(todo.ID)
+	if err != nil {
+		t.Fatalf("Expected the todo to be saved: %v", err)
+	}
+	if strings.Count(content, "# Task:") != 1 || !strings.Contains(content, "payments degraded (sev2)") {
+		t.Errorf("Expected rendered content with one task heading, got:\n%s", content)
+	}
+	saved, err := manager.ReadTodo(todo.ID)
+	if err != nil {
+		t.Fatalf("Failed to read todo: %v", err)
+	}
+	if saved.Task != "Checkout outage" || len(saved.Sections) != 2 ||
+		saved.Sections["impact"] == nil || saved.Sections["timeline"].Title != "Timeline" {
+		t.Errorf("Expected sections inferred from the template headings, got %+v", saved.Sections)
+	}
+
+	todo, err = tm.CreateFromTemplate("spike", "Can we drop the cache?", "medium", "research")
+	if err != nil {
+		t.Fatalf("Failed to create from template: %v", err)
+	}
+	saved, _ = manager.ReadTodo(todo.ID)
+	if len(saved.Sections) != 2 || !saved.Sections["question"].Required || saved.Sections["findings"].Schema != SchemaResearch {
+		t.Errorf("Expected the template's own section definitions, got %+v", saved.Sections)
+	}
+}
@@ -0,0 +1,195 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+// TemplateChild is a phase or subtask a template creates under its todo.
+// Task and checklist items are rendered with the parent's variables; a
+// child template is rendered with the child's task.
+type TemplateChild struct {
+	Task      string   `yaml:"task"`
+	Template  string   `yaml:"template,omitempty"`
+	Priority  string   `yaml:"priority,omitempty"` // defaults to the parent's
+	Type      string   `yaml:"type,omitempty"`     // defaults to phase
+	Checklist []string `yaml:"checklist,omitempty"`
+}
+
+// RenderedTodo is a todo as a template would create it
+type RenderedTodo struct {
+	Task     string
+	Priority string
+	Type     string
+	Content  string
+	Sections map[string]*SectionDefinition
+	Children []*RenderedTodo
+}
+
+// CreateTreeFromTemplate creates a todo from a template along with the child
+// phases or subtasks it declares, each linked to it through parent_id.
+// Everything is rendered before anything is written, so a missing variable
+// or child template leaves no partial tree behind.
+func (tm *TemplateManager) CreateTreeFromTemplate(templateName, task, priority, todoType string, variables map[string]string) (*Todo, []*Todo, error) {
+	tmpl, err := tm.LoadTemplate(templateName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rendered, err := tm.render(tmpl, task, priority, todoType, variables)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if tm.todos == nil {
+		return nil, nil, fmt.Errorf("template manager has no todo manager to save '%s' with", templateName)
+	}
+	parent, err := tm.todos.CreateTodoWithTemplateSections(rendered.Task, rendered.Priority, rendered.Type, rendered.Content, rendered.Sections)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var children []*Todo
+	for _, rc := range rendered.Children {
+		child, err := tm.todos.CreateTodoWithTemplateSections(rc.Task, rc.Priority, rc.Type, rc.Content, rc.Sections)
+		if err != nil {
+			return parent, children, interrors.Wrap(err, fmt.Sprintf("failed to create child '%s'", rc.Task))
+		}
+		err = tm.todos.UpdateTodoFrontmatter(child.ID, func(todo *Todo) error {
+			todo.ParentID = parent.ID
+			return nil
+		})
+		if err != nil {
+			return parent, children, interrors.Wrap(err, fmt.Sprintf("failed to link child '%s'", child.ID))
+		}
+		child.ParentID = parent.ID
+		children = append(children, child)
+	}
+	return parent, children, nil
+}
+
+// render executes a template and the children it declares. A todo type left
+// empty becomes multi-phase for templates with children, feature otherwise.
+func (tm *TemplateManager) render(tmpl *Template, task, priority, todoType string, variables map[string]string) (*RenderedTodo, error) {
+	if todoType == "" {
+		todoType = "feature"
+		if len(tmpl.Children) > 0 {
+			todoType = "multi-phase"
+		}
+	}
+
+	parent, vars, err := tm.renderTodo(tmpl, task, priority, todoType, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, child := range tmpl.Children {
+		rendered, err := tm.renderChild(tmpl, i, child, parent, vars, variables)
+		if err != nil {
+			return nil, err
+		}
+		parent.Children = append(parent.Children, rendered)
+	}
+	return parent, nil
+}
+
+// renderChild renders one declared child. Templates named by children are
+// used for their content only; their own children are not expanded.
+func (tm *TemplateManager) renderChild(tmpl *Template, index int, child TemplateChild, parent *RenderedTodo, vars map[string]interface{}, variables map[string]string) (*RenderedTodo, error) {
+	field := fmt.Sprintf("children[%d]", index)
+	task, err := executeString(tmpl.Name, child.Task, vars)
+	if err != nil {
+		return nil, interrors.NewValidationError(field, child.Task, fmt.Sprintf("invalid task: %v", err))
+	}
+	if strings.TrimSpace(task) == "" {
+		return nil, interrors.NewValidationError(field, child.Task, fmt.Sprintf("child %d of template '%s' has no task", index+1, tmpl.Name))
+	}
+
+	priority := child.Priority
+	if priority == "" {
+		priority = parent.Priority
+	}
+	todoType := child.Type
+	if todoType == "" {
+		todoType = "phase"
+	}
+
+	var rendered *RenderedTodo
+	if child.Template != "" {
+		childTmpl, err := tm.LoadTemplate(child.Template)
+		if err != nil {
+			return nil, err
+		}
+		if rendered, _, err = tm.renderTodo(childTmpl, task, priority, todoType, variables); err != nil {
+			return nil, err
+		}
+	} else {
+		sections := getDefaultSections()
+		rendered = &RenderedTodo{
+			Task:     task,
+			Priority: priority,
+			Type:     todoType,
+			Content:  emptySectionsBody(sections),
+			Sections: sections,
+		}
+	}
+
+	if len(child.Checklist) > 0 {
+		var items strings.Builder
+		for _, item := range child.Checklist {
+			text, err := executeString(tmpl.Name, item, vars)
+			if err != nil {
+				return nil, interrors.NewValidationError(field, item, fmt.Sprintf("invalid checklist item: %v", err))
+			}
+			items.WriteString("- [ ] " + text + "\n")
+		}
+		rendered.Content = appendUnderHeading(rendered.Content, "## Checklist", items.String())
+		ensureChecklistSection(rendered.Sections)
+	}
+	return rendered, nil
+}
+
+// executeString renders a short template string such as a child's task
+func executeString(name, text string, vars map[string]interface{}) (string, error) {
+	t, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, vars); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// emptySectionsBody is the body a new todo gets: a heading per section
+func emptySectionsBody(sections map[string]*SectionDefinition) string {
+	var b strings.Builder
+	for _, section := range GetOrderedSections(sections) {
+		fmt.Fprintf(&b, "## %s\n\n", section.Definition.Title)
+	}
+	return b.String()
+}
+
+// ensureChecklistSection adds a checklist section after the others when a
+// template didn't define one
+func ensureChecklistSection(sections map[string]*SectionDefinition) {
+	if _, ok := sections["checklist"]; ok {
+		return
+	}
+	order := 0
+	for _, def := range sections {
+		if def.Order > order {
+			order = def.Order
+		}
+	}
+	sections["checklist"] = &SectionDefinition{
+		Title:  "Checklist",
+		Order:  order + 1,
+		Schema: SchemaChecklist,
+	}
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTreeTemplates(t *testing.T, templatesDir string) {
+	t.Helper()
+	templates := map[string]string{
+		"new-service": `---
+template_name: new-service
+description: Stand up a new service
+variables:
+  - service
+children:
+  - task: "Design {{.service}} API"
+    template: design-doc
+    checklist:
+      - Review with {{.service}} owners
+  - task: "Provision {{.service}} infrastructure"
+    priority: medium
+    checklist:
+      - Create database
+      - Add dashboards
+  - task: "Launch {{.service}}"
+    type: subtask
+---
+
+## Overview
+{{.service}} service rollout
+`,
+		"design-doc": `---
+template_name: design-doc
+description: Design document
+variables: []
+---
+
+## Design
+Design for {{.task}}
+`,
+		"broken-tree": `---
+template_name: broken-tree
+description: Child template is missing
+variables: []
+children:
+  - task: "First phase"
+  - task: "Second phase"
+    template: nowhere
+---
+
+## Overview
+`,
+	}
+	for name, content := range templates {
+		if err := os.WriteFile(filepath.Join(templatesDir, name+".md"), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write template %s: %v", name, err)
+		}
+	}
+}
+
+func TestCreateTreeFromTemplate(t *testing.T) {
+	tempDir := t.TempDir()
+	templatesDir := filepath.Join(tempDir, "templates")
+	os.MkdirAll(templatesDir, 0755)
+	writeTreeTemplates(t, templatesDir)
+
+	manager := NewTodoManager(tempDir)
+	tm := NewTemplateManagerWithTodos(templatesDir, manager)
+
+	parent, children, err := tm.CreateTreeFromTemplate("new-service", "Billing service", "high", "", map[string]string{"service": "billing"})
+	if err != nil {
+		t.Fatalf("CreateTreeFromTemplate failed: %v", err)
+	}
+	if parent.Type != "multi-phase" || len(children) != 3 {
+		t.Fatalf("Expected a multi-phase parent with 3 children, got %s with %d", parent.Type, len(children))
+	}
+
+	expected := []struct{ task, priority, todoType string }{
+		{"Design billing API", "high", "phase"},
+		{"Provision billing infrastructure", "medium", "phase"},
+		{"Launch billing", "high", "subtask"},
+	}
+	for i, want := range expected {
+		child, err := manager.ReadTodo(children[i].ID)
+		if err != nil {
+			t.Fatalf("Failed to read child %d: %v", i, err)
+		}
+		if child.Task != want.task || child.Priority != want.priority || child.Type != want.todoType || child.ParentID != parent.ID {
+			t.Errorf("Child %d: expected %+v under %s, got %s/%s/%s under %q", i, want, parent.ID, child.Task, child.Priority, child.Type, child.ParentID)
+		}
+	}
+
+	design, _ := manager.ReadTodoContent(children[0].ID)
+	if !strings.Contains(design, "Design for Design billing API") || !strings.Contains(design, "- [ ] Review with billing owners") {
+		t.Errorf("Expected the child template and checklist to be rendered, got:\n%s", design)
+	}
+	designTodo, _ := manager.ReadTodo(children[0].ID)
+	if designTodo.Sections["checklist"] == nil || designTodo.Sections["design"] == nil {
+		t.Errorf("Expected the design and added checklist sections, got %+v", designTodo.Sections)
+	}
+
+	infra, _ := manager.ReadTodoContent(children[1].ID)
+	if !strings.Contains(infra, "## Checklist\n\n- [ ] Create database\n- [ ] Add dashboards") {
+		t.Errorf("Expected checklist items in the default checklist section, got:\n%s", infra)
+	}
+
+	// Nothing is written when part of the tree can't be rendered
+	before, _ := manager.ListTodos("", "", 0)
+	if _, _, err := tm.CreateTreeFromTemplate("broken-tree", "Broken", "high", "", nil); err == nil {
+		t.Error("Expected a missing child template to fail")
+	}
+	if after, _ := manager.ListTodos("", "", 0); len(after) != len(before) {
+		t.Errorf("Expected no todos from a failed tree, got %d new", len(after)-len(before))
+	}
+
+	problems, _ := tm.ValidateTemplate("broken-tree")
+	if len(problems) != 1 || problems[0] != "child 2 uses template 'nowhere', which does not exist" {
+		t.Errorf("Expected validation to report the missing child template, got %v", problems)
+	}
+}
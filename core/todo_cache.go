@@ -0,0 +1,414 @@
+package core
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// todoCache holds the parsed frontmatter of every active todo in a project.
+// Listing, hierarchy and stats queries answer from it instead of re-reading
+// each markdown file.
+//
+// Without a watcher the cache revalidates on every read by walking the tree
+// and re-parsing only files whose size or modification time changed. While a
+// watcher is running, reads are served straight from memory and filesystem
+// events keep the entries current, including edits made outside the server.
+type todoCache struct {
+	root string // <project>/.claude/todos
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry // keyed by file path
+	stale   bool                   // next read must walk the tree
+
+	watcher  *fsnotify.Watcher
+	watchers int // WatchTodos calls not yet matched by StopWatching
+
+	listenersMu  sync.Mutex
+	listeners    map[int]func(TodoChange)
+	nextListener int
+	pending      []TodoChange // changes not yet delivered
+	dispatching  bool
+}
+
+// TodoChange describes a todo file that was created, modified or removed
+// (archiving removes it from the active tree). Changes made outside the
+// server are only reported while the tree is watched.
+type TodoChange struct {
+	ID      string
+	Removed bool
+}
+
+// cacheEntry is one parsed todo file
+type cacheEntry struct {
+	todo    *Todo
+	modTime time.Time
+	size    int64
+}
+
+var (
+	projectCachesMu sync.Mutex
+	projectCaches   = make(map[string]*todoCache)
+)
+
+// cacheForProject returns the cache shared by every manager of a project,
+// building it on first use
+func cacheForProject(basePath string) *todoCache {
+	root := filepath.Join(basePath, ".claude", "todos")
+	if abs, err := filepath.Abs(root); err == nil {
+		root = abs
+	}
+
+	projectCachesMu.Lock()
+	defer projectCachesMu.Unlock()
+
+	if cache, ok := projectCaches[root]; ok {
+		return cache
+	}
+
+	cache := &todoCache{
+		root:    root,
+		entries: make(map[string]*cacheEntry),
+	}
+	cache.mu.Lock()
+	cache.syncLocked()
+	cache.mu.Unlock()
+
+	projectCaches[root] = cache
+	return cache
+}
+
+// todos returns copies of all cached todos, newest first
+func (c *todoCache) todos() []*Todo {
+	c.mu.Lock()
+	var changes []TodoChange
+	if c.watcher == nil || c.stale {
+		changes = c.syncLocked()
+	}
+
+	todos := make([]*Todo, 0, len(c.entries))
+	for _, entry := range c.entries {
+		todos = append(todos, cloneTodo(entry.todo))
+	}
+	c.mu.Unlock()
+
+	c.notify(changes)
+	sort.Slice(todos, func(i, j int) bool {
+		if todos[i].Started.Equal(todos[j].Started) {
+			return todos[i].ID < todos[j].ID
+		}
+		return todos[i].Started.After(todos[j].Started)
+	})
+	return todos
+}
+
+// syncLocked walks the todo tree, re-parsing new or modified files and
+// dropping entries whose files are gone. Directories are (re)registered with
+// the watcher so new day folders are followed. Caller holds c.mu.
+func (c *todoCache) syncLocked() []TodoChange {
+	seen := make(map[string]bool, len(c.entries))
+	var changes []TodoChange
+
+	filepath.Walk(c.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip unreadable entries, a missing root means no todos
+		}
+		if info.IsDir() {
+			if c.watcher != nil {
+				if err := c.watcher.Add(path); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to watch %s: %v\n", path, err)
+				}
+			}
+			return nil
+		}
+		if !strings.HasSuffix(info.Name(), ".md") {
+			return nil
+		}
+
+		seen[path] = true
+		if entry, ok := c.entries[path]; ok && entry.size == info.Size() && entry.modTime.Equal(info.ModTime()) {
+			return nil
+		}
+		changes = append(changes, c.loadLocked(path, info)...)
+		return nil
+	})
+
+	for path, entry := range c.entries {
+		if !seen[path] {
+			delete(c.entries, path)
+			changes = append(changes, TodoChange{ID: entry.todo.ID, Removed: true})
+		}
+	}
+	c.stale = false
+	return changes
+}
+
+// loadLocked parses a single file into the cache. Malformed files are left
+// out, as ListTodos always skipped them. Caller holds c.mu.
+func (c *todoCache) loadLocked(path string, info os.FileInfo) []TodoChange {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return c.dropLocked(path)
+	}
+
+	todo, err := parseTodoMarkdown(string(content))
+	if err != nil {
+		return c.dropLocked(path)
+	}
+	if todo.ID == "" {
+		todo.ID = strings.TrimSuffix(filepath.Base(path), ".md")
+	}
+
+	c.entries[path] = &cacheEntry{
+		todo:    todo,
+		modTime: info.ModTime(),
+		size:    info.Size(),
+	}
+	return []TodoChange{{ID: todo.ID}}
+}
+
+// dropLocked removes the entry for path, if any. Caller holds c.mu.
+func (c *todoCache) dropLocked(path string) []TodoChange {
+	entry, ok := c.entries[path]
+	if !ok {
+		return nil
+	}
+	delete(c.entries, path)
+	return []TodoChange{{ID: entry.todo.ID, Removed: true}}
+}
+
+// refresh re-reads a single file after the server wrote, moved or removed
+// it, so the change is visible before any watcher event arrives
+func (c *todoCache) refresh(path string) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if !strings.HasPrefix(path, c.root+string(filepath.Separator)) || !strings.HasSuffix(path, ".md") {
+		return
+	}
+
+	c.mu.Lock()
+	var changes []TodoChange
+	info, err := os.Stat(path)
+	if err != nil {
+		changes = c.dropLocked(path)
+	} else if entry, ok := c.entries[path]; !ok || entry.size != info.Size() || !entry.modTime.Equal(info.ModTime()) {
+		// Unchanged files are skipped, so the watcher echoing our own
+		// writes doesn't report them twice
+		changes = c.loadLocked(path, info)
+	}
+	c.mu.Unlock()
+
+	c.notify(changes)
+}
+
+// invalidate forces a full walk on the next read
+func (c *todoCache) invalidate() {
+	c.mu.Lock()
+	c.stale = true
+	c.mu.Unlock()
+}
+
+// watch starts (or joins) the filesystem watcher for this project
+func (c *todoCache) watch() error {
+	c.mu.Lock()
+	if c.watcher != nil {
+		c.watchers++
+		c.mu.Unlock()
+		return nil
+	}
+
+	if _, err := os.Stat(c.root); err != nil {
+		c.mu.Unlock()
+		return fmt.Errorf("cannot watch %s: %w", c.root, err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		c.mu.Unlock()
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	c.watcher = watcher
+	c.watchers = 1
+	changes := c.syncLocked()
+	c.mu.Unlock()
+
+	c.notify(changes)
+	go c.watchLoop(watcher)
+	return nil
+}
+
+// unwatch releases one watch; the watcher stops with the last one and the
+// cache is no longer shared
+func (c *todoCache) unwatch() {
+	c.mu.Lock()
+	if c.watcher == nil {
+		c.mu.Unlock()
+		return
+	}
+	c.watchers--
+	if c.watchers > 0 {
+		c.mu.Unlock()
+		return
+	}
+	watcher := c.watcher
+	c.watcher = nil
+	c.mu.Unlock()
+
+	// Close outside the lock: the event loop may be waiting for it
+	watcher.Close()
+	forgetCache(c)
+}
+
+// forgetCache drops c from the shared caches once no manager watches it, so
+// projects the server no longer serves don't stay in memory. Managers still
+// holding it keep working; it revalidates on every read like any unwatched
+// cache.
+func forgetCache(c *todoCache) {
+	projectCachesMu.Lock()
+	defer projectCachesMu.Unlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.watcher == nil && projectCaches[c.root] == c {
+		delete(projectCaches, c.root)
+	}
+}
+
+// watchLoop applies filesystem events until the watcher is closed
+func (c *todoCache) watchLoop(watcher *fsnotify.Watcher) {
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			c.handleEvent(event)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			// Dropped events (e.g. queue overflow) mean entries can't be trusted
+			fmt.Fprintf(os.Stderr, "Warning: todo watcher error for %s: %v\n", c.root, err)
+			c.resync()
+		}
+	}
+}
+
+// handleEvent refreshes the file an event refers to. Anything else that
+// changes the tree (new day folders, moved directories, the root itself being
+// replaced during migration) triggers a full walk.
+func (c *todoCache) handleEvent(event fsnotify.Event) {
+	if strings.HasSuffix(event.Name, ".md") {
+		c.refresh(event.Name)
+		return
+	}
+	if event.Has(fsnotify.Create) || event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+		c.resync()
+	}
+}
+
+// resync walks the tree now and reports what changed
+func (c *todoCache) resync() {
+	c.mu.Lock()
+	changes := c.syncLocked()
+	c.mu.Unlock()
+
+	c.notify(changes)
+}
+
+// subscribe registers fn for every change the cache observes and returns a
+// function that removes it again
+func (c *todoCache) subscribe(fn func(TodoChange)) func() {
+	c.listenersMu.Lock()
+	defer c.listenersMu.Unlock()
+
+	if c.listeners == nil {
+		c.listeners = make(map[int]func(TodoChange))
+	}
+	id := c.nextListener
+	c.nextListener++
+	c.listeners[id] = fn
+
+	return func() {
+		c.listenersMu.Lock()
+		delete(c.listeners, id)
+		c.listenersMu.Unlock()
+	}
+}
+
+// notify queues changes for the listeners. Delivery happens in order on a
+// separate goroutine, because writers call this while holding the manager
+// lock and listeners are free to read todos back.
+func (c *todoCache) notify(changes []TodoChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	c.listenersMu.Lock()
+	defer c.listenersMu.Unlock()
+
+	if len(c.listeners) == 0 {
+		return
+	}
+	c.pending = append(c.pending, changes...)
+	if !c.dispatching {
+		c.dispatching = true
+		go c.dispatch()
+	}
+}
+
+// dispatch delivers queued changes until the queue is empty
+func (c *todoCache) dispatch() {
+	for {
+		c.listenersMu.Lock()
+		if len(c.pending) == 0 {
+			c.dispatching = false
+			c.listenersMu.Unlock()
+			return
+		}
+		changes := c.pending
+		c.pending = nil
+		listeners := make([]func(TodoChange), 0, len(c.listeners))
+		for _, fn := range c.listeners {
+			listeners = append(listeners, fn)
+		}
+		c.listenersMu.Unlock()
+
+		for _, change := range changes {
+			for _, fn := range listeners {
+				fn(change)
+			}
+		}
+	}
+}
+
+// cloneTodo copies a cached todo so callers can't modify the cache
+func cloneTodo(todo *Todo) *Todo {
+	clone := *todo
+	clone.Tags = append([]string(nil), todo.Tags...)
+	clone.Blocks = append([]string(nil), todo.Blocks...)
+	clone.BlockedBy = append([]string(nil), todo.BlockedBy...)
+	clone.Related = append([]string(nil), todo.Related...)
+
+	if todo.Sections != nil {
+		clone.Sections = make(map[string]*SectionDefinition, len(todo.Sections))
+		for key, section := range todo.Sections {
+			if section == nil {
+				clone.Sections[key] = nil
+				continue
+			}
+			copied := *section
+			clone.Sections[key] = &copied
+		}
+	}
+	return &clone
+}
@@ -0,0 +1,184 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// editTodoFile rewrites a todo file behind the manager's back
+func editTodoFile(t *testing.T, basePath, id, old, new string) {
+	t.Helper()
+	path, err := ResolveTodoPath(basePath, id)
+	if err != nil {
+		t.Fatalf("Failed to resolve %s: %v", id, err)
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(strings.Replace(string(content), old, new, 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func findTodo(todos []*Todo, id string) *Todo {
+	for _, todo := range todos {
+		if todo.ID == id {
+			return todo
+		}
+	}
+	return nil
+}
+
+func TestTodoCache_RevalidatesWithoutWatcher(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := NewTodoManager(tempDir)
+
+	todo, err := manager.CreateTodo("Cached task", "high", "feature")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	editTodoFile(t, tempDir, todo.ID, "priority: high", "priority: low")
+
+	todos, err := manager.ListTodos("", "low", 0)
+	if err != nil || len(todos) != 1 {
+		t.Fatalf("Expected hand-edited priority to be listed, got %d todos (err %v)", len(todos), err)
+	}
+
+	// Handed-out todos are copies
+	todos[0].Priority = "changed"
+	todos, _ = manager.ListTodos("", "", 0)
+	if todos[0].Priority != "low" {
+		t.Errorf("Modifying a listed todo should not touch the cache, got %q", todos[0].Priority)
+	}
+
+	path, _ := ResolveTodoPath(tempDir, todo.ID)
+	os.Remove(path)
+	if todos, _ := manager.ListTodos("", "", 0); len(todos) != 0 {
+		t.Errorf("Removed todo should disappear from the cache, got %d todos", len(todos))
+	}
+}
+
+func TestTodoCache_SharedAcrossManagers(t *testing.T) {
+	tempDir := t.TempDir()
+	first := NewTodoManager(tempDir)
+	second := NewTodoManager(tempDir)
+	if first.cache != second.cache {
+		t.Fatal("Managers of the same project should share one cache")
+	}
+
+	parent, _ := first.CreateTodo("Parent project", "high", "multi-phase")
+	child, err := first.CreateTodoWithParent("First phase", "high", "phase", parent.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Children live in date folders, not the flat todos directory
+	children, err := second.GetChildren(parent.ID)
+	if err != nil || len(children) != 1 || children[0].ID != child.ID {
+		t.Errorf("Expected %s as only child, got %v (err %v)", child.ID, children, err)
+	}
+
+	stats, err := NewStatsEngine(second).GenerateTodoStats()
+	if err != nil || stats.TotalTodos != 2 {
+		t.Errorf("Expected stats over 2 cached todos, got %+v (err %v)", stats, err)
+	}
+}
+
+func TestTodoCache_WatcherAppliesExternalEdits(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := NewTodoManager(tempDir)
+	todo, err := manager.CreateTodo("Watched task", "high", "feature")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := manager.WatchTodos(); err != nil {
+		t.Fatalf("Failed to start watcher: %v", err)
+	}
+	defer manager.StopWatching()
+
+	waitFor := func(what string, check func([]*Todo) bool) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			todos, _ := manager.ListTodos("", "", 0)
+			if check(todos) {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Errorf("Watcher did not pick up %s", what)
+	}
+
+	editTodoFile(t, tempDir, todo.ID, "status: in_progress", "status: blocked")
+	waitFor("edited status", func(todos []*Todo) bool {
+		found := findTodo(todos, todo.ID)
+		return found != nil && found.Status == "blocked"
+	})
+
+	// A todo dropped into a new day folder by another tool
+	dir := filepath.Join(tempDir, ".claude", "todos", "2020", "01", "02")
+	os.MkdirAll(dir, 0755)
+	content := "---\ntodo_id: imported\nstarted: 2020-01-02T10:00:00Z\nstatus: in_progress\npriority: low\ntype: bug\n---\n\n# Task: Imported\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, "imported.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("new todo", func(todos []*Todo) bool {
+		return findTodo(todos, "imported") != nil
+	})
+
+	// Writes through the manager are visible immediately
+	if err := manager.UpdateTodo(todo.ID, "", "", "", map[string]string{"priority": "low"}); err != nil {
+		t.Fatal(err)
+	}
+	todos, _ := manager.ListTodos("", "", 0)
+	if found := findTodo(todos, todo.ID); found == nil || found.Priority != "low" {
+		t.Errorf("Expected own update to be cached, got %+v", found)
+	}
+}
+
+func TestTodoCache_ForgottenOnceUnwatched(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := NewTodoManager(tempDir)
+	manager.CreateTodo("Watched task", "high", "feature")
+	if err := manager.WatchTodos(); err != nil {
+		t.Fatalf("Failed to start watcher: %v", err)
+	}
+	manager.StopWatching()
+
+	projectCachesMu.Lock()
+	_, cached := projectCaches[manager.cache.root]
+	projectCachesMu.Unlock()
+	if cached {
+		t.Error("Expected the project cache to be dropped once nothing watches it")
+	}
+}
+
+func TestListTodos_LongWindowKeepsFutureStarts(t *testing.T) {
+	manager := NewTodoManager(t.TempDir())
+	todo := &Todo{
+		ID:       "scheduled",
+		Task:     "Scheduled",
+		Started:  time.Now().AddDate(0, 0, 3),
+		Status:   "in_progress",
+		Priority: "high",
+		Type:     "feature",
+		Sections: getDefaultSections(),
+	}
+	if err := manager.writeTodo(todo); err != nil {
+		t.Fatal(err)
+	}
+
+	if found, _ := manager.ListTodos("", "", 400); findTodo(found, "scheduled") == nil {
+		t.Error("Expected a year-plus window to include future-started todos, as the full scan did")
+	}
+	if found, _ := manager.ListTodos("", "", 7); findTodo(found, "scheduled") != nil {
+		t.Error("Expected a short window to stop at today, as the date-folder scan did")
+	}
+}
@@ -55,6 +55,7 @@ func (tm *TodoManager) writeTodo(todo *Todo) error {
 	if err := ioutil.WriteFile(filename, []byte(contentBuilder.String()), 0644); err != nil {
 		return interrors.NewOperationError("write", "todo file", "failed to save todo", err)
 	}
+	tm.cache.refresh(filename)
 
 	return nil
 }
@@ -92,6 +93,7 @@ func (tm *TodoManager) writeTodoWithContent(todo *Todo, templateContent string)
 	if err := ioutil.WriteFile(filename, []byte(contentBuilder.String()), 0644); err != nil {
 		return interrors.NewOperationError("write", "todo file", "failed to save todo", err)
 	}
+	tm.cache.refresh(filename)
 
 	return nil
 }
@@ -135,6 +137,12 @@ func (tm *TodoManager) ParseTodoFileContent(id, content string) (*Todo, error) {
 }
 
 func (tm *TodoManager) parseTodoFile(content string) (*Todo, error) {
+	return parseTodoMarkdown(content)
+}
+
+// parseTodoMarkdown parses frontmatter and task heading. It needs no manager
+// state, so the metadata cache uses it directly.
+func parseTodoMarkdown(content string) (*Todo, error) {
 	// Split frontmatter and content
 	parts := strings.SplitN(content, "---", 3)
 	if len(parts) < 3 {
@@ -147,6 +155,10 @@ func (tm *TodoManager) parseTodoFile(content string) (*Todo, error) {
 		return nil, interrors.Wrap(err, "failed to parse frontmatter")
 	}
 
+	// Hand-edited dates like "due: 2026-03-01" decode as UTC midnight
+	todo.Due = localDate(todo.Due)
+	todo.Scheduled = localDate(todo.Scheduled)
+
 	// Extract task from heading
 	todo.Task = extractTask(parts[2])
 
@@ -216,6 +228,7 @@ func (tm *TodoManager) SaveTodo(todo *Todo) error {
 				// Log warning but don't fail - the new file was written successfully
 				fmt.Fprintf(os.Stderr, "Warning: failed to remove old todo file at %s: %v\n", oldPath, err)
 			}
+			tm.cache.refresh(oldPath)
 			// Update cache
 			globalPathCache.Delete(todo.ID)
 			globalPathCache.Set(todo.ID, newPath)
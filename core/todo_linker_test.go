@@ -91,9 +91,9 @@ func TestLinkTodos(t *testing.T) {
 				child, _ := manager.CreateTodo("Task 2", "medium", "feature")
 				return parent.ID, child.ID
 			},
-			linkType:    "blocks",
+			linkType:    "depends-on",
 			expectError: true,
-			errorMsg:    "unsupported link type: blocks",
+			errorMsg:    "unsupported link type",
 		},
 		{
 			name: "empty parent ID",
@@ -326,6 +326,159 @@ func TestLinkTodosErrorRecovery(t *testing.T) {
 	})
 }
 
+// TestLinkTodosBlocks tests that blocks links are stored on both todos and enforced
+func TestLinkTodosBlocks(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "link-blocks-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	manager := NewTodoManager(tempDir)
+	linker := NewTodoLinker(manager)
+
+	blocker, _ := manager.CreateTodo("Design API", "high", "feature")
+	blocked, _ := manager.CreateTodo("Implement endpoints", "medium", "feature")
+
+	err = manager.UpdateTodo(blocked.ID, "findings", "append", "Existing notes", nil)
+	if err != nil {
+		t.Fatalf("Failed to update blocked todo: %v", err)
+	}
+
+	if err := linker.LinkTodos(blocker.ID, blocked.ID, "blocks"); err != nil {
+		t.Fatalf("Failed to link todos: %v", err)
+	}
+	// Linking twice must not duplicate entries
+	if err := linker.LinkTodos(blocker.ID, blocked.ID, "blocks"); err != nil {
+		t.Fatalf("Failed to relink todos: %v", err)
+	}
+
+	updatedBlocker, _ := manager.ReadTodo(blocker.ID)
+	if len(updatedBlocker.Blocks) != 1 || updatedBlocker.Blocks[0] != blocked.ID {
+		t.Errorf("Expected blocks [%s], got %v", blocked.ID, updatedBlocker.Blocks)
+	}
+
+	updatedBlocked, content, err := manager.ReadTodoWithContent(blocked.ID)
+	if err != nil {
+		t.Fatalf("Failed to read blocked todo: %v", err)
+	}
+	if len(updatedBlocked.BlockedBy) != 1 || updatedBlocked.BlockedBy[0] != blocker.ID {
+		t.Errorf("Expected blocked_by [%s], got %v", blocker.ID, updatedBlocked.BlockedBy)
+	}
+	if !strings.Contains(content, "Existing notes") {
+		t.Error("Expected existing content to be preserved after linking")
+	}
+
+	// Starting or completing the blocked todo is refused while the blocker is open
+	for _, status := range []string{"in_progress", "completed"} {
+		err = manager.UpdateTodo(blocked.ID, "", "", "", map[string]string{"status": status})
+		if err == nil || !strings.Contains(err.Error(), blocker.ID) {
+			t.Errorf("Expected status %s to be refused because of %s, got: %v", status, blocker.ID, err)
+		}
+	}
+
+	// Other status changes are still allowed
+	if err := manager.UpdateTodo(blocked.ID, "", "", "", map[string]string{"status": "blocked"}); err != nil {
+		t.Errorf("Expected status blocked to be allowed, got: %v", err)
+	}
+
+	// Once the blocker is completed the blocked todo can start
+	if err := manager.UpdateTodo(blocker.ID, "", "", "", map[string]string{"status": "completed"}); err != nil {
+		t.Fatalf("Failed to complete blocker: %v", err)
+	}
+	open, err := manager.GetOpenBlockers(blocked.ID)
+	if err != nil {
+		t.Fatalf("Failed to get open blockers: %v", err)
+	}
+	if len(open) != 0 {
+		t.Errorf("Expected no open blockers, got %v", open)
+	}
+	if err := manager.UpdateTodo(blocked.ID, "", "", "", map[string]string{"status": "in_progress"}); err != nil {
+		t.Errorf("Expected status in_progress to be allowed, got: %v", err)
+	}
+}
+
+// TestLinkTodosBlocksRejectsCycles tests that self links and dependency cycles are refused
+func TestLinkTodosBlocksRejectsCycles(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "link-cycle-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	manager := NewTodoManager(tempDir)
+	linker := NewTodoLinker(manager)
+
+	a, _ := manager.CreateTodo("Task A", "high", "feature")
+	b, _ := manager.CreateTodo("Task B", "high", "feature")
+	c, _ := manager.CreateTodo("Task C", "high", "feature")
+
+	if err := linker.LinkTodos(a.ID, a.ID, "blocks"); err == nil {
+		t.Error("Expected error when a todo blocks itself")
+	}
+	if err := linker.LinkTodos(a.ID, b.ID, "blocks"); err != nil {
+		t.Fatalf("Failed to link A blocks B: %v", err)
+	}
+	if err := linker.LinkTodos(b.ID, c.ID, "blocks"); err != nil {
+		t.Fatalf("Failed to link B blocks C: %v", err)
+	}
+
+	err = linker.LinkTodos(c.ID, a.ID, "blocks")
+	if err == nil || !strings.Contains(err.Error(), "circular dependency") {
+		t.Errorf("Expected circular dependency error, got: %v", err)
+	}
+}
+
+// TestLinkTodosRelatesTo tests that relates-to links are stored symmetrically
+func TestLinkTodosRelatesTo(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "link-related-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	manager := NewTodoManager(tempDir)
+	linker := NewTodoLinker(manager)
+
+	first, _ := manager.CreateTodo("Investigate flaky test", "medium", "bug")
+	second, _ := manager.CreateTodo("Refactor test harness", "low", "refactor")
+
+	if err := linker.LinkTodos(first.ID, second.ID, "relates-to"); err != nil {
+		t.Fatalf("Failed to link todos: %v", err)
+	}
+
+	updatedFirst, _ := manager.ReadTodo(first.ID)
+	updatedSecond, _ := manager.ReadTodo(second.ID)
+	if len(updatedFirst.Related) != 1 || updatedFirst.Related[0] != second.ID {
+		t.Errorf("Expected related [%s], got %v", second.ID, updatedFirst.Related)
+	}
+	if len(updatedSecond.Related) != 1 || updatedSecond.Related[0] != first.ID {
+		t.Errorf("Expected related [%s], got %v", first.ID, updatedSecond.Related)
+	}
+
+	// Related todos don't gate status changes
+	if err := manager.UpdateTodo(second.ID, "", "", "", map[string]string{"status": "completed"}); err != nil {
+		t.Errorf("Expected completion to be allowed, got: %v", err)
+	}
+}
+
+func TestLinkRelatedRollsBackOnFailure(t *testing.T) {
+	manager := NewTodoManager(t.TempDir())
+	linker := NewTodoLinker(manager)
+
+	first, _ := manager.CreateTodo("Investigate flaky test", "medium", "bug")
+
+	// The second write fails, so the first must not keep a one-sided link
+	if err := linker.linkRelated(first.ID, "no-such-todo"); err == nil {
+		t.Fatal("Expected error linking to a missing todo")
+	}
+
+	updated, _ := manager.ReadTodo(first.ID)
+	if len(updated.Related) != 0 {
+		t.Errorf("Expected no related todos after failed link, got %v", updated.Related)
+	}
+}
+
 // BenchmarkLinkTodos benchmarks the LinkTodos operation
 func BenchmarkLinkTodos(b *testing.B) {
 	// Create temp directory
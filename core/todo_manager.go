@@ -9,6 +9,7 @@ type TodoManager struct {
 	basePath string
 	mu       sync.Mutex
 	idCounts map[string]int // Track ID usage for uniqueness
+	cache    *todoCache     // Parsed metadata, shared per project
 }
 
 // NewTodoManager creates a new todo manager
@@ -16,10 +17,31 @@ func NewTodoManager(basePath string) *TodoManager {
 	return &TodoManager{
 		basePath: basePath,
 		idCounts: make(map[string]int),
+		cache:    cacheForProject(basePath),
 	}
 }
 
 // GetBasePath returns the base path for todo storage
 func (tm *TodoManager) GetBasePath() string {
 	return tm.basePath
-}
\ No newline at end of file
+}
+
+// WatchTodos starts watching the todo tree so edits made outside the server
+// (e.g. in an editor) update the metadata cache. Until it is called, every
+// listing revalidates the cache against the files on disk.
+func (tm *TodoManager) WatchTodos() error {
+	return tm.cache.watch()
+}
+
+// StopWatching releases the watcher started by WatchTodos
+func (tm *TodoManager) StopWatching() {
+	tm.cache.unwatch()
+}
+
+// OnTodoChange calls fn whenever a todo of this project is created, modified
+// or removed, whether by this server or (while watched) by anything else.
+// fn runs on a separate goroutine, in the order changes were seen. The
+// returned function unregisters it.
+func (tm *TodoManager) OnTodoChange(fn func(TodoChange)) func() {
+	return tm.cache.subscribe(fn)
+}
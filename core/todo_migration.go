@@ -132,6 +132,7 @@ func (tm *TodoManager) MigrateToDateStructure() (*MigrationStats, error) {
 
 	// Clear path cache after migration
 	globalPathCache.Clear()
+	tm.cache.invalidate()
 
 	return stats, nil
 }
@@ -273,6 +274,7 @@ func (tm *TodoManager) RollbackMigration() error {
 
 	// Clear path cache
 	globalPathCache.Clear()
+	tm.cache.invalidate()
 
 	fmt.Fprintf(os.Stderr, "Migration rolled back successfully\n")
 	return nil
@@ -8,6 +8,7 @@ import (
 type ChecklistItem struct {
 	Text   string `json:"text"`
 	Status string `json:"status"` // "pending", "in_progress", "completed"
+	ID     string `json:"id,omitempty"` // from a trailing "^id" anchor
 }
 
 // Todo represents a todo item
@@ -21,6 +22,20 @@ type Todo struct {
 	Type      string    `yaml:"type"`
 	ParentID  string    `yaml:"parent_id,omitempty"`
 	Tags      []string  `yaml:"tags,omitempty"`
+	Due       time.Time `yaml:"due,omitempty"`
+	Scheduled time.Time `yaml:"scheduled,omitempty"`
+
+	// Recurring todos spawn a new instance when completed and archived
+	Recurrence         string `yaml:"recurrence,omitempty"`
+	PreviousOccurrence string `yaml:"previous_occurrence,omitempty"`
+
+	// Dependency links (blocks / relates-to), kept in sync on both todos
+	Blocks    []string `yaml:"blocks,omitempty"`
+	BlockedBy []string `yaml:"blocked_by,omitempty"`
+	Related   []string `yaml:"related,omitempty"`
+
+	// Survivor a duplicate was folded into before it was archived
+	MergedInto string `yaml:"merged_into,omitempty"`
 
 	// Section metadata (new)
 	Sections map[string]*SectionDefinition `yaml:"sections,omitempty"`
@@ -5,8 +5,6 @@ import (
 	"gopkg.in/yaml.v3"
 	"io/ioutil"
 	"os"
-	"path/filepath"
-	"sort"
 	"strings"
 	"time"
 	
@@ -54,6 +52,16 @@ func (tm *TodoManager) CreateTodo(task, priority, todoType string) (*Todo, error
 
 // CreateTodoWithTemplate creates a new todo with optional template content
 func (tm *TodoManager) CreateTodoWithTemplate(task, priority, todoType, templateContent string) (*Todo, error) {
+	return tm.CreateTodoWithTemplateSections(task, priority, todoType, templateContent, nil)
+}
+
+// CreateTodoWithTemplateSections creates a new todo with template content
+// and the template's section definitions; nil sections means the defaults
+func (tm *TodoManager) CreateTodoWithTemplateSections(task, priority, todoType, templateContent string, sections map[string]*SectionDefinition) (*Todo, error) {
+	if sections == nil {
+		sections = getDefaultSections()
+	}
+
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
 
@@ -77,7 +85,7 @@ func (tm *TodoManager) CreateTodoWithTemplate(task, priority, todoType, template
 		Status:   "in_progress",
 		Priority: priority,
 		Type:     todoType,
-		Sections: getDefaultSections(),
+		Sections: sections,
 	}
 
 	// Write todo to file (with template content if provided)
@@ -128,6 +136,18 @@ func (tm *TodoManager) UpdateTodo(id, section, operation, content string, metada
 
 		// Update metadata fields
 		if status, ok := metadata["status"]; ok {
+			if status == "completed" || status == "in_progress" {
+				if open := tm.openBlockers(todo); len(open) > 0 {
+					return interrors.NewValidationError("status", status,
+						fmt.Sprintf("blocked by open todos: %s", strings.Join(open, ", ")))
+				}
+			}
+			if status == "completed" {
+				if empty := emptyRequiredSections(todo.Sections, string(fileContent)); len(empty) > 0 {
+					return interrors.NewValidationError("status", status,
+						fmt.Sprintf("required sections are still empty: %s", strings.Join(empty, ", ")))
+				}
+			}
 			todo.Status = status
 			if status == "completed" {
 				todo.Completed = time.Now()
@@ -142,6 +162,34 @@ func (tm *TodoManager) UpdateTodo(id, section, operation, content string, metada
 		if parentID, ok := metadata["parent_id"]; ok {
 			todo.ParentID = parentID
 		}
+		if due, ok := metadata["due"]; ok {
+			parsed, err := ParseDueDate(due)
+			if err != nil {
+				return interrors.NewValidationError("due", due, "expected YYYY-MM-DD, YYYY-MM-DD HH:MM or RFC3339")
+			}
+			todo.Due = parsed
+		}
+		if scheduled, ok := metadata["scheduled"]; ok {
+			parsed, err := ParseDueDate(scheduled)
+			if err != nil {
+				return interrors.NewValidationError("scheduled", scheduled, "expected YYYY-MM-DD, YYYY-MM-DD HH:MM or RFC3339")
+			}
+			todo.Scheduled = parsed
+		}
+		if recurrence, ok := metadata["recurrence"]; ok {
+			if recurrence == "" || strings.EqualFold(recurrence, "none") {
+				todo.Recurrence = ""
+			} else {
+				rule, err := ParseRecurrence(recurrence)
+				if err != nil {
+					return err
+				}
+				todo.Recurrence = rule.String()
+			}
+		}
+		if err := applyTagMetadata(todo, metadata); err != nil {
+			return err
+		}
 		if started, ok := metadata["started"]; ok {
 			// Try multiple time formats
 			formats := []string{
@@ -181,24 +229,106 @@ func (tm *TodoManager) UpdateTodo(id, section, operation, content string, metada
 		if err := ioutil.WriteFile(filename, []byte(updatedContent), 0644); err != nil {
 			return interrors.NewOperationError("write", "todo file", "failed to save changes", err)
 		}
+		tm.cache.refresh(filename)
 		
 		return nil
 	}
 
 	// For section updates, use sophisticated section-aware update
+	_, err = tm.updateTodoSection(id, string(fileContent), section, operation, content)
+	return err
+}
+
+// UpdateTodoSection appends, prepends or replaces a section's content and
+// returns any ways the content doesn't fit the section's schema. Projects
+// with strict settings get those back as a validation error instead, and
+// nothing is written.
+func (tm *TodoManager) UpdateTodoSection(id, section, operation, content string) ([]string, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	filename, err := ResolveTodoPath(tm.basePath, id)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, interrors.NewNotFoundError("todo", id)
+		}
+		return nil, interrors.Wrap(err, "failed to resolve todo path")
+	}
+
+	fileContent, err := ioutil.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, interrors.NewNotFoundError("todo", id)
+		}
+		return nil, interrors.Wrap(err, "failed to read todo")
+	}
+
 	return tm.updateTodoSection(id, string(fileContent), section, operation, content)
 }
 
-// updateTodoSection handles section-specific updates
-func (tm *TodoManager) updateTodoSection(id, fileContent, section, operation, content string) error {
+// UpdateTodoFrontmatter applies mutate to a todo's frontmatter and writes it
+// back without touching the markdown body.
+func (tm *TodoManager) UpdateTodoFrontmatter(id string, mutate func(todo *Todo) error) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	filename, err := ResolveTodoPath(tm.basePath, id)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return interrors.NewNotFoundError("todo", id)
+		}
+		return interrors.Wrap(err, "failed to resolve todo path")
+	}
+
+	fileContent, err := ioutil.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return interrors.NewNotFoundError("todo", id)
+		}
+		return interrors.Wrap(err, "failed to read todo")
+	}
+
+	todo, err := tm.parseTodoFile(string(fileContent))
+	if err != nil {
+		return interrors.Wrap(err, "failed to parse todo")
+	}
+
+	if err := mutate(todo); err != nil {
+		return err
+	}
+
+	updatedContent, err := updateFrontmatter(string(fileContent), todo)
+	if err != nil {
+		return interrors.Wrap(err, "failed to update frontmatter")
+	}
+
+	if err := ioutil.WriteFile(filename, []byte(updatedContent), 0644); err != nil {
+		return interrors.NewOperationError("write", "todo file", "failed to save changes", err)
+	}
+	tm.cache.refresh(filename)
+
+	return nil
+}
+
+// updateTodoSection handles section-specific updates, returning schema
+// warnings about the content written
+func (tm *TodoManager) updateTodoSection(id, fileContent, section, operation, content string) ([]string, error) {
 	// For now, implement a simple section update
 	// This will be replaced with the sophisticated section updater later
 	
+	warnings, err := tm.checkSectionWrite(fileContent, section, operation, content)
+	if err != nil {
+		return nil, err
+	}
+
 	updatedContent := fileContent
 	
 	// Handle special cases
 	if section == "checklist" && operation == "toggle" {
-		updatedContent = toggleChecklistItem(updatedContent, content)
+		updatedContent, err = toggleChecklistSection(updatedContent, content)
+		if err != nil {
+			return nil, err
+		}
 	} else if operation == "append" {
 		// Simple append operation
 		updatedContent = appendToSection(updatedContent, section, content)
@@ -213,14 +343,61 @@ func (tm *TodoManager) updateTodoSection(id, fileContent, section, operation, co
 	// Resolve the path and write back to file
 	filename, err := ResolveTodoPath(tm.basePath, id)
 	if err != nil {
-		return interrors.Wrap(err, "failed to resolve todo path for update")
+		return nil, interrors.Wrap(err, "failed to resolve todo path for update")
 	}
 	
 	if err := ioutil.WriteFile(filename, []byte(updatedContent), 0644); err != nil {
-		return interrors.NewOperationError("write", "todo section", "failed to save section update", err)
+		return nil, interrors.NewOperationError("write", "todo section", "failed to save section update", err)
 	}
+	tm.cache.refresh(filename)
 
-	return nil
+	return warnings, nil
+}
+
+// checkSectionWrite validates content about to be appended, prepended or
+// written over a section against the section's schema. Appends and
+// prepends to test_results are checked as they will be stamped.
+func (tm *TodoManager) checkSectionWrite(fileContent, section, operation, content string) ([]string, error) {
+	if operation != "append" && operation != "prepend" && operation != "replace" {
+		return nil, nil
+	}
+	if operation == "replace" && strings.TrimSpace(content) == "" {
+		return nil, nil
+	}
+
+	var sections map[string]*SectionDefinition
+	if todo, err := tm.parseTodoFile(fileContent); err == nil {
+		sections = todo.Sections
+	}
+	def := sectionDefinitionFor(sections, section)
+	if def == nil {
+		return nil, nil
+	}
+	schema := def.Schema
+
+	written := content
+	if section == "test_results" && operation != "replace" {
+		written = formatWithTimestamp(content)
+	}
+	problems := checkSectionContent(tm.sectionValidator(def), schema, written)
+	if len(problems) == 0 {
+		return nil, nil
+	}
+
+	settings, err := LoadProjectSettings(tm.basePath)
+	if err != nil {
+		return nil, err
+	}
+	if settings.Strict {
+		return nil, interrors.NewValidationError(section, content,
+			fmt.Sprintf("content doesn't match the %s schema: %s", schema, strings.Join(problems, "; ")))
+	}
+
+	warnings := make([]string, len(problems))
+	for i, problem := range problems {
+		warnings[i] = fmt.Sprintf("%s (%s): %s", section, schema, problem)
+	}
+	return warnings, nil
 }
 
 // updateFrontmatter updates the YAML frontmatter while preserving the rest of the content
@@ -243,8 +420,6 @@ func updateFrontmatter(content string, todo *Todo) (string, error) {
 
 // appendToSection appends content to a section
 func appendToSection(fileContent, section, content string) string {
-	lines := strings.Split(fileContent, "\n")
-	
 	// Map section names to their proper titles
 	sectionTitles := map[string]string{
 		"findings":      "Findings & Research",
@@ -270,7 +445,13 @@ func appendToSection(fileContent, section, content string) string {
 		contentToAppend = formatWithTimestamp(content)
 	}
 	
-	sectionHeader := "## " + sectionTitle
+	return appendUnderHeading(fileContent, definedSectionHeader(fileContent, section, "## "+sectionTitle), contentToAppend)
+}
+
+// appendUnderHeading appends content after the last non-empty line below an
+// exact section header, adding the section at the end if it is missing
+func appendUnderHeading(fileContent, sectionHeader, contentToAppend string) string {
+	lines := strings.Split(fileContent, "\n")
 	sectionIndex := -1
 	nextSectionIndex := len(lines)
 	
@@ -302,8 +483,12 @@ func appendToSection(fileContent, section, content string) string {
 	
 	// Handle spacing
 	if lastContentIndex > sectionIndex {
-		// There's existing content, add newline before appending
-		contentToAppend = "\n" + contentToAppend
+		// There's existing content, add newline before appending, unless
+		// the new rows continue a table
+		if !strings.HasPrefix(strings.TrimSpace(lines[lastContentIndex]), "|") ||
+			!strings.HasPrefix(strings.TrimSpace(contentToAppend), "|") {
+			contentToAppend = "\n" + contentToAppend
+		}
 	} else {
 		// Section is empty
 		// Check if there's already an empty line after the header
@@ -346,7 +531,7 @@ func replaceSection(fileContent, section, content string) string {
 		sectionTitle = strings.Title(strings.Replace(section, "_", " ", -1))
 	}
 	
-	sectionHeader := "## " + sectionTitle
+	sectionHeader := definedSectionHeader(fileContent, section, "## "+sectionTitle)
 	sectionIndex := -1
 	nextSectionIndex := len(lines)
 	
@@ -422,7 +607,7 @@ func prependToSection(fileContent, section, content string) string {
 		contentToPrepend = formatWithTimestamp(content)
 	}
 	
-	sectionHeader := "## " + sectionTitle
+	sectionHeader := definedSectionHeader(fileContent, section, "## "+sectionTitle)
 	sectionIndex := -1
 	
 	for i, line := range lines {
@@ -461,104 +646,38 @@ func prependToSection(fileContent, section, content string) string {
 	return strings.Join(newLines, "\n")
 }
 
-// ListTodos lists todos based on filters
+// ListTodos lists todos based on filters, newest first. It answers from the
+// project's metadata cache rather than reading every file.
 func (tm *TodoManager) ListTodos(status, priority string, days int) ([]*Todo, error) {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
 
-	var todos []*Todo
-	todosRoot := filepath.Join(tm.basePath, ".claude", "todos")
-	cutoffTime := time.Now().AddDate(0, 0, -days)
-
-	// Check if todos directory exists
-	if _, err := os.Stat(todosRoot); os.IsNotExist(err) {
-		return []*Todo{}, nil
-	}
-
-	// Optimization: If days filter is active and reasonable, only scan relevant directories
-	if days > 0 && days < 365 {
-		// Use ScanDateRange for optimized scanning
-		startDate := cutoffTime
-		endDate := time.Now()
-		
-		paths, err := ScanDateRange(tm.basePath, startDate, endDate)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "ListTodos: Error in ScanDateRange: %v\n", err)
-			// Fall back to full scan on error
-		} else {
-			// Process found files
-			for _, path := range paths {
-				content, err := ioutil.ReadFile(path)
-				if err != nil {
-					continue
-				}
-
-				todo, err := tm.parseTodoFile(string(content))
-				if err != nil {
-					continue
-				}
-
-				// Apply filters
-				if status != "" && !strings.EqualFold(todo.Status, status) {
-					continue
-				}
-
-				if priority != "" && !strings.EqualFold(todo.Priority, priority) {
-					continue
-				}
-
-				todos = append(todos, todo)
-			}
-			return todos, nil
+	// Day granularity, as the date-folder scan always did: "last 7 days"
+	// starts at the beginning of the 7th day back. Windows under a year
+	// were served from the date folders up to today, so they stop there;
+	// longer ones came from the full walk, which had no upper bound.
+	var cutoff, end time.Time
+	if days > 0 {
+		now := time.Now()
+		cutoff = startOfDay(now.AddDate(0, 0, -days), now)
+		if days < 365 {
+			end = startOfDay(now, now).AddDate(0, 0, 1)
 		}
 	}
 
-	// Full recursive scan for no date filter or fallback
-	err := filepath.Walk(todosRoot, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil // Continue on error
-		}
-
-		// Skip directories and non-markdown files
-		if info.IsDir() || !strings.HasSuffix(info.Name(), ".md") {
-			return nil
-		}
-
-		content, err := ioutil.ReadFile(path)
-		if err != nil {
-			return nil // Skip files we can't read
-		}
-
-		todo, err := tm.parseTodoFile(string(content))
-		if err != nil {
-			return nil // Skip malformed files
-		}
-
-		// Apply filters
+	todos := []*Todo{}
+	for _, todo := range tm.cache.todos() {
 		if status != "" && !strings.EqualFold(todo.Status, status) {
-			return nil
+			continue
 		}
-
 		if priority != "" && !strings.EqualFold(todo.Priority, priority) {
-			return nil
+			continue
 		}
-
-		if days > 0 && todo.Started.Before(cutoffTime) {
-			return nil
+		if days > 0 && (todo.Started.Before(cutoff) || (!end.IsZero() && !todo.Started.Before(end))) {
+			continue
 		}
-
 		todos = append(todos, todo)
-		return nil
-	})
-
-	if err != nil && !os.IsNotExist(err) {
-		return nil, fmt.Errorf("failed to walk todos directory: %w", err)
 	}
 
-	// Sort by started date (newest first)
-	sort.Slice(todos, func(i, j int) bool {
-		return todos[i].Started.After(todos[j].Started)
-	})
-
 	return todos, nil
 }
\ No newline at end of file
@@ -210,8 +210,11 @@ func MigrateToDateStructure(basePath string, todoID string, started time.Time) e
 		return fmt.Errorf("failed to move todo file: %w", err)
 	}
 
-	// Update cache
+	// Update caches
 	globalPathCache.Set(todoID, destPath)
+	cache := cacheForProject(basePath)
+	cache.refresh(sourcePath)
+	cache.refresh(destPath)
 
 	return nil
 }
@@ -0,0 +1,384 @@
+package core
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+// sectionKeyPattern keeps section keys usable as todo_update section names
+var sectionKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_]*$`)
+
+// SectionHeading returns the markdown heading a section is written under.
+// Titles are stored both with and without the "## " prefix.
+func SectionHeading(def *SectionDefinition) string {
+	return "## " + strings.TrimSpace(strings.TrimPrefix(def.Title, "## "))
+}
+
+// sectionBlock is a "## " heading and the lines below it, up to the next
+// heading
+type sectionBlock struct {
+	heading string
+	body    string
+}
+
+// sectionDoc is a todo body split by section. A section's blocks start with
+// its own heading, followed by any headings below it that belong to no
+// section, so they travel with it when sections move.
+type sectionDoc struct {
+	preamble string
+	loose    []sectionBlock
+	order    []string
+	blocks   map[string][]sectionBlock
+}
+
+// splitTodoBody splits markdown into the text before the first "## " heading
+// and one block per heading. Headings inside code fences don't count.
+func splitTodoBody(body string) (string, []sectionBlock) {
+	var preamble []string
+	var blocks []sectionBlock
+	var current []string
+	inFence := false
+
+	flush := func() {
+		if len(blocks) == 0 {
+			preamble = current
+		} else {
+			blocks[len(blocks)-1].body = strings.Join(current, "\n")
+		}
+		current = nil
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+		if !inFence && strings.HasPrefix(trimmed, "## ") {
+			flush()
+			blocks = append(blocks, sectionBlock{heading: trimmed})
+			continue
+		}
+		current = append(current, line)
+	}
+	flush()
+
+	return strings.Join(preamble, "\n"), blocks
+}
+
+// newSectionDoc matches the headings in body to the todo's sections
+func newSectionDoc(sections map[string]*SectionDefinition, body string) *sectionDoc {
+	preamble, blocks := splitTodoBody(body)
+	doc := &sectionDoc{preamble: preamble, blocks: make(map[string][]sectionBlock)}
+
+	byHeading := make(map[string][]string)
+	for _, section := range GetOrderedSections(sections) {
+		doc.order = append(doc.order, section.Key)
+		heading := SectionHeading(section.Definition)
+		byHeading[heading] = append(byHeading[heading], section.Key)
+	}
+
+	owner := ""
+	for _, block := range blocks {
+		if keys := byHeading[block.heading]; len(keys) > 0 {
+			owner = keys[0]
+			byHeading[block.heading] = keys[1:]
+		}
+		if owner == "" {
+			doc.loose = append(doc.loose, block)
+		} else {
+			doc.blocks[owner] = append(doc.blocks[owner], block)
+		}
+	}
+	return doc
+}
+
+// body returns the section's own text, without the headings that follow it
+func (doc *sectionDoc) body(key string) string {
+	if blocks := doc.blocks[key]; len(blocks) > 0 {
+		return blocks[0].body
+	}
+	return ""
+}
+
+// render writes the body back with the sections in doc.order. A section
+// without a heading gets an empty one.
+func (doc *sectionDoc) render(sections map[string]*SectionDefinition) string {
+	var sb strings.Builder
+	sb.WriteString(strings.TrimRight(doc.preamble, " \t\n"))
+
+	writeBlock := func(block sectionBlock) {
+		sb.WriteString("\n\n" + block.heading)
+		if text := strings.TrimRight(strings.TrimLeft(block.body, "\n"), " \t\n"); text != "" {
+			sb.WriteString("\n\n" + text)
+		}
+	}
+	for _, block := range doc.loose {
+		writeBlock(block)
+	}
+	for _, key := range doc.order {
+		blocks := doc.blocks[key]
+		if len(blocks) == 0 {
+			blocks = []sectionBlock{{heading: SectionHeading(sections[key])}}
+		}
+		for _, block := range blocks {
+			writeBlock(block)
+		}
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// SectionBodies returns the text under each section's heading in a todo
+// file, keyed by section
+func SectionBodies(sections map[string]*SectionDefinition, content string) map[string]string {
+	body := content
+	if parts := strings.SplitN(content, "---", 3); len(parts) == 3 {
+		body = parts[2]
+	}
+	doc := newSectionDoc(sections, body)
+
+	bodies := make(map[string]string)
+	for key := range doc.blocks {
+		bodies[key] = doc.body(key)
+	}
+	return bodies
+}
+
+// editSections applies edit to a todo's section definitions and markdown
+// together, then writes the file with its headings in section order
+func (tm *TodoManager) editSections(id string, edit func(todo *Todo, doc *sectionDoc) error) (*Todo, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	filename, err := ResolveTodoPath(tm.basePath, id)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, interrors.NewNotFoundError("todo", id)
+		}
+		return nil, interrors.Wrap(err, "failed to resolve todo path")
+	}
+
+	fileContent, err := ioutil.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, interrors.NewNotFoundError("todo", id)
+		}
+		return nil, interrors.Wrap(err, "failed to read todo")
+	}
+
+	parts := strings.SplitN(string(fileContent), "---", 3)
+	if len(parts) < 3 {
+		return nil, interrors.NewValidationError("content", id, "invalid todo file format: missing frontmatter")
+	}
+	todo, err := tm.parseTodoFile(string(fileContent))
+	if err != nil {
+		return nil, interrors.Wrap(err, "failed to parse todo")
+	}
+
+	if len(todo.Sections) == 0 {
+		// Todos from before section metadata declare none; take them from
+		// the headings, so the existing sections can be edited too
+		todo.Sections = InferSectionsFromMarkdown(parts[2])
+	}
+	doc := newSectionDoc(todo.Sections, parts[2])
+	if err := edit(todo, doc); err != nil {
+		return nil, err
+	}
+	for i, key := range doc.order {
+		todo.Sections[key].Order = i + 1
+	}
+
+	updated, err := updateFrontmatter("---"+parts[1]+"---"+doc.render(todo.Sections), todo)
+	if err != nil {
+		return nil, interrors.Wrap(err, "failed to update frontmatter")
+	}
+	if err := ioutil.WriteFile(filename, []byte(updated), 0644); err != nil {
+		return nil, interrors.NewOperationError("write", "todo file", "failed to save section changes", err)
+	}
+	tm.cache.refresh(filename)
+
+	return todo, nil
+}
+
+// AddSection adds a custom section with an empty heading; a table with
+// declared columns starts with its header row. position is 1-based; zero or
+// past the end adds it last.
+func (tm *TodoManager) AddSection(id, key string, def *SectionDefinition, position int) (*Todo, error) {
+	if !sectionKeyPattern.MatchString(key) {
+		return nil, interrors.NewValidationError("key", key, "section keys may only contain lowercase letters, digits and '_'")
+	}
+	section := *def
+	if section.Schema == "" {
+		section.Schema = SchemaFreeform
+	}
+	if !isValidSchema(section.Schema) {
+		return nil, interrors.NewValidationError("schema", string(section.Schema), "invalid schema type")
+	}
+	if section.Title == "" {
+		section.Title = strings.Title(strings.ReplaceAll(key, "_", " "))
+	}
+	section.Custom = true
+
+	body := ""
+	if columns := tableColumns(&section); section.Schema == SchemaTable && len(columns) > 0 {
+		separators := make([]string, len(columns))
+		for i := range separators {
+			separators[i] = "---"
+		}
+		body = "| " + strings.Join(columns, " | ") + " |\n| " + strings.Join(separators, " | ") + " |"
+	}
+
+	return tm.editSections(id, func(todo *Todo, doc *sectionDoc) error {
+		if _, exists := todo.Sections[key]; exists {
+			return interrors.NewConflictError("section", key, "section already exists")
+		}
+		todo.Sections[key] = &section
+		doc.blocks[key] = []sectionBlock{{heading: SectionHeading(&section), body: body}}
+
+		at := len(doc.order)
+		if position > 0 && position <= len(doc.order) {
+			at = position - 1
+		}
+		doc.order = append(doc.order[:at], append([]string{key}, doc.order[at:]...)...)
+		return nil
+	})
+}
+
+// RenameSection changes a section's key, its title or both. A new title
+// renames the markdown heading too.
+func (tm *TodoManager) RenameSection(id, key, newKey, newTitle string) (*Todo, error) {
+	if newKey == "" {
+		newKey = key
+	}
+	if !sectionKeyPattern.MatchString(newKey) {
+		return nil, interrors.NewValidationError("new_key", newKey, "section keys may only contain lowercase letters, digits and '_'")
+	}
+
+	return tm.editSections(id, func(todo *Todo, doc *sectionDoc) error {
+		def, exists := todo.Sections[key]
+		if !exists {
+			return interrors.NewNotFoundError("section", key)
+		}
+		if newKey != key {
+			if _, taken := todo.Sections[newKey]; taken {
+				return interrors.NewConflictError("section", newKey, "section already exists")
+			}
+			delete(todo.Sections, key)
+			todo.Sections[newKey] = def
+			def.Custom = true
+
+			doc.blocks[newKey] = doc.blocks[key]
+			delete(doc.blocks, key)
+			for i, k := range doc.order {
+				if k == key {
+					doc.order[i] = newKey
+				}
+			}
+		}
+
+		if newTitle != "" {
+			def.Title = newTitle
+			if blocks := doc.blocks[newKey]; len(blocks) > 0 {
+				blocks[0].heading = SectionHeading(def)
+			}
+		}
+		return nil
+	})
+}
+
+// ReorderSections puts the given sections first, in that order; the rest
+// follow in their current order
+func (tm *TodoManager) ReorderSections(id string, keys []string) (*Todo, error) {
+	return tm.editSections(id, func(todo *Todo, doc *sectionDoc) error {
+		listed := make(map[string]bool)
+		for _, key := range keys {
+			if _, exists := todo.Sections[key]; !exists {
+				return interrors.NewNotFoundError("section", key)
+			}
+			if listed[key] {
+				return interrors.NewValidationError("order", key, fmt.Sprintf("section '%s' is listed more than once", key))
+			}
+			listed[key] = true
+		}
+
+		order := append([]string{}, keys...)
+		for _, key := range doc.order {
+			if !listed[key] {
+				order = append(order, key)
+			}
+		}
+		doc.order = order
+		return nil
+	})
+}
+
+// RemoveSection drops an empty, non-required section and its heading
+func (tm *TodoManager) RemoveSection(id, key string) (*Todo, error) {
+	return tm.editSections(id, func(todo *Todo, doc *sectionDoc) error {
+		def, exists := todo.Sections[key]
+		if !exists {
+			return interrors.NewNotFoundError("section", key)
+		}
+		if def.Required {
+			return interrors.NewValidationError("section", key, "section is required and can't be removed")
+		}
+		if !sectionIsEmpty(def, doc.body(key)) {
+			return interrors.NewValidationError("section", key, "section has content; clear it with todo_update operation=replace before removing it")
+		}
+
+		// Headings that followed the section stay where they were
+		at := 0
+		for i, k := range doc.order {
+			if k == key {
+				at = i
+			}
+		}
+		if blocks := doc.blocks[key]; len(blocks) > 1 {
+			if at > 0 {
+				prev := doc.order[at-1]
+				doc.blocks[prev] = append(doc.blocks[prev], blocks[1:]...)
+			} else {
+				doc.loose = append(doc.loose, blocks[1:]...)
+			}
+		}
+
+		delete(todo.Sections, key)
+		delete(doc.blocks, key)
+		doc.order = append(doc.order[:at], doc.order[at+1:]...)
+		return nil
+	})
+}
+
+// definedSectionHeader returns the heading the frontmatter gives a section
+// when the file has it, otherwise fallback
+func definedSectionHeader(fileContent, section, fallback string) string {
+	parts := strings.SplitN(fileContent, "---", 3)
+	if len(parts) < 3 {
+		return fallback
+	}
+	var frontmatter struct {
+		Sections map[string]*SectionDefinition `yaml:"sections"`
+	}
+	if err := yaml.Unmarshal([]byte(parts[1]), &frontmatter); err != nil {
+		return fallback
+	}
+	def := frontmatter.Sections[section]
+	if def == nil || def.Title == "" {
+		return fallback
+	}
+
+	heading := SectionHeading(def)
+	for _, line := range strings.Split(parts[2], "\n") {
+		if strings.TrimSpace(line) == heading {
+			return heading
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,146 @@
+package core
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+// headingsOf lists the "## " headings of a todo file in order
+func headingsOf(content string) []string {
+	var headings []string
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "## ") {
+			headings = append(headings, strings.TrimPrefix(line, "## "))
+		}
+	}
+	return headings
+}
+
+func TestSectionEditing(t *testing.T) {
+	manager := NewTodoManager(t.TempDir())
+	todo, err := manager.CreateTodo("Harden session handling", "high", "feature")
+	if err != nil {
+		t.Fatalf("Failed to create todo: %v", err)
+	}
+	manager.UpdateTodo(todo.ID, "findings", "append", "Sessions never expire", nil)
+
+	// A custom section lands at its position, and todo_update finds it
+	if _, err := manager.AddSection(todo.ID, "risks", &SectionDefinition{Title: "Open Risks", Schema: SchemaChecklist}, 2); err != nil {
+		t.Fatalf("AddSection failed: %v", err)
+	}
+	if err := manager.UpdateTodo(todo.ID, "risks", "append", "- [ ] Token replay", nil); err != nil {
+		t.Fatalf("UpdateTodo on a custom section failed: %v", err)
+	}
+	content, _ := manager.ReadTodoContent(todo.ID)
+	headings := headingsOf(content)
+	if headings[0] != "Findings & Research" || headings[1] != "Open Risks" || len(headings) != 9 {
+		t.Errorf("Expected the new heading second of nine, got %v", headings)
+	}
+	if !strings.Contains(content, "## Open Risks\n\n- [ ] Token replay\n") {
+		t.Errorf("Expected the item under the new heading, got:\n%s", content)
+	}
+
+	// Renaming moves the key and the heading, keeping the body
+	if _, err := manager.RenameSection(todo.ID, "findings", "research_notes", "Research Notes"); err != nil {
+		t.Fatalf("RenameSection failed: %v", err)
+	}
+	updated, err := manager.ReorderSections(todo.ID, []string{"scratchpad", "risks"})
+	if err != nil {
+		t.Fatalf("ReorderSections failed: %v", err)
+	}
+	content, _ = manager.ReadTodoContent(todo.ID)
+	expected := []string{"Working Scratchpad", "Open Risks", "Research Notes", "Web Searches"}
+	if got := headingsOf(content); strings.Join(got[:4], "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected headings to start %v, got %v", expected, got)
+	}
+	if !strings.Contains(content, "## Research Notes\n\nSessions never expire\n\n## Web Searches") {
+		t.Errorf("Expected the renamed section to keep its body, got:\n%s", content)
+	}
+	for i, section := range GetOrderedSections(updated.Sections) {
+		if heading := SectionHeading(section.Definition); heading != "## "+headingsOf(content)[i] {
+			t.Errorf("Section %d is %s in the frontmatter but %s in the markdown", i+1, heading, headingsOf(content)[i])
+		}
+	}
+	if !strings.HasPrefix(content, "---\n") || !strings.Contains(content, "research_notes:") || strings.Contains(content, "findings:") {
+		t.Errorf("Expected the frontmatter to use the new key, got:\n%s", content)
+	}
+
+	// Only empty, optional sections can go
+	if _, err := manager.RemoveSection(todo.ID, "research_notes"); !interrors.IsValidation(err) {
+		t.Errorf("Expected a section with content to be kept, got %v", err)
+	}
+	manager.UpdateTodoFrontmatter(todo.ID, func(todo *Todo) error {
+		todo.Sections["checklist"].Required = true
+		return nil
+	})
+	if _, err := manager.RemoveSection(todo.ID, "checklist"); !interrors.IsValidation(err) {
+		t.Errorf("Expected a required section to be kept, got %v", err)
+	}
+	updated, err = manager.RemoveSection(todo.ID, "web_searches")
+	if err != nil {
+		t.Fatalf("RemoveSection failed: %v", err)
+	}
+	content, _ = manager.ReadTodoContent(todo.ID)
+	if strings.Contains(content, "## Web Searches") || updated.Sections["web_searches"] != nil || len(updated.Sections) != 8 {
+		t.Errorf("Expected web_searches to be gone, got:\n%s", content)
+	}
+
+	if _, err := manager.AddSection(todo.ID, "risks", &SectionDefinition{}, 0); !interrors.IsConflict(err) {
+		t.Errorf("Expected a conflict adding an existing key, got %v", err)
+	}
+	if _, err := manager.ReorderSections(todo.ID, []string{"nowhere"}); !interrors.IsNotFound(err) {
+		t.Errorf("Expected not found reordering an unknown section, got %v", err)
+	}
+}
+
+func TestSectionBodiesIgnoresFencedHeadings(t *testing.T) {
+	sections := map[string]*SectionDefinition{
+		"tests": {Title: "Test Cases", Order: 1, Schema: SchemaTestCases},
+		"notes": {Title: "## Notes", Order: 2, Schema: SchemaFreeform},
+	}
+	content := "---\ntodo_id: x\n---\n\n# Task: x\n\n## Test Cases\n\n```markdown\n## Not a section\n```\n\n## Notes\n\nDone\n"
+
+	bodies := SectionBodies(sections, content)
+	if !strings.Contains(bodies["tests"], "## Not a section") || strings.TrimSpace(bodies["notes"]) != "Done" {
+		t.Errorf("Unexpected bodies: %q", bodies)
+	}
+}
+
+func TestSectionEditingOnLegacyTodo(t *testing.T) {
+	basePath := t.TempDir()
+	manager := NewTodoManager(basePath)
+	todo, _ := manager.CreateTodo("Legacy task", "high", "bug")
+
+	// Written before section metadata existed: headings but no sections:
+	path, _ := ResolveTodoPath(basePath, todo.ID)
+	legacy := "---\ntodo_id: " + todo.ID + "\nstarted: 2025-01-18T10:00:00Z\nstatus: in_progress\npriority: high\ntype: bug\n---\n\n" +
+		"# Task: Legacy task\n\n## Findings & Research\n\nCache misses on login\n\n## Checklist\n\n- [ ] Reproduce\n"
+	if err := os.WriteFile(path, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := manager.AddSection(todo.ID, "risks", &SectionDefinition{Title: "Risks"}, 1); err != nil {
+		t.Fatalf("AddSection failed: %v", err)
+	}
+	content, _ := manager.ReadTodoContent(todo.ID)
+	if headings := headingsOf(content); strings.Join(headings, "|") != "Risks|Findings & Research|Checklist" {
+		t.Errorf("Expected the new section first, got %v", headings)
+	}
+
+	if _, err := manager.ReorderSections(todo.ID, []string{"checklist", "findings", "risks"}); err != nil {
+		t.Fatalf("Expected the existing sections to be reorderable, got %v", err)
+	}
+	if _, err := manager.RemoveSection(todo.ID, "findings"); err == nil {
+		t.Error("Expected a section with content to be kept")
+	}
+	updated, content, _ := manager.ReadTodoWithContent(todo.ID)
+	if headings := headingsOf(content); strings.Join(headings, "|") != "Checklist|Findings & Research|Risks" {
+		t.Errorf("Expected the reordered headings, got %v", headings)
+	}
+	if len(updated.Sections) != 3 || updated.Sections["checklist"] == nil || !strings.Contains(content, "- [ ] Reproduce") {
+		t.Errorf("Expected the frontmatter to declare all three sections, got %+v", updated.Sections)
+	}
+}
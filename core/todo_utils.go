@@ -111,98 +111,19 @@ func extractTask(content string) string {
 	return ""
 }
 
-// toggleChecklistItem toggles a checklist item between states
-func toggleChecklistItem(content string, itemText string) string {
-	lines := strings.Split(content, "\n")
-	
-	for i, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		
-		// Check all possible checkbox formats
-		var itemContent string
-		var currentMarker string
-		
-		if strings.HasPrefix(trimmed, "- [ ]") {
-			itemContent = strings.TrimSpace(trimmed[5:])
-			currentMarker = "[ ]"
-		} else if strings.HasPrefix(trimmed, "- [x]") || strings.HasPrefix(trimmed, "- [X]") {
-			itemContent = strings.TrimSpace(trimmed[5:])
-			currentMarker = "[x]"
-			// Handle uppercase X
-			if strings.HasPrefix(trimmed, "- [X]") {
-				currentMarker = "[X]"
-			}
-		} else if strings.HasPrefix(trimmed, "- [>]") {
-			itemContent = strings.TrimSpace(trimmed[5:])
-			currentMarker = "[>]"
-		} else if strings.HasPrefix(trimmed, "- [-]") {
-			itemContent = strings.TrimSpace(trimmed[5:])
-			currentMarker = "[-]"
-		} else if strings.HasPrefix(trimmed, "- [~]") {
-			itemContent = strings.TrimSpace(trimmed[5:])
-			currentMarker = "[~]"
-		} else {
-			continue
-		}
-		
-		if itemContent == itemText {
-			// Toggle the checkbox state: pending -> in_progress -> completed -> pending
-			var newMarker string
-			switch currentMarker {
-			case "[ ]":
-				newMarker = "[>]"
-			case "[>]", "[-]", "[~]":
-				newMarker = "[x]"
-			case "[x]", "[X]":
-				newMarker = "[ ]"
-			}
-			
-			// Preserve the original indentation
-			leadingWhitespace := line[:len(line)-len(trimmed)]
-			lines[i] = leadingWhitespace + "- " + newMarker + " " + itemContent
-			break
-		}
-	}
-	
-	return strings.Join(lines, "\n")
-}
-
-// ParseChecklist parses checklist items from content
+// ParseChecklist parses checklist items from content, nested items
+// included, in document order
 func ParseChecklist(content string) []ChecklistItem {
 	var items []ChecklistItem
-	lines := strings.Split(content, "\n")
-	
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		
-		// Parse checklist items regardless of section
-		if strings.HasPrefix(trimmed, "- [ ]") {
-			text := strings.TrimSpace(trimmed[5:])
-			if text != "" { // Skip empty items
-				items = append(items, ChecklistItem{
-					Text:   text,
-					Status: "pending",
-				})
-			}
-		} else if strings.HasPrefix(trimmed, "- [x]") || strings.HasPrefix(trimmed, "- [X]") {
-			text := strings.TrimSpace(trimmed[5:])
-			if text != "" { // Skip empty items
-				items = append(items, ChecklistItem{
-					Text:   text,
-					Status: "completed",
-				})
-			}
-		} else if strings.HasPrefix(trimmed, "- [>]") || strings.HasPrefix(trimmed, "- [-]") || strings.HasPrefix(trimmed, "- [~]") {
-			text := strings.TrimSpace(trimmed[5:])
-			if text != "" { // Skip empty items
-				items = append(items, ChecklistItem{
-					Text:   text,
-					Status: "in_progress",
-				})
-			}
+	for _, line := range strings.Split(content, "\n") {
+		if item, ok := parseChecklistLine(line); ok {
+			items = append(items, ChecklistItem{
+				Text:   item.text,
+				Status: checklistMarkers[item.marker],
+				ID:     item.id,
+			})
 		}
 	}
-	
 	return items
 }
 
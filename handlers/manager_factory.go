@@ -28,6 +28,10 @@ type ManagerFactory struct {
 	lastFailureTime   map[string]time.Time
 	maxCreationAttempts int
 	backoffDuration   time.Duration
+
+	// Notified of todo changes in every project manager
+	changeListener     func(manager TodoManager, change core.TodoChange)
+	stopBaseListening  func()
 }
 
 // managerSet contains all managers for a specific directory
@@ -37,6 +41,7 @@ type managerSet struct {
 	stats        StatsEngine
 	templates    TemplateManager
 	lastAccessed time.Time
+	stopListening func()
 }
 
 // NewManagerFactory creates a new manager factory with a base manager for fallback
@@ -118,6 +123,10 @@ func (f *ManagerFactory) GetManagers(ctx context.Context) (TodoManager, SearchEn
 
 	// Create managers - TodoManager expects the working directory, not the todos path
 	manager := core.NewTodoManager(workingDir)
+	if err := manager.WatchTodos(); err != nil {
+		// Listings still work, they just revalidate against disk each time
+		logging.Warnf("Failed to watch todos for %s: %v", workingDir, err)
+	}
 	
 	// Create search engine with timeout and graceful fallback
 	search, err := f.createSearchEngineWithTimeout(ctx, indexPath, todoPath)
@@ -128,16 +137,20 @@ func (f *ManagerFactory) GetManagers(ctx context.Context) (TodoManager, SearchEn
 	}
 
 	stats := core.NewStatsEngine(manager)
-	templates := core.NewTemplateManager(templatePath)
+	templates := core.NewTemplateManagerWithTodos(templatePath, manager)
 
 	// Cache the managers
-	f.managers[workingDir] = &managerSet{
+	set := &managerSet{
 		manager:      manager,
 		search:       search,
 		stats:        stats,
 		templates:    templates,
 		lastAccessed: time.Now(),
 	}
+	if f.changeListener != nil {
+		set.stopListening = listenForChanges(manager, f.changeListener)
+	}
+	f.managers[workingDir] = set
 
 	// Record successful creation to reset circuit breaker
 	f.recordManagerCreationSuccess(workingDir)
@@ -156,6 +169,13 @@ func (f *ManagerFactory) CleanupStale(maxAge time.Duration) int {
 
 	for dir, set := range f.managers {
 		if now.Sub(set.lastAccessed) > maxAge {
+			// Stop watching the project's todo files
+			if set.stopListening != nil {
+				set.stopListening()
+			}
+			if watcher, ok := set.manager.(interface{ StopWatching() }); ok {
+				watcher.StopWatching()
+			}
 			// Close search engine if it has a Close method
 			if closer, ok := set.search.(interface{ Close() error }); ok {
 				if err := closer.Close(); err != nil {
@@ -175,6 +195,71 @@ func (f *ManagerFactory) CleanupStale(maxAge time.Duration) int {
 	return removed
 }
 
+// SetChangeListener reports todo changes from the base manager and every
+// project manager, including ones created later
+func (f *ManagerFactory) SetChangeListener(fn func(manager TodoManager, change core.TodoChange)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.changeListener = fn
+	if f.stopBaseListening != nil {
+		f.stopBaseListening()
+	}
+	if f.baseManager != nil {
+		f.stopBaseListening = listenForChanges(f.baseManager, fn)
+	}
+	for _, set := range f.managers {
+		if set.stopListening != nil {
+			set.stopListening()
+		}
+		set.stopListening = listenForChanges(set.manager, fn)
+	}
+}
+
+// Managers returns the base manager followed by the cached project managers
+func (f *ManagerFactory) Managers() []TodoManager {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var managers []TodoManager
+	if f.baseManager != nil {
+		managers = append(managers, f.baseManager)
+	}
+	for _, set := range f.managers {
+		managers = append(managers, set.manager)
+	}
+	return managers
+}
+
+// CachedSearch returns the search engine of a project the factory already
+// has managers for, or the base one for an empty dir. It never creates
+// managers, so callers can't make it touch arbitrary directories.
+func (f *ManagerFactory) CachedSearch(workingDir string) (SearchEngine, bool) {
+	if workingDir == "" {
+		return f.baseSearch, true
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	set, exists := f.managers[workingDir]
+	if !exists {
+		return nil, false
+	}
+	return set.search, true
+}
+
+// listenForChanges subscribes fn to a manager's todo changes if the manager
+// supports it (test doubles don't)
+func listenForChanges(manager TodoManager, fn func(manager TodoManager, change core.TodoChange)) func() {
+	coreManager, ok := manager.(*core.TodoManager)
+	if !ok {
+		return func() {}
+	}
+	return coreManager.OnTodoChange(func(change core.TodoChange) {
+		fn(manager, change)
+	})
+}
+
 // GetActiveCount returns the number of cached manager sets
 func (f *ManagerFactory) GetActiveCount() int {
 	f.mu.RLock()
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/user/mcp-todo-server/core"
+)
+
+func TestExtractTodoReadParams_DueFilters(t *testing.T) {
+	req := &MockCallToolRequest{
+		Arguments: map[string]interface{}{
+			"filter": map[string]interface{}{
+				"overdue":         true,
+				"due_within_days": float64(7),
+			},
+			"sort": "due",
+		},
+	}
+
+	params, err := ExtractTodoReadParams(req.ToCallToolRequest())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !params.Filter.Overdue || params.Filter.DueWithinDays != 7 || params.Sort != "due" {
+		t.Errorf("Unexpected params: %+v", params)
+	}
+
+	req.Arguments = map[string]interface{}{"sort": "priority"}
+	if _, err := ExtractTodoReadParams(req.ToCallToolRequest()); err == nil {
+		t.Error("Expected error for invalid sort")
+	}
+}
+
+func TestExtractTodoCreateParams_DueDates(t *testing.T) {
+	req := &MockCallToolRequest{
+		Arguments: map[string]interface{}{
+			"task":      "Ship release notes",
+			"due":       "2026-10-20",
+			"scheduled": "2026-10-18",
+		},
+	}
+
+	params, err := ExtractTodoCreateParams(req.ToCallToolRequest())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if params.Due != "2026-10-20" || params.Scheduled != "2026-10-18" {
+		t.Errorf("Unexpected dates: due=%s scheduled=%s", params.Due, params.Scheduled)
+	}
+
+	req.Arguments["due"] = "tomorrow"
+	_, err = ExtractTodoCreateParams(req.ToCallToolRequest())
+	if err == nil || !strings.Contains(err.Error(), "due date") {
+		t.Errorf("Expected due date error, got: %v", err)
+	}
+}
+
+func TestFormatTodoAgendaResponse(t *testing.T) {
+	now := time.Date(2026, 10, 16, 10, 0, 0, 0, time.Local)
+	today := time.Date(2026, 10, 16, 0, 0, 0, 0, time.Local)
+	todos := []*core.Todo{
+		{ID: "fix-login", Task: "Fix login", Status: "in_progress", Priority: "high", Due: today.AddDate(0, 0, -1)},
+		{ID: "write-docs", Task: "Write docs", Status: "pending", Priority: "medium", Due: today.AddDate(0, 0, 2)},
+		{ID: "cleanup", Task: "Cleanup", Status: "pending", Priority: "low"},
+	}
+
+	result := FormatTodoAgendaResponse(core.BuildAgenda(todos, now), now)
+	text := result.Content[0].(mcp.TextContent).Text
+
+	expected := []string{
+		"OVERDUE (1):",
+		"fix-login: Fix login [HIGH] (due 2026-10-15)",
+		"THIS WEEK (1):",
+		"write-docs: Write docs",
+		"1 open todo(s) have no due or scheduled date.",
+	}
+	for _, want := range expected {
+		if !strings.Contains(text, want) {
+			t.Errorf("Agenda should contain %q, got:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "TODAY") {
+		t.Errorf("Empty groups should be omitted, got:\n%s", text)
+	}
+}
+
+func TestExtractRecurrenceParams(t *testing.T) {
+	req := &MockCallToolRequest{
+		Arguments: map[string]interface{}{
+			"task":       "Weekly dependency audit",
+			"recurrence": "FREQ=WEEKLY;BYDAY=MO",
+		},
+	}
+	params, err := ExtractTodoCreateParams(req.ToCallToolRequest())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if params.Recurrence != "FREQ=WEEKLY;BYDAY=MO" {
+		t.Errorf("Unexpected recurrence: %s", params.Recurrence)
+	}
+
+	req.Arguments["recurrence"] = "FREQ=HOURLY"
+	if _, err := ExtractTodoCreateParams(req.ToCallToolRequest()); err == nil {
+		t.Error("Expected error for unsupported recurrence")
+	}
+
+	// "none" stops a todo from recurring
+	req.Arguments = map[string]interface{}{
+		"id":       "weekly-dependency-audit",
+		"metadata": map[string]interface{}{"recurrence": "none"},
+	}
+	updateParams, err := ExtractTodoUpdateParams(req.ToCallToolRequest())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if updateParams.Metadata.Recurrence != "none" {
+		t.Errorf("Unexpected metadata recurrence: %s", updateParams.Metadata.Recurrence)
+	}
+}
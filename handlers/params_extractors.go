@@ -2,7 +2,11 @@ package handlers
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/user/mcp-todo-server/core"
+	interrors "github.com/user/mcp-todo-server/internal/errors"
 )
 
 // ExtractTodoCreateParams extracts and validates todo_create parameters
@@ -38,6 +42,28 @@ func ExtractTodoCreateParams(request mcp.CallToolRequest) (*TodoCreateParams, er
 		params.ParentID = parentID
 	}
 
+	if due, ok := args["due"].(string); ok {
+		params.Due = due
+	}
+
+	if scheduled, ok := args["scheduled"].(string); ok {
+		params.Scheduled = scheduled
+	}
+
+	if recurrence, ok := args["recurrence"].(string); ok {
+		params.Recurrence = recurrence
+	}
+
+	tags, err := extractTags(args, "tags")
+	if err != nil {
+		return nil, err
+	}
+	params.Tags = tags
+
+	if checkSimilar, ok := args["check_similar"].(bool); ok {
+		params.CheckSimilar = checkSimilar
+	}
+
 	// Validate enums
 	if !isValidPriority(params.Priority) {
 		return nil, fmt.Errorf("invalid priority '%s', must be one of: high, medium, low", params.Priority)
@@ -52,6 +78,20 @@ func ExtractTodoCreateParams(request mcp.CallToolRequest) (*TodoCreateParams, er
 		return nil, fmt.Errorf("type '%s' requires parent_id to be specified", params.Type)
 	}
 
+	if _, err := core.ParseDueDate(params.Due); err != nil {
+		return nil, fmt.Errorf("invalid due date '%s', use YYYY-MM-DD or RFC3339", params.Due)
+	}
+
+	if _, err := core.ParseDueDate(params.Scheduled); err != nil {
+		return nil, fmt.Errorf("invalid scheduled date '%s', use YYYY-MM-DD or RFC3339", params.Scheduled)
+	}
+
+	if params.Recurrence != "" {
+		if _, err := core.ParseRecurrence(params.Recurrence); err != nil {
+			return nil, fmt.Errorf("invalid recurrence '%s', use e.g. FREQ=WEEKLY;BYDAY=MO or FREQ=MONTHLY;BYMONTHDAY=1", params.Recurrence)
+		}
+	}
+
 	return params, nil
 }
 
@@ -78,6 +118,29 @@ func ExtractTodoReadParams(request mcp.CallToolRequest) (*TodoReadParams, error)
 		if days, ok := filterObj["days"].(float64); ok {
 			params.Filter.Days = int(days)
 		}
+		if overdue, ok := filterObj["overdue"].(bool); ok {
+			params.Filter.Overdue = overdue
+		}
+		if dueWithin, ok := filterObj["due_within_days"].(float64); ok {
+			params.Filter.DueWithinDays = int(dueWithin)
+		}
+
+		var err error
+		if params.Filter.Tags, err = extractTags(filterObj, "tags"); err != nil {
+			return nil, err
+		}
+		if params.Filter.AnyTags, err = extractTags(filterObj, "any_tags"); err != nil {
+			return nil, err
+		}
+	}
+
+	// Sort order with default
+	params.Sort = "started"
+	if sortBy, ok := args["sort"].(string); ok && sortBy != "" {
+		params.Sort = sortBy
+	}
+	if params.Sort != "started" && params.Sort != "due" {
+		return nil, fmt.Errorf("invalid sort '%s', must be one of: started, due", params.Sort)
 	}
 
 	// Format with default
@@ -88,7 +151,7 @@ func ExtractTodoReadParams(request mcp.CallToolRequest) (*TodoReadParams, error)
 
 	// Validate format
 	if !isValidFormat(params.Format) {
-		return nil, fmt.Errorf("invalid format '%s', must be one of: full, summary, list", params.Format)
+		return nil, fmt.Errorf("invalid format '%s', must be one of: full, summary, list, mermaid, dot", params.Format)
 	}
 
 	return params, nil
@@ -133,6 +196,26 @@ func ExtractTodoUpdateParams(request mcp.CallToolRequest) (*TodoUpdateParams, er
 		if currentTest, ok := metadataObj["current_test"].(string); ok {
 			params.Metadata.CurrentTest = currentTest
 		}
+		if due, ok := metadataObj["due"].(string); ok {
+			params.Metadata.Due = due
+		}
+		if scheduled, ok := metadataObj["scheduled"].(string); ok {
+			params.Metadata.Scheduled = scheduled
+		}
+		if recurrence, ok := metadataObj["recurrence"].(string); ok {
+			params.Metadata.Recurrence = recurrence
+		}
+
+		var err error
+		if params.Metadata.Tags, err = extractTags(metadataObj, "tags"); err != nil {
+			return nil, err
+		}
+		if params.Metadata.AddTags, err = extractTags(metadataObj, "add_tags"); err != nil {
+			return nil, err
+		}
+		if params.Metadata.RemoveTags, err = extractTags(metadataObj, "remove_tags"); err != nil {
+			return nil, err
+		}
 	}
 
 	// Validate operation
@@ -145,6 +228,20 @@ func ExtractTodoUpdateParams(request mcp.CallToolRequest) (*TodoUpdateParams, er
 		return nil, fmt.Errorf("invalid priority '%s' in metadata", params.Metadata.Priority)
 	}
 
+	if _, err := core.ParseDueDate(params.Metadata.Due); err != nil {
+		return nil, fmt.Errorf("invalid due date '%s' in metadata, use YYYY-MM-DD, RFC3339 or none", params.Metadata.Due)
+	}
+
+	if _, err := core.ParseDueDate(params.Metadata.Scheduled); err != nil {
+		return nil, fmt.Errorf("invalid scheduled date '%s' in metadata, use YYYY-MM-DD, RFC3339 or none", params.Metadata.Scheduled)
+	}
+
+	if params.Metadata.Recurrence != "" && !strings.EqualFold(params.Metadata.Recurrence, "none") {
+		if _, err := core.ParseRecurrence(params.Metadata.Recurrence); err != nil {
+			return nil, fmt.Errorf("invalid recurrence '%s' in metadata, use e.g. FREQ=DAILY;INTERVAL=3 or none", params.Metadata.Recurrence)
+		}
+	}
+
 	return params, nil
 }
 
@@ -176,12 +273,29 @@ func ExtractTodoSearchParams(request mcp.CallToolRequest) (*TodoSearchParams, er
 		if status, ok := filtersObj["status"].(string); ok {
 			params.Filters.Status = status
 		}
+		if priority, ok := filtersObj["priority"].(string); ok {
+			params.Filters.Priority = priority
+		}
+		if todoType, ok := filtersObj["type"].(string); ok {
+			params.Filters.Type = todoType
+		}
+		if month, ok := filtersObj["month"].(string); ok {
+			params.Filters.Month = month
+		}
 		if dateFrom, ok := filtersObj["date_from"].(string); ok {
 			params.Filters.DateFrom = dateFrom
 		}
 		if dateTo, ok := filtersObj["date_to"].(string); ok {
 			params.Filters.DateTo = dateTo
 		}
+
+		var err error
+		if params.Filters.Tags, err = extractTags(filtersObj, "tags"); err != nil {
+			return nil, err
+		}
+		if params.Filters.AnyTags, err = extractTags(filtersObj, "any_tags"); err != nil {
+			return nil, err
+		}
 	}
 
 	// Limit with default
@@ -193,6 +307,13 @@ func ExtractTodoSearchParams(request mcp.CallToolRequest) (*TodoSearchParams, er
 		}
 	}
 
+	if includeArchived, ok := args["include_archived"].(bool); ok {
+		params.IncludeArchived = includeArchived
+	}
+	if archivedOnly, ok := args["archived_only"].(bool); ok {
+		params.ArchivedOnly = archivedOnly
+	}
+
 	return params, nil
 }
 
@@ -214,6 +335,235 @@ func ExtractTodoArchiveParams(request mcp.CallToolRequest) (*TodoArchiveParams,
 	return params, nil
 }
 
+// ExtractTodoRestoreParams extracts and validates todo_restore parameters
+func ExtractTodoRestoreParams(request mcp.CallToolRequest) (*TodoRestoreParams, error) {
+	params := &TodoRestoreParams{}
+
+	args := request.GetArguments()
+
+	// Required ID
+	id, ok := args["id"].(string)
+	if !ok || id == "" {
+		return nil, fmt.Errorf("missing required parameter 'id'")
+	}
+	params.ID = id
+
+	if keep, ok := args["keep_completed"].(bool); ok {
+		params.KeepCompleted = keep
+	}
+	if cascade, ok := args["cascade"].(bool); ok {
+		params.Cascade = cascade
+	}
+
+	return params, nil
+}
+
+// ExtractTodoSimilarParams extracts and validates todo_similar parameters
+func ExtractTodoSimilarParams(request mcp.CallToolRequest) (*TodoSimilarParams, error) {
+	params := &TodoSimilarParams{}
+
+	args := request.GetArguments()
+
+	// Either a todo to compare against or free text
+	if id, ok := args["id"].(string); ok {
+		params.ID = strings.TrimSpace(id)
+	}
+	if text, ok := args["text"].(string); ok {
+		params.Text = strings.TrimSpace(text)
+	}
+	if params.ID == "" && params.Text == "" {
+		return nil, fmt.Errorf("either 'id' or 'text' is required")
+	}
+	if params.ID != "" && params.Text != "" {
+		return nil, fmt.Errorf("'id' and 'text' are mutually exclusive")
+	}
+
+	// Limit with default
+	params.Limit = 5
+	if limit, ok := args["limit"].(float64); ok && limit > 0 {
+		params.Limit = int(limit)
+		if params.Limit > 20 {
+			params.Limit = 20
+		}
+	}
+
+	return params, nil
+}
+
+// ExtractTodoMergeParams extracts and validates merge_duplicates parameters
+func ExtractTodoMergeParams(request mcp.CallToolRequest) (*TodoMergeParams, error) {
+	params := &TodoMergeParams{}
+
+	args := request.GetArguments()
+
+	// Required survivor
+	survivor, ok := args["survivor"].(string)
+	if !ok || strings.TrimSpace(survivor) == "" {
+		return nil, fmt.Errorf("missing required parameter 'survivor'")
+	}
+	params.Survivor = strings.TrimSpace(survivor)
+
+	duplicates, err := extractIDs(args, "duplicates")
+	if err != nil {
+		return nil, err
+	}
+	if len(duplicates) == 0 {
+		return nil, fmt.Errorf("missing required parameter 'duplicates'")
+	}
+	params.Duplicates = duplicates
+
+	return params, nil
+}
+
+// ExtractTodoIndexParams extracts and validates todo_index parameters
+func ExtractTodoIndexParams(request mcp.CallToolRequest) (*TodoIndexParams, error) {
+	return newTodoIndexParams(request.GetString("action", ""), request.GetString("mode", ""))
+}
+
+// newTodoIndexParams applies defaults and validates a todo_index action,
+// shared with the HTTP admin endpoint
+func newTodoIndexParams(action, mode string) (*TodoIndexParams, error) {
+	params := &TodoIndexParams{Action: "health", Mode: core.ReindexIncremental}
+	if action != "" {
+		params.Action = action
+	}
+	if mode != "" {
+		params.Mode = mode
+	}
+
+	switch params.Action {
+	case "health", "reindex", "rebuild", "compact":
+	default:
+		return nil, interrors.NewValidationError("action", action, "must be one of: health, reindex, rebuild, compact")
+	}
+	if params.Mode != core.ReindexIncremental && params.Mode != core.ReindexFull {
+		return nil, interrors.NewValidationError("mode", mode, "must be incremental or full")
+	}
+	if params.Action == "rebuild" {
+		params.Mode = core.ReindexRebuild
+	}
+
+	return params, nil
+}
+
+// ExtractTodoTemplateParams extracts and validates todo_template parameters.
+// Without an action, naming a template creates a todo from it and naming
+// none lists them.
+func ExtractTodoTemplateParams(request mcp.CallToolRequest) (*TodoTemplateParams, error) {
+	params := &TodoTemplateParams{
+		Action:      request.GetString("action", ""),
+		Template:    request.GetString("template", ""),
+		Task:        request.GetString("task", ""),
+		Priority:    request.GetString("priority", "high"),
+		Type:        request.GetString("type", ""), // left to the template: feature, or multi-phase for trees
+		ID:          request.GetString("id", ""),
+		Description: request.GetString("description", ""),
+	}
+
+	var err error
+	if params.Variables, err = extractTemplateVariables(request.GetArguments()); err != nil {
+		return nil, err
+	}
+
+	if params.Action == "" {
+		params.Action = "create"
+		if params.Template == "" {
+			params.Action = "list"
+		}
+	}
+	switch params.Action {
+	case "list":
+	case "create", "preview", "validate", "save":
+		if params.Template == "" {
+			return nil, interrors.NewValidationError("template", "", fmt.Sprintf("template is required for %s", params.Action))
+		}
+	default:
+		return nil, interrors.NewValidationError("action", params.Action, "must be one of: list, create, preview, validate, save")
+	}
+	if params.Action == "save" && params.ID == "" {
+		return nil, interrors.NewValidationError("id", "", "id of the todo to save as a template is required")
+	}
+
+	return params, nil
+}
+
+// ExtractTodoSectionsParams extracts and validates todo_sections parameters
+func ExtractTodoSectionsParams(request mcp.CallToolRequest) (*TodoSectionsParams, error) {
+	params := &TodoSectionsParams{
+		ID:       request.GetString("id", ""),
+		Action:   request.GetString("action", "list"),
+		Key:      request.GetString("key", ""),
+		Title:    request.GetString("title", ""),
+		Schema:   request.GetString("schema", "freeform"),
+		Position: request.GetInt("position", 0),
+		NewKey:   request.GetString("new_key", ""),
+	}
+	if params.ID == "" {
+		return nil, interrors.NewValidationError("id", "", "missing required parameter")
+	}
+
+	var err error
+	if params.Order, err = extractIDs(request.GetArguments(), "order"); err != nil {
+		return nil, err
+	}
+	if params.Columns, err = extractIDs(request.GetArguments(), "columns"); err != nil {
+		return nil, err
+	}
+	if len(params.Columns) > 0 && params.Schema != string(core.SchemaTable) {
+		return nil, interrors.NewValidationError("columns", strings.Join(params.Columns, ","), "columns only apply to table sections")
+	}
+
+	switch params.Action {
+	case "list":
+	case "add", "remove":
+		if params.Key == "" {
+			return nil, interrors.NewValidationError("key", "", fmt.Sprintf("key is required for %s", params.Action))
+		}
+	case "rename":
+		if params.Key == "" {
+			return nil, interrors.NewValidationError("key", "", "key is required for rename")
+		}
+		if params.NewKey == "" && params.Title == "" {
+			return nil, interrors.NewValidationError("new_key", "", "rename needs new_key, title or both")
+		}
+	case "reorder":
+		if len(params.Order) == 0 {
+			return nil, interrors.NewValidationError("order", "", "order must list the section keys to move to the front")
+		}
+	default:
+		return nil, interrors.NewValidationError("action", params.Action, "must be one of: list, add, rename, reorder, remove")
+	}
+
+	return params, nil
+}
+
+// ExtractTodoGraphParams extracts and validates todo_graph parameters
+func ExtractTodoGraphParams(request mcp.CallToolRequest) (*TodoGraphParams, error) {
+	params := &TodoGraphParams{}
+
+	args := request.GetArguments()
+
+	// Optional root todo; without it every active todo is drawn
+	if id, ok := args["id"].(string); ok {
+		params.ID = id
+	}
+
+	// Format with default
+	params.Format = "mermaid"
+	if format, ok := args["format"].(string); ok && format != "" {
+		params.Format = format
+	}
+	if !core.IsValidGraphFormat(params.Format) {
+		return nil, fmt.Errorf("invalid format '%s', must be one of: mermaid, dot", params.Format)
+	}
+
+	if includeArchived, ok := args["include_archived"].(bool); ok {
+		params.IncludeArchived = includeArchived
+	}
+
+	return params, nil
+}
+
 // ExtractTodoCreateMultiParams extracts and validates todo_create_multi parameters
 func ExtractTodoCreateMultiParams(request mcp.CallToolRequest) (*TodoCreateMultiParams, error) {
 	params := &TodoCreateMultiParams{}
@@ -245,6 +595,12 @@ func ExtractTodoCreateMultiParams(request mcp.CallToolRequest) (*TodoCreateMulti
 		params.Parent.Type = todoType
 	}
 
+	parentTags, err := extractTags(parentObj, "tags")
+	if err != nil {
+		return nil, fmt.Errorf("invalid parent tags: %w", err)
+	}
+	params.Parent.Tags = parentTags
+
 	// Extract children
 	childrenInterface, ok := args["children"].([]interface{})
 	if !ok {
@@ -282,6 +638,12 @@ func ExtractTodoCreateMultiParams(request mcp.CallToolRequest) (*TodoCreateMulti
 			child.Type = todoType
 		}
 
+		childTags, err := extractTags(childObj, "tags")
+		if err != nil {
+			return nil, fmt.Errorf("invalid tags for child %d: %w", i, err)
+		}
+		child.Tags = childTags
+
 		params.Children = append(params.Children, child)
 	}
 
@@ -310,4 +672,94 @@ func ExtractTodoCreateMultiParams(request mcp.CallToolRequest) (*TodoCreateMulti
 	}
 
 	return params, nil
-}
\ No newline at end of file
+}
+
+// extractTags reads a tag list given either as an array of strings or a
+// comma-separated string, and normalizes it. It returns nil when the key is
+// absent and an empty slice when it is present but empty.
+func extractTags(args map[string]interface{}, key string) ([]string, error) {
+	var raw []string
+	switch value := args[key].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		raw = strings.Split(value, ",")
+	case []interface{}:
+		for _, item := range value {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s: expected an array of strings", key)
+			}
+			raw = append(raw, str)
+		}
+	case []string:
+		raw = value
+	default:
+		return nil, fmt.Errorf("invalid %s: expected an array of strings", key)
+	}
+
+	var nonEmpty []string
+	for _, tag := range raw {
+		if strings.TrimSpace(tag) != "" {
+			nonEmpty = append(nonEmpty, tag)
+		}
+	}
+
+	tags, err := core.NormalizeTags(nonEmpty)
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// extractIDs reads a list of todo or section IDs given either as an array of
+// strings or a comma-separated string
+func extractIDs(args map[string]interface{}, key string) ([]string, error) {
+	var raw []string
+	switch value := args[key].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		raw = strings.Split(value, ",")
+	case []interface{}:
+		for _, item := range value {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s: expected an array of IDs", key)
+			}
+			raw = append(raw, str)
+		}
+	default:
+		return nil, fmt.Errorf("invalid %s: expected an array of IDs", key)
+	}
+
+	var ids []string
+	for _, id := range raw {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// extractTemplateVariables reads the variables object for todo_template.
+// Numbers and booleans are accepted and passed to the template as text.
+func extractTemplateVariables(args map[string]interface{}) (map[string]string, error) {
+	switch value := args["variables"].(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		vars := make(map[string]string, len(value))
+		for name, v := range value {
+			switch v.(type) {
+			case string, float64, bool:
+				vars[name] = fmt.Sprint(v)
+			default:
+				return nil, fmt.Errorf("invalid variables: %s must be a string, number or boolean", name)
+			}
+		}
+		return vars, nil
+	default:
+		return nil, fmt.Errorf("invalid variables: expected an object of name/value pairs")
+	}
+}
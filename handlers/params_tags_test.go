@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractTagParams(t *testing.T) {
+	t.Run("create accepts array or comma string", func(t *testing.T) {
+		for _, tags := range []interface{}{
+			[]interface{}{"Backend", "area/auth"},
+			"backend, area/auth",
+		} {
+			req := &MockCallToolRequest{Arguments: map[string]interface{}{"task": "Add token refresh", "tags": tags}}
+			params, err := ExtractTodoCreateParams(req.ToCallToolRequest())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if want := []string{"backend", "area/auth"}; !reflect.DeepEqual(params.Tags, want) {
+				t.Errorf("Expected %v, got %v", want, params.Tags)
+			}
+		}
+	})
+
+	t.Run("update distinguishes clear from unset", func(t *testing.T) {
+		req := &MockCallToolRequest{Arguments: map[string]interface{}{
+			"id":       "add-token-refresh",
+			"metadata": map[string]interface{}{"tags": []interface{}{}, "add_tags": []interface{}{"api"}},
+		}}
+		params, err := ExtractTodoUpdateParams(req.ToCallToolRequest())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if params.Metadata.Tags == nil || len(params.Metadata.Tags) != 0 {
+			t.Errorf("Expected empty, non-nil tags to clear, got %#v", params.Metadata.Tags)
+		}
+		if params.Metadata.RemoveTags != nil {
+			t.Errorf("Expected unset remove_tags, got %#v", params.Metadata.RemoveTags)
+		}
+	})
+
+	t.Run("read and search filters", func(t *testing.T) {
+		req := &MockCallToolRequest{Arguments: map[string]interface{}{
+			"filter": map[string]interface{}{"tags": []interface{}{"backend"}, "any_tags": "frontend,api"},
+		}}
+		readParams, err := ExtractTodoReadParams(req.ToCallToolRequest())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(readParams.Filter.Tags) != 1 || len(readParams.Filter.AnyTags) != 2 {
+			t.Errorf("Unexpected read filter: %+v", readParams.Filter)
+		}
+
+		req.Arguments = map[string]interface{}{
+			"query":   "auth",
+			"filters": map[string]interface{}{"any_tags": []interface{}{"area/auth"}},
+		}
+		searchParams, err := ExtractTodoSearchParams(req.ToCallToolRequest())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(searchParams.Filters.AnyTags, []string{"area/auth"}) {
+			t.Errorf("Unexpected search filter: %+v", searchParams.Filters)
+		}
+	})
+
+	t.Run("invalid tags are rejected", func(t *testing.T) {
+		req := &MockCallToolRequest{Arguments: map[string]interface{}{"task": "Tidy up", "tags": []interface{}{"two words"}}}
+		if _, err := ExtractTodoCreateParams(req.ToCallToolRequest()); err == nil {
+			t.Error("Expected error for invalid tag")
+		}
+	})
+}
@@ -469,6 +469,8 @@ func TestIsValidFormat(t *testing.T) {
 		{"full", true},
 		{"summary", true},
 		{"list", true},
+		{"mermaid", true},
+		{"dot", true},
 		{"json", false},
 		{"", false},
 	}
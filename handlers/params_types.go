@@ -2,11 +2,17 @@ package handlers
 
 // TodoCreateParams represents parameters for todo_create
 type TodoCreateParams struct {
-	Task     string
-	Priority string
-	Type     string
-	Template string
-	ParentID string
+	Task       string
+	Priority   string
+	Type       string
+	Template   string
+	ParentID   string
+	Due        string
+	Scheduled  string
+	Recurrence string
+	Tags       []string
+
+	CheckSimilar bool // hold off when similar work exists
 }
 
 // TodoCreateMultiParams represents parameters for todo_create_multi
@@ -17,9 +23,10 @@ type TodoCreateMultiParams struct {
 
 // TodoCreateInfo represents information for creating a todo in bulk operations
 type TodoCreateInfo struct {
-	Task     string `json:"task"`
-	Priority string `json:"priority,omitempty"`
-	Type     string `json:"type,omitempty"`
+	Task     string   `json:"task"`
+	Priority string   `json:"priority,omitempty"`
+	Type     string   `json:"type,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
 }
 
 // TodoReadParams represents parameters for todo_read
@@ -27,13 +34,18 @@ type TodoReadParams struct {
 	ID     string
 	Filter TodoFilter
 	Format string
+	Sort   string // "started" (default, newest first) or "due"
 }
 
 // TodoFilter represents filter options for todo_read
 type TodoFilter struct {
-	Status   string
-	Priority string
-	Days     int
+	Status        string
+	Priority      string
+	Days          int
+	Overdue       bool
+	DueWithinDays int
+	Tags          []string // todo must carry all of these
+	AnyTags       []string // todo must carry at least one of these
 }
 
 // TodoUpdateParams represents parameters for todo_update
@@ -50,6 +62,12 @@ type TodoMetadata struct {
 	Status      string
 	Priority    string
 	CurrentTest string
+	Due         string // YYYY-MM-DD, RFC3339, or "none" to clear
+	Scheduled   string
+	Recurrence  string // RRULE subset, or "none" to clear
+	Tags        []string // replaces all tags when non-nil; empty clears
+	AddTags     []string
+	RemoveTags  []string
 }
 
 // TodoSearchParams represents parameters for todo_search
@@ -58,16 +76,82 @@ type TodoSearchParams struct {
 	Scope   []string
 	Filters SearchFilters
 	Limit   int
+
+	IncludeArchived bool
+	ArchivedOnly    bool
 }
 
 // SearchFilters represents search filter options
 type SearchFilters struct {
 	Status   string
+	Priority string
+	Type     string
+	Month    string // YYYY-MM, on the started date
 	DateFrom string
 	DateTo   string
+	Tags     []string
+	AnyTags  []string
 }
 
 // TodoArchiveParams represents parameters for todo_archive
 type TodoArchiveParams struct {
 	ID string
-}
\ No newline at end of file
+}
+
+// TodoRestoreParams represents parameters for todo_restore
+type TodoRestoreParams struct {
+	ID            string
+	KeepCompleted bool
+	Cascade       bool
+}
+
+// TodoSimilarParams represents parameters for todo_similar
+type TodoSimilarParams struct {
+	ID    string
+	Text  string
+	Limit int
+}
+
+// TodoMergeParams represents parameters for todo_clean merge_duplicates
+type TodoMergeParams struct {
+	Survivor   string
+	Duplicates []string
+}
+
+// TodoIndexParams represents parameters for todo_index
+type TodoIndexParams struct {
+	Action string // health, reindex, rebuild or compact
+	Mode   string // reindex mode: incremental or full
+}
+
+// TodoTemplateParams represents parameters for todo_template
+type TodoTemplateParams struct {
+	Action      string // list, create, preview, validate or save
+	Template    string
+	Task        string
+	Priority    string
+	Type        string
+	Variables   map[string]string
+	ID          string // todo to save as a template
+	Description string
+}
+
+// TodoSectionsParams represents parameters for todo_sections
+type TodoSectionsParams struct {
+	ID       string
+	Action   string // list, add, rename, reorder or remove
+	Key      string
+	Title    string
+	Schema   string
+	Position int      // 1-based position for add; 0 adds last
+	NewKey   string   // rename target
+	Order    []string // reorder: keys to put first
+	Columns  []string // add: declared columns of a table section
+}
+
+// TodoGraphParams represents parameters for todo_graph
+type TodoGraphParams struct {
+	ID              string
+	Format          string
+	IncludeArchived bool
+}
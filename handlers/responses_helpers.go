@@ -122,6 +122,8 @@ func formatTodosFullWithContent(todos []*core.Todo, contents map[string]string)
 		if todo.ParentID != "" {
 			data["parent_id"] = todo.ParentID
 		}
+		addDateFields(data, todo)
+		addLinkFields(data, todo)
 
 		// Add sections with content
 		if content, exists := contents[todo.ID]; exists {
@@ -132,6 +134,7 @@ func formatTodosFullWithContent(todos []*core.Todo, contents map[string]string)
 				if key == "checklist" {
 					// Parse checklist items
 					sectionData[key] = core.ParseChecklist(sectionContent)
+					addChecklistProgress(data, sectionContent)
 				} else {
 					// Regular section content - just the string
 					sectionData[key] = strings.TrimSpace(sectionContent)
@@ -145,4 +148,59 @@ func formatTodosFullWithContent(todos []*core.Todo, contents map[string]string)
 
 	jsonData, _ := json.MarshalIndent(results, "", "  ")
 	return mcp.NewToolResultText(string(jsonData))
-}
\ No newline at end of file
+}
+
+// addChecklistProgress adds the checklist as a tree, with each item's
+// progress rolled up from its sub-items, to structured todo output
+func addChecklistProgress(data map[string]interface{}, checklist string) {
+	items := core.ParseChecklistTree(checklist)
+	if len(items) == 0 {
+		return
+	}
+	data["checklist_progress"] = map[string]interface{}{
+		"percent": core.ChecklistProgress(items),
+		"items":   items,
+	}
+}
+
+// addDateFields adds due/scheduled dates to structured todo output
+func addDateFields(data map[string]interface{}, todo *core.Todo) {
+	if todo.HasDue() {
+		data["due"] = todo.Due.Format(time.RFC3339)
+		data["overdue"] = todo.IsOverdue(time.Now())
+	}
+	if !todo.Scheduled.IsZero() {
+		data["scheduled"] = todo.Scheduled.Format(time.RFC3339)
+	}
+}
+
+// addLinkFields adds blocks/relates-to links to structured todo output
+func addLinkFields(data map[string]interface{}, todo *core.Todo) {
+	if len(todo.BlockedBy) > 0 {
+		data["blocked_by"] = todo.BlockedBy
+	}
+	if len(todo.Blocks) > 0 {
+		data["blocks"] = todo.Blocks
+	}
+	if len(todo.Related) > 0 {
+		data["related"] = todo.Related
+	}
+}
+
+// formatTodoLinkLines lists a todo's blockers, dependents and related todos
+func formatTodoLinkLines(todo *core.Todo) string {
+	var lines []string
+	if len(todo.BlockedBy) > 0 {
+		lines = append(lines, "Blocked by: "+strings.Join(todo.BlockedBy, ", "))
+	}
+	if len(todo.Blocks) > 0 {
+		lines = append(lines, "Blocks: "+strings.Join(todo.Blocks, ", "))
+	}
+	if len(todo.Related) > 0 {
+		lines = append(lines, "Related: "+strings.Join(todo.Related, ", "))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "\n" + strings.Join(lines, "\n")
+}
@@ -43,6 +43,8 @@ func formatSingleTodoWithContent(todo *core.Todo, content string, format string)
 		if todo.ParentID != "" {
 			data["parent_id"] = todo.ParentID
 		}
+		addDateFields(data, todo)
+		addLinkFields(data, todo)
 
 		// Add sections with content
 		sections := extractSectionContents(content)
@@ -52,6 +54,7 @@ func formatSingleTodoWithContent(todo *core.Todo, content string, format string)
 			if key == "checklist" {
 				// Parse checklist items
 				sectionData[key] = core.ParseChecklist(sectionContent)
+				addChecklistProgress(data, sectionContent)
 			} else {
 				// Regular section content - just the string
 				sectionData[key] = strings.TrimSpace(sectionContent)
@@ -87,13 +90,15 @@ func formatSingleTodo(todo *core.Todo, format string) *mcp.CallToolResult {
 		if todo.ParentID != "" {
 			data["parent_id"] = todo.ParentID
 		}
+		addDateFields(data, todo)
+		addLinkFields(data, todo)
 
 		jsonData, _ := json.MarshalIndent(data, "", "  ")
 		return mcp.NewToolResultText(string(jsonData))
 	}
 
 	// Summary format
-	summary := formatTodoSummaryLine(todo)
+	summary := formatTodoSummaryLine(todo) + formatTodoLinkLines(todo)
 	
 	// Add single todo prompt
 	prompt := getSingleTodoPrompt(todo)
@@ -117,6 +122,8 @@ func formatTodosFull(todos []*core.Todo) *mcp.CallToolResult {
 		if !todo.Completed.IsZero() {
 			data["completed"] = todo.Completed.Format(time.RFC3339)
 		}
+		addDateFields(data, todo)
+		addLinkFields(data, todo)
 		results = append(results, data)
 	}
 
@@ -271,6 +278,24 @@ func formatTodoSummaryLineWithOptions(todo *core.Todo, showParent bool, showType
 	if showParent && todo.ParentID != "" {
 		line += fmt.Sprintf(" (parent: %s)", todo.ParentID)
 	}
+
+	// Show deadlines, flagging anything past due
+	if todo.HasDue() {
+		if todo.IsOverdue(time.Now()) {
+			line += fmt.Sprintf(" (OVERDUE: due %s)", core.FormatDueDate(todo.Due))
+		} else {
+			line += fmt.Sprintf(" (due %s)", core.FormatDueDate(todo.Due))
+		}
+	}
+
+	// Flag dependencies so blocked work stands out in listings
+	if len(todo.BlockedBy) > 0 {
+		line += fmt.Sprintf(" (blocked by: %s)", strings.Join(todo.BlockedBy, ", "))
+	}
+
+	if len(todo.Tags) > 0 {
+		line += " #" + strings.Join(todo.Tags, " #")
+	}
 	
 	return line
 }
@@ -399,6 +424,61 @@ func getSingleTodoPrompt(todo *core.Todo) string {
 	}
 }
 
+// FormatTodoAgendaResponse formats open todos grouped by due date
+func FormatTodoAgendaResponse(agenda *core.Agenda, now time.Time) *mcp.CallToolResult {
+	groups := []struct {
+		title string
+		todos []*core.Todo
+	}{
+		{"OVERDUE", agenda.Overdue},
+		{"TODAY", agenda.Today},
+		{"THIS WEEK", agenda.ThisWeek},
+		{"LATER", agenda.Later},
+	}
+
+	var lines []string
+	for _, group := range groups {
+		if len(group.todos) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, fmt.Sprintf("%s (%d):", group.title, len(group.todos)))
+		for _, todo := range group.todos {
+			lines = append(lines, "  "+formatAgendaLine(todo, now))
+		}
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, "Nothing due or scheduled")
+	}
+	if agenda.Undated > 0 {
+		lines = append(lines, "", fmt.Sprintf("%d open todo(s) have no due or scheduled date.", agenda.Undated))
+	}
+
+	return mcp.NewToolResultText(strings.Join(lines, "\n"))
+}
+
+// formatAgendaLine formats a todo with its due or scheduled date
+func formatAgendaLine(todo *core.Todo, now time.Time) string {
+	line := fmt.Sprintf("%s %s: %s", getStatusIcon(todo.Status), todo.ID, todo.Task)
+	if priority := getPriorityLabel(todo.Priority); priority != "" {
+		line += " " + priority
+	}
+
+	if todo.HasDue() {
+		line += fmt.Sprintf(" (due %s)", core.FormatDueDate(todo.Due))
+	}
+	if !todo.Scheduled.IsZero() {
+		line += fmt.Sprintf(" (scheduled %s)", core.FormatDueDate(todo.Scheduled))
+	}
+	if len(todo.BlockedBy) > 0 {
+		line += fmt.Sprintf(" (blocked by: %s)", strings.Join(todo.BlockedBy, ", "))
+	}
+	return line
+}
+
 // FormatSearchResult represents a search result
 type FormatSearchResult struct {
 	ID      string  `json:"id"`
@@ -414,6 +494,35 @@ func FormatTodoSearchResponse(results []core.SearchResult) *mcp.CallToolResult {
 		return mcp.NewToolResultText("No matching todos found" + prompt)
 	}
 
+	header := fmt.Sprintf("Found %d matching todos:\n", len(results))
+	prompt := getSearchPrompts(len(results))
+	return mcp.NewToolResultText(header + formatSearchResultLines(results) + prompt)
+}
+
+// FormatTodoSearchResponseWithFacets formats search results followed by a
+// breakdown of every match by status, type, priority, tag and month
+func FormatTodoSearchResponseWithFacets(response *core.SearchResponse) *mcp.CallToolResult {
+	if len(response.Results) == 0 {
+		prompt := getSearchPrompts(0)
+		return mcp.NewToolResultText("No matching todos found" + prompt)
+	}
+
+	header := fmt.Sprintf("Found %d matching todos:\n", len(response.Results))
+	if response.Total > uint64(len(response.Results)) {
+		header = fmt.Sprintf("Found %d matching todos (showing top %d):\n", response.Total, len(response.Results))
+	}
+
+	breakdown := ""
+	if len(response.Facets) > 0 {
+		breakdown = "\n\n" + formatSearchFacets(response.Facets, response.Total)
+	}
+
+	prompt := getSearchPrompts(len(response.Results))
+	return mcp.NewToolResultText(header + formatSearchResultLines(response.Results) + breakdown + prompt)
+}
+
+// formatSearchResultLines renders one entry per result with its matches
+func formatSearchResultLines(results []core.SearchResult) string {
 	var lines []string
 	for _, result := range results {
 		score := fmt.Sprintf("%.0f%%", result.Score*100)
@@ -422,13 +531,86 @@ func FormatTodoSearchResponse(results []core.SearchResult) *mcp.CallToolResult {
 			snippet = "No content preview available"
 		}
 		
+		label := result.ID
+		if result.Archived {
+			label += " [archived: " + result.ArchivePath + "]"
+		}
+
+		// Show where each match came from when highlights are available
+		preview := snippet
+		if len(result.Highlights) > 0 {
+			var matches []string
+			for _, h := range result.Highlights {
+				matches = append(matches, fmt.Sprintf("[%s] %s", h.Section, strings.Join(strings.Fields(h.Fragment), " ")))
+			}
+			preview = strings.Join(matches, "\n  ")
+		}
+
 		lines = append(lines, fmt.Sprintf("• %s (relevance: %s)\n  %s", 
-			result.ID, score, snippet))
+			label, score, preview))
 	}
+	return strings.Join(lines, "\n\n")
+}
 
-	header := fmt.Sprintf("Found %d matching todos:\n", len(results))
-	prompt := getSearchPrompts(len(results))
-	return mcp.NewToolResultText(header + strings.Join(lines, "\n\n") + prompt)
+// facetQualifiers maps facet names to the todo_search qualifier that filters on them
+var facetQualifiers = map[string]string{
+	"status":   "status",
+	"type":     "type",
+	"priority": "priority",
+	"tags":     "tag",
+	"month":    "started",
+}
+
+// formatSearchFacets lists facet counts as ready-to-use query qualifiers
+func formatSearchFacets(facets []core.SearchFacet, total uint64) string {
+	lines := []string{fmt.Sprintf("Breakdown of all %d matches:", total)}
+	for _, facet := range facets {
+		qualifier := facetQualifiers[facet.Name]
+		var values []string
+		for _, v := range facet.Values {
+			values = append(values, fmt.Sprintf("%s:%s (%d)", qualifier, v.Value, v.Count))
+		}
+		lines = append(lines, fmt.Sprintf("- %s: %s", facet.Name, strings.Join(values, ", ")))
+	}
+	lines = append(lines, "Add any of these to the query to narrow the results.")
+	return strings.Join(lines, "\n")
+}
+
+// FormatTodoSimilarResponse lists the todos most like source, closest first
+func FormatTodoSimilarResponse(source string, results []core.SearchResult) *mcp.CallToolResult {
+	if len(results) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No todos similar to %s found.", source))
+	}
+
+	lines := []string{fmt.Sprintf("Todos similar to %s, closest first:", source)}
+	for _, result := range results {
+		line := fmt.Sprintf("• %s - %s (similarity: %.0f%%)", result.ID, result.Task, result.Score*100)
+		if result.Archived {
+			line += " [archived: " + result.ArchivePath + "]"
+		}
+		lines = append(lines, line)
+	}
+
+	return mcp.NewToolResultText(strings.Join(lines, "\n") +
+		"\n\nRead the closest match with todo_read to reuse its findings and tests, or restore an archived one with todo_restore.")
+}
+
+// FormatSimilarWorkWarning explains why todo_create held off: task looks like
+// work that is already done or under way
+func FormatSimilarWorkWarning(task string, matches []core.SearchResult) *mcp.CallToolResult {
+	lines := []string{fmt.Sprintf("Not created: '%s' looks like work you already have.\n", task)}
+	for _, match := range matches {
+		if match.Archived {
+			lines = append(lines, fmt.Sprintf("- You solved something similar in %s: %s (%.0f%% similar, archived at %s)",
+				match.ID, match.Task, match.Score*100, match.ArchivePath))
+		} else {
+			lines = append(lines, fmt.Sprintf("- A similar todo is already open: %s: %s (%.0f%% similar)",
+				match.ID, match.Task, match.Score*100))
+		}
+	}
+
+	return mcp.NewToolResultText(strings.Join(lines, "\n") +
+		"\n\nReview it with todo_read first. To create the todo anyway, call todo_create again without check_similar.")
 }
 
 // getSearchPrompts returns contextual prompts based on search results
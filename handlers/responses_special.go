@@ -3,6 +3,9 @@ package handlers
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/user/mcp-todo-server/core"
 )
@@ -24,6 +27,34 @@ func FormatTodoArchiveResponse(todoID string, archivePath string, todoType strin
 	return mcp.NewToolResultText(result)
 }
 
+// FormatTodoRestoreResponse formats the response for todo_restore. The first
+// restored todo is the one that was asked for, the rest are its children.
+func FormatTodoRestoreResponse(restored []*core.Todo) *mcp.CallToolResult {
+	var todos []map[string]interface{}
+	for _, todo := range restored {
+		todos = append(todos, map[string]interface{}{
+			"id":     todo.ID,
+			"task":   todo.Task,
+			"status": todo.Status,
+			"path":   filepath.Join(".claude", "todos", core.GetDailyPath(todo.Started), todo.ID+".md"),
+		})
+	}
+
+	message := fmt.Sprintf("Todo '%s' restored from the archive", restored[0].ID)
+	if len(restored) > 1 {
+		message += fmt.Sprintf(" with %d archived children", len(restored)-1)
+	}
+
+	response := map[string]interface{}{
+		"id":       restored[0].ID,
+		"restored": todos,
+		"message":  message,
+	}
+
+	jsonData, _ := json.MarshalIndent(response, "", "  ")
+	return mcp.NewToolResultText(string(jsonData) + "\n\nThe todo is in_progress again. Record why it was reopened in its findings before continuing.")
+}
+
 // getArchivePrompts returns contextual prompts based on todo type after archiving
 func getArchivePrompts(todoType string) string {
 	basePrompt := "Todo archived successfully. "
@@ -96,18 +127,171 @@ func FormatCleanResponse(operation string, result interface{}) *mcp.CallToolResu
 	return mcp.NewToolResultText(string(jsonData))
 }
 
+// FormatDuplicatePairsResponse lists near-duplicate pairs with their scores
+func FormatDuplicatePairsResponse(pairs []core.DuplicatePair, threshold float64) *mcp.CallToolResult {
+	if len(pairs) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No duplicate todos found (threshold %.2f)", threshold))
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Found %d near-duplicate pairs (threshold %.2f):\n\n", len(pairs), threshold))
+	for _, pair := range pairs {
+		b.WriteString(fmt.Sprintf("- %s <-> %s (score %.2f)\n", pair.First, pair.Second, pair.Score))
+	}
+	b.WriteString("\nMerge a pair with operation=merge_duplicates, survivor=<id> and duplicates=<other id>.")
+	return mcp.NewToolResultText(b.String())
+}
+
+// FormatMergeResponse formats the response for merge_duplicates
+func FormatMergeResponse(result *core.MergeResult) *mcp.CallToolResult {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Merged %d todos into %s\n", len(result.Merged), result.Survivor))
+	for _, id := range result.Merged {
+		b.WriteString(fmt.Sprintf("- %s archived (merged_into: %s)\n", id, result.Survivor))
+	}
+	if len(result.Reparented) > 0 {
+		b.WriteString(fmt.Sprintf("\nRe-parented under %s: %s\n", result.Survivor, strings.Join(result.Reparented, ", ")))
+	}
+	return mcp.NewToolResultText(strings.TrimSuffix(b.String(), "\n"))
+}
+
+// FormatIndexHealthResponse reports how the search index compares with the
+// todo files on disk
+func FormatIndexHealthResponse(health core.IndexHealth) *mcp.CallToolResult {
+	var b strings.Builder
+	if health.Healthy {
+		b.WriteString("Search index: healthy\n")
+	} else {
+		b.WriteString(fmt.Sprintf("Search index: unhealthy (%s)\n", health.Error))
+	}
+	b.WriteString(fmt.Sprintf("- Documents: %d\n", health.DocCount))
+	b.WriteString(fmt.Sprintf("- Files on disk: %d (%d archived), %d up to date\n", health.Files, health.ArchivedFiles, health.Indexed))
+	if len(health.Missing) > 0 {
+		b.WriteString(fmt.Sprintf("- Not indexed: %d (%s)\n", len(health.Missing), summarizeIDs(health.Missing)))
+	}
+	if health.Stale() > 0 {
+		b.WriteString(fmt.Sprintf("- Stale: %d (%d changed on disk, %d files gone)\n", health.Stale(), len(health.Outdated), len(health.Orphaned)))
+	}
+	b.WriteString(fmt.Sprintf("- Circuit breaker: %s (%d failures)\n", health.CircuitBreaker, health.CircuitFailures))
+	if job := health.LastReindex; job != nil {
+		b.WriteString("- Last reindex: " + describeReindex(job) + "\n")
+	}
+	if len(health.Missing) > 0 || health.Stale() > 0 {
+		b.WriteString("\nRun todo_index with action=reindex to catch up.")
+	}
+	return mcp.NewToolResultText(strings.TrimSuffix(b.String(), "\n"))
+}
+
+// FormatReindexResponse reports a reindex started in the background, or its
+// outcome once finished
+func FormatReindexResponse(job *core.ReindexStatus) *mcp.CallToolResult {
+	if job.Running {
+		return mcp.NewToolResultText(fmt.Sprintf("Reindex (%s) started in the background. Check progress with todo_index action=health.", job.Mode))
+	}
+	if job.Error != "" {
+		return HandleError(fmt.Errorf("%s reindex failed after %d/%d files: %s", job.Mode, job.Done, job.Total, job.Error))
+	}
+	return mcp.NewToolResultText("Reindex finished: " + describeReindex(job))
+}
+
+// describeReindex summarizes a reindex job in one line
+func describeReindex(job *core.ReindexStatus) string {
+	summary := fmt.Sprintf("%s, %d/%d files", job.Mode, job.Done, job.Total)
+	if job.Deleted > 0 {
+		summary += fmt.Sprintf(", %d orphaned entries removed", job.Deleted)
+	}
+	switch {
+	case job.Running:
+		summary += ", running since " + job.StartedAt.Format("15:04:05")
+	case job.Error != "":
+		summary += ", failed: " + job.Error
+	default:
+		summary += fmt.Sprintf(", took %v", job.FinishedAt.Sub(job.StartedAt).Round(time.Millisecond))
+	}
+	return summary
+}
+
+// summarizeIDs lists the first few IDs
+func summarizeIDs(ids []string) string {
+	const shown = 5
+	if len(ids) <= shown {
+		return strings.Join(ids, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(ids[:shown], ", "), len(ids)-shown)
+}
+
 // FormatTemplateListResponse formats the list of available templates
-func FormatTemplateListResponse(templates []string) *mcp.CallToolResult {
+func FormatTemplateListResponse(templates []core.TemplateInfo) *mcp.CallToolResult {
 	if len(templates) == 0 {
-		return mcp.NewToolResultText("No templates available")
+		return mcp.NewToolResultText("Available templates: none yet. Save one from a todo with action=save.")
 	}
 
-	response := map[string]interface{}{
-		"templates": templates,
-		"count":     len(templates),
-		"message":   "Available templates",
+	var b strings.Builder
+	b.WriteString("Available templates:\n")
+	for _, t := range templates {
+		b.WriteString("- " + t.Name)
+		switch {
+		case t.Error != "":
+			b.WriteString(" (invalid: " + t.Error + ")")
+		case t.Description != "":
+			b.WriteString(": " + t.Description)
+		}
+		if len(t.Variables) > 0 {
+			b.WriteString(" [variables: " + strings.Join(t.Variables, ", ") + "]")
+		}
+		b.WriteString("\n")
 	}
+	return mcp.NewToolResultText(b.String())
+}
 
-	jsonData, _ := json.MarshalIndent(response, "", "  ")
-	return mcp.NewToolResultText(string(jsonData))
-}
\ No newline at end of file
+// FormatTemplatePreviewResponse shows a rendered template, the sections a
+// todo created from it would get, and the children it would create
+func FormatTemplatePreviewResponse(name string, preview *core.RenderedTodo) *mcp.CallToolResult {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Preview of template '%s' (nothing was created)\n\n", name)
+	if ordered := core.GetOrderedSections(preview.Sections); len(ordered) > 0 {
+		b.WriteString("Sections:\n")
+		for _, section := range ordered {
+			fmt.Fprintf(&b, "- %s: %s (%s)\n", section.Key, section.Definition.Title, section.Definition.Schema)
+		}
+		b.WriteString("\n")
+	}
+	if len(preview.Children) > 0 {
+		fmt.Fprintf(&b, "Creates %d children:\n", len(preview.Children))
+		for i, child := range preview.Children {
+			fmt.Fprintf(&b, "%d. %s [%s] [%s]\n", i+1, child.Task, strings.ToUpper(child.Priority), child.Type)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "# Task: %s\n\n%s", preview.Task, preview.Content)
+	return mcp.NewToolResultText(b.String())
+}
+
+// FormatTemplateValidationResponse lists the problems found in a template
+func FormatTemplateValidationResponse(name string, problems []string) *mcp.CallToolResult {
+	if len(problems) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("Template '%s' is valid.", name))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Template '%s' has %d problem(s):\n", name, len(problems))
+	for _, problem := range problems {
+		b.WriteString("- " + problem + "\n")
+	}
+	return mcp.NewToolResultText(b.String())
+}
+
+// FormatTodoGraphResponse renders a todo graph inside a fenced code block
+// so it can be pasted straight into markdown
+func FormatTodoGraphResponse(graph *core.TodoGraph, format string) *mcp.CallToolResult {
+	if len(graph.Todos) == 0 {
+		return mcp.NewToolResultText("No todos found")
+	}
+
+	diagram, err := core.NewGraphFormatter().Format(graph, format)
+	if err != nil {
+		return HandleError(err)
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("```%s\n%s```", format, diagram))
+}
@@ -214,6 +214,49 @@ func TestFormatSearchResult(t *testing.T) {
 	}
 }
 
+func TestFormatTodoSearchResponseHighlights(t *testing.T) {
+	result := FormatTodoSearchResponse([]core.SearchResult{{
+		ID:      "cache-race",
+		Score:   0.8,
+		Snippet: "Fix **race**",
+		Highlights: []core.SearchHighlight{
+			{Field: "task", Section: "Task", Fragment: "Fix **race**"},
+			{Field: "content", Section: "Notes", Fragment: "… the **race**\n  only under load …"},
+		},
+	}})
+
+	text := result.Content[0].(mcp.TextContent).Text
+	want := "• cache-race (relevance: 80%)\n  [Task] Fix **race**\n  [Notes] … the **race** only under load …"
+	if !strings.Contains(text, want) {
+		t.Errorf("Expected %q in response, got:\n%s", want, text)
+	}
+}
+
+func TestFormatTodoSearchResponseWithFacets(t *testing.T) {
+	result := FormatTodoSearchResponseWithFacets(&core.SearchResponse{
+		Results: []core.SearchResult{{ID: "login-bug", Score: 0.9, Snippet: "Fix login **timeout**"}},
+		Total:   3,
+		Facets: []core.SearchFacet{
+			{Name: "status", Values: []core.SearchFacetValue{{Value: "in_progress", Count: 2}, {Value: "blocked", Count: 1}}},
+			{Name: "tags", Values: []core.SearchFacetValue{{Value: "auth", Count: 2}}},
+			{Name: "month", Values: []core.SearchFacetValue{{Value: "2026-10", Count: 3}}},
+		},
+	})
+
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{
+		"Found 3 matching todos (showing top 1):",
+		"Breakdown of all 3 matches:",
+		"- status: status:in_progress (2), status:blocked (1)",
+		"- tags: tag:auth (2)",
+		"- month: started:2026-10 (3)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in response, got:\n%s", want, text)
+		}
+	}
+}
+
 // Test helper functions for formatting
 func TestFormattingHelpers(t *testing.T) {
 	t.Run("formatTodosList", func(t *testing.T) {
@@ -104,6 +104,10 @@ func FormatEnrichedTodoUpdateResponse(todo *core.Todo, content string, section s
 		}
 
 		progress["checklist"] = fmt.Sprintf("%d/%d completed (%d%%)", completed, total, completionPercentage)
+		if tree := core.ParseChecklistTree(sectionContents["checklist"]); len(tree) < total {
+			// Nested items count towards their parent
+			progress["checklist_rollup"] = fmt.Sprintf("%d%% done", core.ChecklistProgress(tree))
+		}
 		progress["checklist_breakdown"] = map[string]int{
 			"pending":     pending,
 			"in_progress": inProgress,
@@ -312,6 +316,13 @@ func FormatTodoSectionsResponse(todo *core.Todo) *mcp.CallToolResult {
 
 // FormatTodoSectionsResponseWithContent formats sections with content status
 func FormatTodoSectionsResponseWithContent(todo *core.Todo, content string) *mcp.CallToolResult {
+	return FormatTodoSectionsResponseWithMetrics(todo, content, nil)
+}
+
+// FormatTodoSectionsResponseWithMetrics is FormatTodoSectionsResponseWithContent
+// with metrics computed by the manager; sections missing from metrics use
+// their schema's validator
+func FormatTodoSectionsResponseWithMetrics(todo *core.Todo, content string, metrics map[string]map[string]interface{}) *mcp.CallToolResult {
 	// Handle legacy todos with no sections
 	if todo.Sections == nil || len(todo.Sections) == 0 {
 		return mcp.NewToolResultText(fmt.Sprintf("No sections found for todo '%s'", todo.ID))
@@ -319,7 +330,9 @@ func FormatTodoSectionsResponseWithContent(todo *core.Todo, content string) *mcp
 	
 	sections := make(map[string]interface{})
 
-	// Parse content to check which sections have content
+	// Parse content to check which sections have content. Headings are
+	// matched to section titles first, then by the standard names.
+	bodies := core.SectionBodies(todo.Sections, content)
 	sectionContents := extractSectionContents(content)
 
 	// Add existing sections with content status
@@ -330,9 +343,16 @@ func FormatTodoSectionsResponseWithContent(todo *core.Todo, content string) *mcp
 			"required": section.Required,
 			"order":    section.Order,
 		}
+		if section.Custom {
+			sectionData["custom"] = true
+		}
 
 		// Check if section has content
-		if sectionContent, exists := sectionContents[key]; exists {
+		sectionContent, exists := bodies[key]
+		if !exists {
+			sectionContent, exists = sectionContents[key]
+		}
+		if exists {
 			hasContent := len(strings.TrimSpace(sectionContent)) > 0
 			sectionData["hasContent"] = hasContent
 			if hasContent {
@@ -341,6 +361,11 @@ func FormatTodoSectionsResponseWithContent(todo *core.Todo, content string) *mcp
 		} else {
 			sectionData["hasContent"] = false
 		}
+		if sectionMetrics, ok := metrics[key]; ok {
+			sectionData["metrics"] = sectionMetrics
+		} else if validator := core.GetValidatorFor(section); validator != nil {
+			sectionData["metrics"] = validator.GetMetrics(sectionContent)
+		}
 
 		sections[key] = sectionData
 	}
@@ -353,4 +378,20 @@ func FormatTodoSectionsResponseWithContent(todo *core.Todo, content string) *mcp
 
 	jsonData, _ := json.MarshalIndent(response, "", "  ")
 	return mcp.NewToolResultText(string(jsonData))
-}
\ No newline at end of file
+}
+
+// FormatSectionChangeResponse reports a section change with the todo's
+// sections in their new order
+func FormatSectionChangeResponse(message string, todo *core.Todo) *mcp.CallToolResult {
+	var sb strings.Builder
+	sb.WriteString(message + "\n\nSections:\n")
+	for i, section := range core.GetOrderedSections(todo.Sections) {
+		sb.WriteString(fmt.Sprintf("%d. %s: %s [%s]", i+1, section.Key,
+			strings.TrimPrefix(section.Definition.Title, "## "), section.Definition.Schema))
+		if section.Definition.Required {
+			sb.WriteString(" (required)")
+		}
+		sb.WriteString("\n")
+	}
+	return mcp.NewToolResultText(strings.TrimRight(sb.String(), "\n"))
+}
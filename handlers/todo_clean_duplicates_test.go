@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/user/mcp-todo-server/core"
+)
+
+func TestHandleTodoCleanMergeDuplicates(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := core.NewTodoManager(tempDir)
+	search, err := core.NewSearchEngine(filepath.Join(tempDir, "index", "todos.bleve"), tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create search engine: %v", err)
+	}
+	defer search.Close()
+	h := NewTodoHandlersWithDependencies(manager, search, NewMockStatsEngine(), NewMockTemplateManager())
+
+	for _, task := range []string{"Fix login timeout bug", "Fix the login timeout", "Add dark mode toggle"} {
+		todo, _ := manager.CreateTodo(task, "high", "bug")
+		content, _ := manager.ReadTodoContent(todo.ID)
+		search.IndexTodo(todo, content)
+	}
+	manager.UpdateTodo("fix-the-login-timeout", "findings", "append", "Load balancer drops the session cookie", nil)
+
+	text := callText(t, h.HandleTodoClean, map[string]interface{}{"operation": "find_duplicates"})
+	if !strings.Contains(text, "- fix-login-timeout-bug <-> fix-the-login-timeout (score 0.75)") {
+		t.Errorf("Expected the scored near-duplicate pair, got:\n%s", text)
+	}
+	text = callText(t, h.HandleTodoClean, map[string]interface{}{"operation": "find_duplicates", "threshold": 0.9})
+	if !strings.HasPrefix(text, "No duplicate todos found (threshold 0.90)") {
+		t.Errorf("Expected no pairs at a strict threshold, got:\n%s", text)
+	}
+
+	text = callText(t, h.HandleTodoClean, map[string]interface{}{
+		"operation":  "merge_duplicates",
+		"survivor":   "fix-login-timeout-bug",
+		"duplicates": []interface{}{"fix-the-login-timeout"},
+	})
+	if !strings.Contains(text, "Merged 1 todos into fix-login-timeout-bug") {
+		t.Errorf("Expected a merge summary, got:\n%s", text)
+	}
+
+	// The survivor is searchable by the merged findings; the duplicate only as archived
+	results, err := search.SearchTodos("cookie", map[string]string{"archived": "include"}, 10)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	found := map[string]bool{}
+	for _, r := range results {
+		found[r.ID] = r.Archived
+	}
+	if archived, ok := found["fix-login-timeout-bug"]; !ok || archived {
+		t.Errorf("Expected the survivor to match the merged findings, got %+v", results)
+	}
+	if archived, ok := found["fix-the-login-timeout"]; !ok || !archived {
+		t.Errorf("Expected the duplicate to be indexed as archived, got %+v", results)
+	}
+
+	request := &MockCallToolRequest{Arguments: map[string]interface{}{"operation": "merge_duplicates", "survivor": "fix-login-timeout-bug"}}
+	if result, _ := h.HandleTodoClean(context.Background(), request.ToCallToolRequest()); result == nil || !result.IsError {
+		t.Error("Expected an error without duplicates")
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/user/mcp-todo-server/core"
+	interrors "github.com/user/mcp-todo-server/internal/errors"
 )
 
 // HandleTodoArchive handles the todo_archive tool
@@ -26,6 +28,7 @@ func (h *TodoHandlers) HandleTodoArchive(ctx context.Context, request mcp.CallTo
 
 	// Read todo BEFORE archiving to get its metadata
 	todo, readErr := manager.ReadTodo(params.ID)
+	content, _ := manager.ReadTodoContent(params.ID)
 
 	// Archive todo
 	err = manager.ArchiveTodo(params.ID)
@@ -45,12 +48,12 @@ func (h *TodoHandlers) HandleTodoArchive(ctx context.Context, request mcp.CallTo
 		archivePath = filepath.Join(".claude", "archive", params.ID+".md")
 	}
 
-	// Remove from search index if available
+	// Keep it searchable as an archived todo
 	if search != nil {
-		err = search.DeleteTodo(params.ID)
+		err = indexArchivedTodo(manager, search, params.ID)
 		if err != nil {
 			// Log but don't fail
-			fmt.Fprintf(os.Stderr, "Warning: failed to remove from search index: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Warning: failed to update search index: %v\n", err)
 		}
 	}
 
@@ -60,13 +63,23 @@ func (h *TodoHandlers) HandleTodoArchive(ctx context.Context, request mcp.CallTo
 		todoType = todo.Type
 	}
 	
-	return FormatTodoArchiveResponse(params.ID, archivePath, todoType), nil
+	result := FormatTodoArchiveResponse(params.ID, archivePath, todoType)
+
+	// Recurring todos get their next instance once archived, but only when
+	// this occurrence was actually done
+	if todo != nil && todo.Status == "completed" {
+		if note := spawnNextOccurrence(manager, search, todo, content); note != "" {
+			result.Content = append(result.Content, mcp.NewTextContent(strings.TrimSpace(note)))
+		}
+	}
+
+	return result, nil
 }
 
 // HandleTodoClean performs cleanup operations
 func (h *TodoHandlers) HandleTodoClean(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	// Get managers for the current context
-	manager, _, _, _, err := h.factory.GetManagers(ctx)
+	manager, search, _, _, err := h.factory.GetManagers(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get context-aware managers: %w", err)
 	}
@@ -85,7 +98,20 @@ func (h *TodoHandlers) HandleTodoClean(ctx context.Context, request mcp.CallTool
 		return mcp.NewToolResultText(fmt.Sprintf("Archived %d todos older than %d days", count, days)), nil
 
 	case "find_duplicates":
-		// Find duplicate todos
+		// Near-duplicate scoring reads todo bodies - need concrete TodoManager
+		if concreteManager, ok := manager.(*core.TodoManager); ok {
+			threshold := request.GetFloat("threshold", core.DefaultDuplicateThreshold)
+			if threshold <= 0 || threshold > 1 {
+				return HandleError(interrors.NewValidationError("threshold", fmt.Sprintf("%g", threshold), "must be greater than 0 and at most 1")), nil
+			}
+			pairs, err := concreteManager.FindNearDuplicates(threshold)
+			if err != nil {
+				return HandleError(err), nil
+			}
+			return FormatDuplicatePairsResponse(pairs, threshold), nil
+		}
+
+		// Otherwise fall back to exact title matches
 		duplicates, err := manager.FindDuplicateTodos()
 		if err != nil {
 			return HandleError(err), nil
@@ -104,7 +130,135 @@ func (h *TodoHandlers) HandleTodoClean(ctx context.Context, request mcp.CallTool
 		}
 		return mcp.NewToolResultText(response), nil
 
+	case "merge_duplicates":
+		params, err := ExtractTodoMergeParams(request)
+		if err != nil {
+			return HandleError(err), nil
+		}
+
+		concreteManager, ok := manager.(*core.TodoManager)
+		if !ok {
+			return HandleError(fmt.Errorf("Merge feature not available with current manager")), nil
+		}
+
+		merged, err := concreteManager.MergeTodos(params.Survivor, params.Duplicates)
+		if merged == nil {
+			return HandleError(err), nil
+		}
+		if err != nil {
+			// Some duplicates were merged before the failure
+			fmt.Fprintf(os.Stderr, "Warning: partial merge into %s: %v\n", params.Survivor, err)
+		}
+
+		if search != nil {
+			reindexMerge(manager, search, merged)
+		}
+
+		result := FormatMergeResponse(merged)
+		if err != nil {
+			result.Content = append(result.Content, mcp.NewTextContent("Warning: "+err.Error()))
+		}
+		return result, nil
+
 	default:
 		return HandleError(fmt.Errorf("unknown operation: %s", operation)), nil
 	}
-}
\ No newline at end of file
+}
+
+// HandleTodoRestore handles the todo_restore tool
+func (h *TodoHandlers) HandleTodoRestore(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	params, err := ExtractTodoRestoreParams(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get managers for the current context
+	manager, search, _, _, err := h.factory.GetManagers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get context-aware managers: %w", err)
+	}
+
+	// Restoring walks the archive - need concrete TodoManager
+	concreteManager, ok := manager.(*core.TodoManager)
+	if !ok {
+		return HandleError(fmt.Errorf("Restore feature not available with current manager")), nil
+	}
+
+	restored, err := concreteManager.RestoreTodoWithCascade(params.ID, params.KeepCompleted, params.Cascade)
+	if len(restored) == 0 {
+		return HandleError(err), nil
+	}
+	if err != nil {
+		// Some children failed; report what was restored alongside the error
+		fmt.Fprintf(os.Stderr, "Warning: partial restore of %s: %v\n", params.ID, err)
+	}
+
+	// Archived todos stay indexed but flagged as archived; re-indexing
+	// clears the flag and their archive path
+	if search != nil {
+		for _, todo := range restored {
+			content, readErr := manager.ReadTodoContent(todo.ID)
+			if readErr != nil {
+				continue
+			}
+			if indexErr := search.IndexTodo(todo, content); indexErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to index restored todo %s: %v\n", todo.ID, indexErr)
+			}
+		}
+	}
+
+	result := FormatTodoRestoreResponse(restored)
+	if err != nil {
+		result.Content = append(result.Content, mcp.NewTextContent("Warning: "+err.Error()))
+	}
+	return result, nil
+}
+
+// reindexMerge refreshes the survivor and re-parented children, and moves
+// merged duplicates to their archive paths
+func reindexMerge(manager TodoManager, search SearchEngine, merged *core.MergeResult) {
+	for _, id := range append([]string{merged.Survivor}, merged.Reparented...) {
+		todo, content, err := manager.ReadTodoWithContent(id)
+		if err != nil {
+			continue
+		}
+		if err := search.IndexTodo(todo, content); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to index %s: %v\n", id, err)
+		}
+	}
+	for _, id := range merged.Merged {
+		if err := indexArchivedTodo(manager, search, id); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to update search index for %s: %v\n", id, err)
+		}
+	}
+}
+
+// indexArchivedTodo re-indexes a freshly archived todo under its archive
+// path. Engines or managers that can't do that just drop it from the index.
+func indexArchivedTodo(manager TodoManager, search SearchEngine, id string) error {
+	archiver, canIndex := search.(interface {
+		IndexArchivedTodo(todo *core.Todo, content, archivePath string) error
+	})
+	concrete, isCore := manager.(*core.TodoManager)
+	if !canIndex || !isCore {
+		return search.DeleteTodo(id)
+	}
+
+	todo, err := concrete.ReadArchivedTodo(id)
+	if err != nil {
+		return search.DeleteTodo(id)
+	}
+	content, err := concrete.ReadArchivedTodoContent(id)
+	if err != nil {
+		return search.DeleteTodo(id)
+	}
+	path, err := core.ResolveArchivedTodoPath(manager.GetBasePath(), id)
+	if err != nil {
+		return search.DeleteTodo(id)
+	}
+	if rel, err := filepath.Rel(manager.GetBasePath(), path); err == nil {
+		path = rel
+	}
+
+	return archiver.IndexArchivedTodo(todo, content, path)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/user/mcp-todo-server/core"
 	interrors "github.com/user/mcp-todo-server/internal/errors"
+	"github.com/user/mcp-todo-server/internal/logging"
 )
 
 // TodoHandlers contains handlers for all todo operations
@@ -28,6 +29,9 @@ type TodoHandlers struct {
 func NewTodoHandlers(todoPath, templatePath string, managerTimeout time.Duration, noAutoArchive bool) (*TodoHandlers, error) {
 	// Create a simple todo manager without context wrapper
 	baseManager := core.NewTodoManager(todoPath)
+	if err := baseManager.WatchTodos(); err != nil {
+		logging.Infof("Not watching base todo path %s: %v", todoPath, err)
+	}
 
 	// Create search engine
 	indexPath := filepath.Join(todoPath, "..", "index", "todos.bleve")
@@ -41,7 +45,7 @@ func NewTodoHandlers(todoPath, templatePath string, managerTimeout time.Duration
 	stats := core.NewStatsEngine(baseManager)
 
 	// Create template manager
-	templates := core.NewTemplateManager(templatePath)
+	templates := core.NewTemplateManagerWithTodos(templatePath, baseManager)
 
 	// Create factory with base managers
 	factory := NewManagerFactory(baseManager, searchEngine, stats, templates)
@@ -86,6 +90,10 @@ func (h *TodoHandlers) Close() error {
 		close(h.cleanupStop)
 		<-h.cleanupDone
 	}
+
+	if h.baseManager != nil {
+		h.baseManager.StopWatching()
+	}
 	
 	// Factory manages cleanup of all search engines
 	// No need to close individual search engines here
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -27,6 +28,13 @@ func (h *TodoHandlers) HandleTodoCreate(ctx context.Context, request mcp.CallToo
 		return nil, interrors.Wrap(err, "failed to get context-aware managers")
 	}
 
+	// Hold off if this looks like work that is already done or under way
+	if params.CheckSimilar {
+		if matches := findSimilarWork(search, params.Task); len(matches) > 0 {
+			return FormatSimilarWorkWarning(params.Task, matches), nil
+		}
+	}
+
 	// Check if we need to use a template based on type
 	var templateContent string
 	if params.Type == "prd" || params.Template != "" {
@@ -97,6 +105,29 @@ func (h *TodoHandlers) HandleTodoCreate(ctx context.Context, request mcp.CallToo
 		}
 	}
 
+	// Apply scheduling fields and tags through a metadata update so the body is kept
+	if params.Due != "" || params.Scheduled != "" || params.Recurrence != "" || len(params.Tags) > 0 {
+		extra := map[string]string{}
+		if params.Due != "" {
+			extra["due"] = params.Due
+		}
+		if params.Scheduled != "" {
+			extra["scheduled"] = params.Scheduled
+		}
+		if params.Recurrence != "" {
+			extra["recurrence"] = params.Recurrence
+		}
+		if len(params.Tags) > 0 {
+			extra["tags"] = strings.Join(params.Tags, ",")
+		}
+		if err := manager.UpdateTodo(todo.ID, "", "", "", extra); err != nil {
+			return HandleError(err), nil
+		}
+		if updated, err := manager.ReadTodo(todo.ID); err == nil {
+			todo = updated
+		}
+	}
+
 	// Index the todo for search
 	if search != nil {
 		content, _ := manager.ReadTodoContent(todo.ID)
@@ -135,6 +166,7 @@ func (h *TodoHandlers) HandleTodoCreateMulti(ctx context.Context, request mcp.Ca
 	if err != nil {
 		return nil, interrors.Wrap(err, "failed to create parent todo")
 	}
+	applyCreateTags(manager, parentTodo, params.Parent.Tags)
 
 	// Index parent todo
 	if search != nil {
@@ -177,6 +209,8 @@ func (h *TodoHandlers) HandleTodoCreateMulti(ctx context.Context, request mcp.Ca
 			}
 		}
 
+		applyCreateTags(manager, childTodo, childParam.Tags)
+
 		// Index child todo
 		if search != nil {
 			content, _ := manager.ReadTodoContent(childTodo.ID)
@@ -190,4 +224,17 @@ func (h *TodoHandlers) HandleTodoCreateMulti(ctx context.Context, request mcp.Ca
 
 	// Create response
 	return FormatTodoCreateMultiResponse(parentTodo, childTodos), nil
-}
\ No newline at end of file
+}
+
+// applyCreateTags tags a freshly created todo. Failures are logged rather
+// than returned, matching how linking errors are handled during bulk creation.
+func applyCreateTags(manager TodoManager, todo *core.Todo, tags []string) {
+	if len(tags) == 0 {
+		return
+	}
+	if err := manager.UpdateTodo(todo.ID, "", "", "", map[string]string{"tags": strings.Join(tags, ",")}); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to tag todo %s: %v\n", todo.ID, err)
+		return
+	}
+	todo.Tags = tags
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/user/mcp-todo-server/core"
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+// indexMaintainer is implemented by search engines that can report on and
+// repair their own index
+type indexMaintainer interface {
+	IndexHealth() core.IndexHealth
+	StartReindex(mode string, progress func(done, total int)) (<-chan struct{}, error)
+	LastReindex() *core.ReindexStatus
+	CompactIndex() error
+}
+
+// HandleTodoIndex handles the todo_index tool
+func (h *TodoHandlers) HandleTodoIndex(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	params, err := ExtractTodoIndexParams(request)
+	if err != nil {
+		return HandleError(err), nil
+	}
+
+	maintainer, err := h.getIndexMaintainer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch params.Action {
+	case "reindex", "rebuild":
+		progress := progressNotifier(ctx, request)
+		done, err := maintainer.StartReindex(params.Mode, progress)
+		if err != nil {
+			return HandleError(interrors.NewConflictError("index", params.Mode, err.Error())), nil
+		}
+
+		// Without a progress token there is nothing to stream; leave it running
+		if progress != nil {
+			select {
+			case <-done:
+			case <-ctx.Done():
+			}
+		}
+		return FormatReindexResponse(maintainer.LastReindex()), nil
+
+	case "compact":
+		if err := maintainer.CompactIndex(); err != nil {
+			return HandleError(interrors.NewOperationError("compact", "search index", "failed to compact index", err)), nil
+		}
+		result := FormatIndexHealthResponse(maintainer.IndexHealth())
+		result.Content = append([]mcp.Content{mcp.NewTextContent("Index compacted.")}, result.Content...)
+		return result, nil
+
+	default:
+		return FormatIndexHealthResponse(maintainer.IndexHealth()), nil
+	}
+}
+
+// IndexAdmin runs a todo_index action for the HTTP admin endpoint and returns
+// a JSON-ready result: the index health, or the reindex job as started.
+// Reindexing always runs in the background here. dir must be a project the
+// server already has open; an empty dir selects the server's own index.
+func (h *TodoHandlers) IndexAdmin(dir, action, mode string) (interface{}, error) {
+	params, err := newTodoIndexParams(action, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	search, ok := h.factory.CachedSearch(dir)
+	if !ok {
+		return nil, interrors.NewNotFoundError("project", dir)
+	}
+	maintainer, ok := search.(indexMaintainer)
+	if search == nil || !ok {
+		return nil, fmt.Errorf("index maintenance is currently unavailable (index may be locked or corrupted)")
+	}
+
+	switch params.Action {
+	case "reindex", "rebuild":
+		if _, err := maintainer.StartReindex(params.Mode, nil); err != nil {
+			return nil, interrors.NewConflictError("index", params.Mode, err.Error())
+		}
+		return maintainer.LastReindex(), nil
+	case "compact":
+		if err := maintainer.CompactIndex(); err != nil {
+			return nil, interrors.NewOperationError("compact", "search index", "failed to compact index", err)
+		}
+	}
+	return maintainer.IndexHealth(), nil
+}
+
+// getIndexMaintainer returns the search engine for the current context if it
+// supports index maintenance
+func (h *TodoHandlers) getIndexMaintainer(ctx context.Context) (indexMaintainer, error) {
+	_, search, _, _, err := h.factory.GetManagers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get context-aware managers: %w", err)
+	}
+
+	maintainer, ok := search.(indexMaintainer)
+	if search == nil || !ok {
+		return nil, fmt.Errorf("index maintenance is currently unavailable (index may be locked or corrupted)")
+	}
+	return maintainer, nil
+}
+
+// progressNotifier returns a callback that sends MCP progress notifications
+// for the request, or nil when the client did not ask for progress
+func progressNotifier(ctx context.Context, request mcp.CallToolRequest) func(done, total int) {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return nil
+	}
+	mcpServer := server.ServerFromContext(ctx)
+	if mcpServer == nil {
+		return nil
+	}
+
+	token := request.Params.Meta.ProgressToken
+	return func(done, total int) {
+		// The token is only valid until the tool call returns
+		if ctx.Err() != nil {
+			return
+		}
+		mcpServer.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+			"progressToken": token,
+			"progress":      done,
+			"total":         total,
+			"message":       fmt.Sprintf("Indexed %d of %d todos", done, total),
+		})
+	}
+}
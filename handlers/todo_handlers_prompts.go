@@ -0,0 +1,365 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/user/mcp-todo-server/core"
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+// Limits that keep prompt text compact
+const (
+	promptFindingsLines    = 20
+	promptTestResultsLines = 10
+)
+
+// retroPeriods maps the retro prompt's period argument to a look-back window,
+// using the same names as todo_stats
+var retroPeriods = map[string]int{
+	"week":    7,
+	"month":   30,
+	"quarter": 90,
+	"year":    365,
+	"all":     0,
+}
+
+// HandleStandupPrompt builds the standup prompt: todos completed since
+// yesterday, what is in progress today and what is blocked
+func (h *TodoHandlers) HandleStandupPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	manager, _, _, _, err := h.factory.GetManagers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get context-aware managers: %w", err)
+	}
+
+	now := time.Now()
+	yesterday := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, now.Location())
+
+	todos, err := manager.ListTodos("", "", 0)
+	if err != nil {
+		return nil, interrors.Wrap(err, "failed to list todos")
+	}
+	done, err := completedTodosSince(manager, todos, yesterday)
+	if err != nil {
+		return nil, err
+	}
+
+	var inProgress, blocked []string
+	for _, todo := range todos {
+		switch {
+		case todo.Status == "blocked":
+			blocked = append(blocked, standupLine(todo, formatBlockers(activeBlockers(manager, todo))))
+		case todo.Status == "in_progress":
+			if open := activeBlockers(manager, todo); len(open) > 0 {
+				blocked = append(blocked, standupLine(todo, formatBlockers(open)))
+				continue
+			}
+			progress := ""
+			if content, err := manager.ReadTodoContent(todo.ID); err == nil {
+				progress = checklistProgress(extractSectionContents(content)["checklist"])
+			}
+			inProgress = append(inProgress, standupLine(todo, progress))
+		}
+	}
+
+	var doneLines []string
+	for _, todo := range done {
+		doneLines = append(doneLines, standupLine(todo, ""))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Standup for %s\n\n", now.Format("Monday, 2 January 2006"))
+	writePromptList(&b, "Done since yesterday", doneLines, "Nothing completed.")
+	writePromptList(&b, "In progress today", inProgress, "Nothing in progress.")
+	writePromptList(&b, "Blocked", blocked, "Nothing blocked.")
+	b.WriteString("Write a short standup update from these todos: what was finished, what comes next, and where help is needed.\n")
+
+	return promptResult("Standup from the current todos", b.String()), nil
+}
+
+// HandleHandoffPrompt builds a compact resume of one todo for a fresh
+// session: its findings, checklist state, latest test results and next steps
+func (h *TodoHandlers) HandleHandoffPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	id := strings.TrimSpace(request.Params.Arguments["id"])
+	if id == "" {
+		return nil, interrors.NewValidationError("id", id, "missing required argument")
+	}
+
+	manager, _, _, _, err := h.factory.GetManagers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get context-aware managers: %w", err)
+	}
+
+	todo, content, err := manager.ReadTodoWithContent(id)
+	if err != nil {
+		return nil, err
+	}
+	sections := extractSectionContents(content)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Handoff: %s\n\n", todo.Task)
+	fmt.Fprintf(&b, "- ID: %s\n- Status: %s\n- Priority: %s\n- Type: %s\n- Started: %s\n",
+		todo.ID, todo.Status, todo.Priority, todo.Type, todo.Started.Format("2006-01-02 15:04"))
+	if todo.ParentID != "" {
+		fmt.Fprintf(&b, "- Parent: %s\n", todo.ParentID)
+	}
+	if links := formatTodoLinkLines(todo); links != "" {
+		for _, line := range strings.Split(strings.TrimSpace(links), "\n") {
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+	}
+
+	if findings := tailLines(sections["findings"], promptFindingsLines); findings != "" {
+		fmt.Fprintf(&b, "\n## Findings\n%s\n", findings)
+	}
+
+	items := core.ParseChecklist(sections["checklist"])
+	var next []string
+	if len(items) > 0 {
+		fmt.Fprintf(&b, "\n## Checklist (%s)\n", checklistProgress(sections["checklist"]))
+		for _, item := range items {
+			fmt.Fprintf(&b, "%s %s\n", checklistMark(item.Status), item.Text)
+			if item.Status != "completed" {
+				next = append(next, item.Text)
+			}
+		}
+	}
+
+	if results := tailLines(sections["test_results"], promptTestResultsLines); results != "" {
+		fmt.Fprintf(&b, "\n## Latest test results\n%s\n", results)
+	}
+
+	b.WriteString("\n## Next steps\n")
+	if len(next) == 0 {
+		b.WriteString("No open checklist items. Review the findings and decide whether the todo is done.\n")
+	}
+	for _, step := range next {
+		fmt.Fprintf(&b, "- %s\n", step)
+	}
+
+	fmt.Fprintf(&b, "\nYou are picking up this todo in a new session. Continue with the next steps and record findings and test results with todo_update (id %q) as you go.\n", todo.ID)
+
+	return promptResult("Handoff for "+todo.ID, b.String()), nil
+}
+
+// HandleRetroPrompt builds a retrospective over the todos completed in a
+// period, with their test results
+func (h *TodoHandlers) HandleRetroPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	period := strings.TrimSpace(request.Params.Arguments["period"])
+	if period == "" {
+		period = "week"
+	}
+	days, ok := retroPeriods[period]
+	if !ok {
+		return nil, interrors.NewValidationError("period", period, "must be one of: week, month, quarter, year, all")
+	}
+
+	manager, _, stats, _, err := h.factory.GetManagers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get context-aware managers: %w", err)
+	}
+
+	var since time.Time
+	if days > 0 {
+		since = time.Now().AddDate(0, 0, -days)
+	}
+
+	todos, err := manager.ListTodos("", "", 0)
+	if err != nil {
+		return nil, interrors.Wrap(err, "failed to list todos")
+	}
+	done, err := completedTodosSince(manager, todos, since)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	if period == "all" {
+		b.WriteString("# Retro: all completed work\n\n")
+	} else {
+		fmt.Fprintf(&b, "# Retro: the last %s\n\n", period)
+	}
+
+	fmt.Fprintf(&b, "Completed: %d todos", len(done))
+	if stats != nil {
+		if periodStats, err := stats.GenerateTodoStatsForPeriod(period); err == nil {
+			fmt.Fprintf(&b, ". Still open from this period: %d in progress, %d blocked", periodStats.InProgressTodos, periodStats.BlockedTodos)
+		}
+	}
+	b.WriteString(".\n")
+
+	for _, todo := range done {
+		fmt.Fprintf(&b, "\n## %s (%s)\n", todo.Task, todo.ID)
+		fmt.Fprintf(&b, "%s, %s priority, completed %s", todo.Type, todo.Priority, todo.Completed.Format("2006-01-02"))
+		if !todo.Started.IsZero() && todo.Completed.After(todo.Started) {
+			fmt.Fprintf(&b, " after %s", formatElapsed(todo.Completed.Sub(todo.Started)))
+		}
+		b.WriteString("\n")
+
+		results := ""
+		if content, err := readAnyTodoContent(manager, todo.ID); err == nil {
+			results = tailLines(extractSectionContents(content)["test_results"], promptTestResultsLines)
+		}
+		if results == "" {
+			b.WriteString("No test results recorded.\n")
+		} else {
+			fmt.Fprintf(&b, "Test results:\n%s\n", results)
+		}
+	}
+
+	b.WriteString("\nRun a retrospective over this work: what went well, what was harder than expected, and what to do differently next time.\n")
+
+	return promptResult("Retrospective for "+period, b.String()), nil
+}
+
+// completedTodosSince returns completed todos from the active list (kept
+// there when auto-archive is off) and the archive, newest first
+func completedTodosSince(manager TodoManager, active []*core.Todo, since time.Time) ([]*core.Todo, error) {
+	var done []*core.Todo
+	seen := make(map[string]bool)
+	for _, todo := range active {
+		if todo.Status == "completed" && !todo.Completed.Before(since) {
+			done = append(done, todo)
+			seen[todo.ID] = true
+		}
+	}
+
+	if concreteManager, ok := manager.(*core.TodoManager); ok {
+		archived, err := concreteManager.ListArchivedTodos(since)
+		if err != nil {
+			return nil, err
+		}
+		for _, todo := range archived {
+			if !seen[todo.ID] {
+				done = append(done, todo)
+			}
+		}
+	}
+
+	sort.SliceStable(done, func(i, j int) bool {
+		return done[i].Completed.After(done[j].Completed)
+	})
+	return done, nil
+}
+
+// activeBlockers returns the blockers of todo that are still open, when the
+// manager can tell
+func activeBlockers(manager TodoManager, todo *core.Todo) []string {
+	if len(todo.BlockedBy) == 0 {
+		return nil
+	}
+	concreteManager, ok := manager.(*core.TodoManager)
+	if !ok {
+		return todo.BlockedBy
+	}
+	open, err := concreteManager.GetOpenBlockers(todo.ID)
+	if err != nil {
+		return nil
+	}
+	return open
+}
+
+// readAnyTodoContent reads an active todo, falling back to the archive
+func readAnyTodoContent(manager TodoManager, id string) (string, error) {
+	content, err := manager.ReadTodoContent(id)
+	if err == nil {
+		return content, nil
+	}
+	if concreteManager, ok := manager.(*core.TodoManager); ok {
+		return concreteManager.ReadArchivedTodoContent(id)
+	}
+	return "", err
+}
+
+func standupLine(todo *core.Todo, detail string) string {
+	line := todo.Task + " (" + todo.ID + ")"
+	if label := getPriorityLabel(todo.Priority); label != "" {
+		line += " " + label
+	}
+	if detail != "" {
+		line += " - " + detail
+	}
+	return line
+}
+
+func formatBlockers(ids []string) string {
+	if len(ids) == 0 {
+		return ""
+	}
+	return "blocked by " + strings.Join(ids, ", ")
+}
+
+// checklistProgress summarizes a checklist section as "2/5 done"
+func checklistProgress(section string) string {
+	items := core.ParseChecklist(section)
+	if len(items) == 0 {
+		return ""
+	}
+	completed := 0
+	for _, item := range items {
+		if item.Status == "completed" {
+			completed++
+		}
+	}
+	return fmt.Sprintf("%d/%d done", completed, len(items))
+}
+
+func checklistMark(status string) string {
+	switch status {
+	case "completed":
+		return "- [x]"
+	case "in_progress":
+		return "- [>]"
+	default:
+		return "- [ ]"
+	}
+}
+
+// tailLines returns the last n non-blank lines of a section, noting when
+// earlier lines were dropped
+func tailLines(section string, n int) string {
+	var lines []string
+	for _, line := range strings.Split(section, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > n {
+		lines = append([]string{"(earlier entries omitted)"}, lines[len(lines)-n:]...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatElapsed renders a duration in days, hours or minutes
+func formatElapsed(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
+
+func writePromptList(b *strings.Builder, title string, lines []string, empty string) {
+	fmt.Fprintf(b, "## %s\n", title)
+	if len(lines) == 0 {
+		fmt.Fprintf(b, "%s\n\n", empty)
+		return
+	}
+	for _, line := range lines {
+		fmt.Fprintf(b, "- %s\n", line)
+	}
+	b.WriteString("\n")
+}
+
+func promptResult(description, text string) *mcp.GetPromptResult {
+	return mcp.NewGetPromptResult(description, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+	})
+}
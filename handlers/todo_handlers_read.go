@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/user/mcp-todo-server/core"
@@ -23,6 +25,11 @@ func (h *TodoHandlers) HandleTodoRead(ctx context.Context, request mcp.CallToolR
 		return nil, fmt.Errorf("failed to get context-aware managers: %w", err)
 	}
 
+	// Graph formats render the todo's tree rather than the todo itself
+	if core.IsValidGraphFormat(params.Format) {
+		return h.readTodoGraph(manager, params)
+	}
+
 	// Handle single todo read
 	if params.ID != "" {
 		// Regular single todo read
@@ -35,7 +42,7 @@ func (h *TodoHandlers) HandleTodoRead(ctx context.Context, request mcp.CallToolR
 		if params.Format == "full" {
 			return formatSingleTodoWithContent(todo, content, params.Format), nil
 		}
-		return FormatTodoReadResponse([]*core.Todo{todo}, params.Format, true), nil
+		return FormatTodoReadResponse(withOpenBlockers(manager, []*core.Todo{todo}), params.Format, true), nil
 	}
 
 	// Handle list todos
@@ -51,6 +58,13 @@ func (h *TodoHandlers) HandleTodoRead(ctx context.Context, request mcp.CallToolR
 	}
 	fmt.Fprintf(os.Stderr, "HandleTodoRead: Found %d todos\n", len(todos))
 
+	// Deadline and tag filters narrow the scanned list rather than walking again
+	todos = core.FilterTodosByDue(todos, params.Filter.Overdue, params.Filter.DueWithinDays, time.Now())
+	todos = core.FilterTodosByTags(todos, params.Filter.Tags, params.Filter.AnyTags)
+	if params.Sort == "due" {
+		core.SortTodosByDue(todos)
+	}
+
 	// For full format with multiple todos, we need to get content for each
 	if params.Format == "full" && len(todos) > 0 {
 		contents := make(map[string]string)
@@ -66,7 +80,72 @@ func (h *TodoHandlers) HandleTodoRead(ctx context.Context, request mcp.CallToolR
 	}
 
 	// Create response
-	return FormatTodoReadResponse(todos, params.Format, false), nil
+	return FormatTodoReadResponse(withOpenBlockers(manager, todos), params.Format, false), nil
+}
+
+// readTodoGraph handles todo_read with a mermaid or dot format. A single ID
+// draws that todo's tree; otherwise the filtered list is drawn.
+func (h *TodoHandlers) readTodoGraph(manager TodoManager, params *TodoReadParams) (*mcp.CallToolResult, error) {
+	if params.ID != "" {
+		if concreteManager, ok := manager.(*core.TodoManager); ok {
+			graph, err := concreteManager.BuildTodoGraph(params.ID, false)
+			if err != nil {
+				return HandleError(err), nil
+			}
+			return FormatTodoGraphResponse(graph, params.Format), nil
+		}
+
+		// Without tree access, draw just the todo itself
+		todo, err := manager.ReadTodo(params.ID)
+		if err != nil {
+			return HandleError(err), nil
+		}
+		return FormatTodoGraphResponse(core.NewTodoGraph([]*core.Todo{todo}), params.Format), nil
+	}
+
+	todos, err := manager.ListTodos(
+		params.Filter.Status,
+		params.Filter.Priority,
+		params.Filter.Days,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todos: %w", err)
+	}
+
+	return FormatTodoGraphResponse(core.NewTodoGraph(todos), params.Format), nil
+}
+
+// HandleTodoAgenda handles the todo_agenda tool
+func (h *TodoHandlers) HandleTodoAgenda(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Get managers for the current context
+	manager, _, _, _, err := h.factory.GetManagers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get context-aware managers: %w", err)
+	}
+
+	todos, err := manager.ListTodos("", "", 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todos: %w", err)
+	}
+
+	now := time.Now()
+	return FormatTodoAgendaResponse(core.BuildAgenda(withOpenBlockers(manager, todos), now), now), nil
+}
+
+// withOpenBlockers returns the todos with blocked_by narrowed to the blockers
+// that are still open, so listings don't flag finished dependencies. Todos
+// that change are copied.
+func withOpenBlockers(manager TodoManager, todos []*core.Todo) []*core.Todo {
+	narrowed := make([]*core.Todo, len(todos))
+	for i, todo := range todos {
+		narrowed[i] = todo
+		if len(todo.BlockedBy) > 0 {
+			clone := *todo
+			clone.BlockedBy = activeBlockers(manager, todo)
+			narrowed[i] = &clone
+		}
+	}
+	return narrowed
 }
 
 // HandleTodoSearch handles the todo_search tool
@@ -88,18 +167,52 @@ func (h *TodoHandlers) HandleTodoSearch(ctx context.Context, request mcp.CallToo
 	if params.Filters.Status != "" {
 		filterMap["status"] = params.Filters.Status
 	}
+	if params.Filters.Priority != "" {
+		filterMap["priority"] = params.Filters.Priority
+	}
+	if params.Filters.Type != "" {
+		filterMap["type"] = params.Filters.Type
+	}
+	if params.Filters.Month != "" {
+		filterMap["month"] = params.Filters.Month
+	}
 	if params.Filters.DateFrom != "" {
 		filterMap["date_from"] = params.Filters.DateFrom
 	}
 	if params.Filters.DateTo != "" {
 		filterMap["date_to"] = params.Filters.DateTo
 	}
+	if len(params.Filters.Tags) > 0 {
+		filterMap["tags"] = strings.Join(params.Filters.Tags, ",")
+	}
+	if len(params.Filters.AnyTags) > 0 {
+		filterMap["any_tags"] = strings.Join(params.Filters.AnyTags, ",")
+	}
+	if len(params.Scope) > 0 {
+		filterMap["scope"] = strings.Join(params.Scope, ",")
+	}
+	if params.ArchivedOnly {
+		filterMap["archived"] = "only"
+	} else if params.IncludeArchived {
+		filterMap["archived"] = "include"
+	}
 
 	// Check if search is available
 	if search == nil {
 		return nil, fmt.Errorf("search functionality is currently unavailable (index may be locked or corrupted)")
 	}
 
+	// Engines that can count facets report the breakdown of all matches
+	if faceted, ok := search.(interface {
+		SearchTodosWithFacets(query string, filters map[string]string, limit int) (*core.SearchResponse, error)
+	}); ok {
+		response, err := faceted.SearchTodosWithFacets(params.Query, filterMap, params.Limit)
+		if err != nil {
+			return nil, fmt.Errorf("search failed: %w", err)
+		}
+		return FormatTodoSearchResponseWithFacets(response), nil
+	}
+
 	// Perform search
 	results, err := search.SearchTodos(params.Query, filterMap, params.Limit)
 	if err != nil {
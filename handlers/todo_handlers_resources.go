@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/user/mcp-todo-server/core"
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+// Resource URIs: active todos are todo://<project>/<id>, archived ones are
+// todo-archive://YYYY/MM/DD/<id> within the caller's project.
+const (
+	TodoResourceTemplate    = "todo://{project}/{id}"
+	ArchiveResourceTemplate = "todo-archive://{year}/{month}/{day}/{id}"
+	todoResourceMIMEType    = "text/markdown"
+)
+
+var archiveDatePattern = regexp.MustCompile(`^\d{4}/\d{2}/\d{2}$`)
+
+// TodoResourceChange is a todo change expressed as its MCP resource
+type TodoResourceChange struct {
+	Resource mcp.Resource // only the URI is set for removals
+	Removed  bool
+}
+
+// ProjectName returns the project segment used in todo:// URIs: the
+// directory name plus a short hash of its absolute path, so two projects
+// that share a name (/a/api and /b/api) don't share URIs
+func ProjectName(basePath string) string {
+	clean := filepath.Clean(basePath)
+	if abs, err := filepath.Abs(clean); err == nil {
+		clean = abs
+	}
+	// The base manager is rooted at <project>/.claude/todos
+	if filepath.Base(clean) == "todos" && filepath.Base(filepath.Dir(clean)) == ".claude" {
+		clean = filepath.Dir(filepath.Dir(clean))
+	}
+	sum := sha256.Sum256([]byte(clean))
+	return fmt.Sprintf("%s-%x", filepath.Base(clean), sum[:4])
+}
+
+// TodoResourceURI returns the resource URI of an active todo
+func TodoResourceURI(project, id string) string {
+	return fmt.Sprintf("todo://%s/%s", url.PathEscape(project), url.PathEscape(id))
+}
+
+// todoResource describes an active todo as an MCP resource
+func todoResource(project string, todo *core.Todo) mcp.Resource {
+	return mcp.NewResource(
+		TodoResourceURI(project, todo.ID),
+		todo.Task,
+		mcp.WithResourceDescription(fmt.Sprintf("%s %s todo (%s)", todo.Priority, todo.Type, todo.Status)),
+		mcp.WithMIMEType(todoResourceMIMEType),
+	)
+}
+
+// ListTodoResources returns a resource for every active todo in the projects
+// the server has seen so far
+func (h *TodoHandlers) ListTodoResources() []mcp.Resource {
+	var resources []mcp.Resource
+	for _, manager := range h.factory.Managers() {
+		todos, err := manager.ListTodos("", "", 0)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to list todos for resources: %v\n", err)
+			continue
+		}
+		project := ProjectName(manager.GetBasePath())
+		for _, todo := range todos {
+			resources = append(resources, todoResource(project, todo))
+		}
+	}
+	return resources
+}
+
+// OnResourceChange calls fn for every todo created, updated, archived or
+// edited on disk in any project
+func (h *TodoHandlers) OnResourceChange(fn func(TodoResourceChange)) {
+	h.factory.SetChangeListener(func(manager TodoManager, change core.TodoChange) {
+		project := ProjectName(manager.GetBasePath())
+		if change.Removed {
+			fn(TodoResourceChange{
+				Resource: mcp.Resource{URI: TodoResourceURI(project, change.ID)},
+				Removed:  true,
+			})
+			return
+		}
+
+		todo, err := manager.ReadTodo(change.ID)
+		if err != nil {
+			// Gone again before we got to it; a removal will follow
+			return
+		}
+		fn(TodoResourceChange{Resource: todoResource(project, todo)})
+	})
+}
+
+// HandleTodoResource reads todo://<project>/<id>
+func (h *TodoHandlers) HandleTodoResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	project, id, err := parseTodoResourceURI(request.Params.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	manager, err := h.managerForProject(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := manager.ReadTodoContent(id)
+	if err != nil {
+		return nil, interrors.NewNotFoundError("todo", id)
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: todoResourceMIMEType,
+			Text:     content,
+		},
+	}, nil
+}
+
+// HandleArchivedTodoResource reads todo-archive://YYYY/MM/DD/<id> from the
+// archive of the caller's project
+func (h *TodoHandlers) HandleArchivedTodoResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	rest := strings.TrimPrefix(request.Params.URI, "todo-archive://")
+	slash := strings.LastIndex(rest, "/")
+	if rest == request.Params.URI || slash == -1 {
+		return nil, interrors.NewValidationError("uri", request.Params.URI, "expected todo-archive://YYYY/MM/DD/<id>")
+	}
+	day, id := rest[:slash], rest[slash+1:]
+	if !archiveDatePattern.MatchString(day) || !validResourceID(id) {
+		return nil, interrors.NewValidationError("uri", request.Params.URI, "expected todo-archive://YYYY/MM/DD/<id>")
+	}
+
+	manager, _, _, _, err := h.factory.GetManagers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get context-aware managers: %w", err)
+	}
+
+	path := filepath.Join(manager.GetBasePath(), ".claude", "archive", filepath.FromSlash(day), id+".md")
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, interrors.NewNotFoundError("archived todo", day+"/"+id)
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: todoResourceMIMEType,
+			Text:     string(content),
+		},
+	}, nil
+}
+
+// managerForProject finds the manager serving a project, preferring the one
+// for the request's working directory
+func (h *TodoHandlers) managerForProject(ctx context.Context, project string) (TodoManager, error) {
+	if manager, _, _, _, err := h.factory.GetManagers(ctx); err == nil && ProjectName(manager.GetBasePath()) == project {
+		return manager, nil
+	}
+
+	// Project managers come after the base manager, and win over it
+	var found TodoManager
+	for _, manager := range h.factory.Managers() {
+		if ProjectName(manager.GetBasePath()) == project {
+			found = manager
+		}
+	}
+	if found == nil {
+		return nil, interrors.NewNotFoundError("project", project)
+	}
+	return found, nil
+}
+
+// parseTodoResourceURI splits todo://<project>/<id>
+func parseTodoResourceURI(uri string) (string, string, error) {
+	rest := strings.TrimPrefix(uri, "todo://")
+	parts := strings.Split(rest, "/")
+	if rest == uri || len(parts) != 2 {
+		return "", "", interrors.NewValidationError("uri", uri, "expected todo://<project>/<id>")
+	}
+
+	project, err := url.PathUnescape(parts[0])
+	if err != nil {
+		return "", "", interrors.NewValidationError("uri", uri, "invalid project name")
+	}
+	id, err := url.PathUnescape(parts[1])
+	if err != nil || !validResourceID(id) {
+		return "", "", interrors.NewValidationError("uri", uri, "invalid todo id")
+	}
+	return project, id, nil
+}
+
+// validResourceID rejects IDs that could escape the todo directories
+func validResourceID(id string) bool {
+	return id != "" && id != "." && id != ".." && !strings.ContainsAny(id, `/\`)
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/user/mcp-todo-server/core"
+)
+
+// sectionEditor is implemented by todo managers that can change a todo's
+// sections and their markdown headings together
+type sectionEditor interface {
+	AddSection(id, key string, def *core.SectionDefinition, position int) (*core.Todo, error)
+	RenameSection(id, key, newKey, newTitle string) (*core.Todo, error)
+	ReorderSections(id string, keys []string) (*core.Todo, error)
+	RemoveSection(id, key string) (*core.Todo, error)
+}
+
+// editSections runs a todo_sections add, rename, reorder or remove and
+// describes what changed
+func editSections(editor sectionEditor, params *TodoSectionsParams) (*core.Todo, string, error) {
+	switch params.Action {
+	case "add":
+		def := &core.SectionDefinition{Title: params.Title, Schema: core.SectionSchema(params.Schema)}
+		if len(params.Columns) > 0 {
+			def.Metadata = map[string]interface{}{"columns": params.Columns}
+		}
+		todo, err := editor.AddSection(params.ID, params.Key, def, params.Position)
+		if err != nil {
+			return nil, "", err
+		}
+		return todo, fmt.Sprintf("Added %s section '%s' to todo '%s'.", params.Schema, params.Key, params.ID), nil
+
+	case "rename":
+		todo, err := editor.RenameSection(params.ID, params.Key, params.NewKey, params.Title)
+		if err != nil {
+			return nil, "", err
+		}
+		var changes []string
+		if params.NewKey != "" && params.NewKey != params.Key {
+			changes = append(changes, fmt.Sprintf("key '%s'", params.NewKey))
+		}
+		if params.Title != "" {
+			changes = append(changes, fmt.Sprintf("title '%s'", params.Title))
+		}
+		if len(changes) == 0 {
+			return todo, fmt.Sprintf("Section '%s' of todo '%s' is unchanged.", params.Key, params.ID), nil
+		}
+		return todo, fmt.Sprintf("Renamed section '%s' of todo '%s' to %s.", params.Key, params.ID, strings.Join(changes, " and ")), nil
+
+	case "reorder":
+		todo, err := editor.ReorderSections(params.ID, params.Order)
+		if err != nil {
+			return nil, "", err
+		}
+		return todo, fmt.Sprintf("Reordered the sections of todo '%s'.", params.ID), nil
+
+	default:
+		todo, err := editor.RemoveSection(params.ID, params.Key)
+		if err != nil {
+			return nil, "", err
+		}
+		return todo, fmt.Sprintf("Removed section '%s' from todo '%s'.", params.Key, params.ID), nil
+	}
+}
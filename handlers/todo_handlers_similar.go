@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/user/mcp-todo-server/core"
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+// similarWarnThreshold is the similarity at which todo_create with
+// check_similar holds off and points at the existing work
+const similarWarnThreshold = 0.5
+
+// similarSearcher is implemented by search engines that can rank todos by
+// how alike they are
+type similarSearcher interface {
+	SimilarTodos(id string, limit int) ([]core.SearchResult, error)
+	SimilarToText(text string, limit int) ([]core.SearchResult, error)
+}
+
+// HandleTodoSimilar handles the todo_similar tool
+func (h *TodoHandlers) HandleTodoSimilar(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	params, err := ExtractTodoSimilarParams(request)
+	if err != nil {
+		return HandleError(err), nil
+	}
+
+	// Get managers for the current context
+	manager, search, _, _, err := h.factory.GetManagers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get context-aware managers: %w", err)
+	}
+
+	similar, ok := search.(similarSearcher)
+	if search == nil || !ok {
+		return nil, fmt.Errorf("similarity search is currently unavailable (index may be locked or corrupted)")
+	}
+
+	var results []core.SearchResult
+	if params.ID == "" {
+		results, err = similar.SimilarToText(params.Text, params.Limit)
+	} else {
+		results, err = similar.SimilarTodos(params.ID, params.Limit)
+		if err != nil {
+			// Not indexed (yet): compare by the todo's own text instead
+			text, readErr := todoText(manager, params.ID)
+			if readErr != nil {
+				return HandleError(interrors.NewNotFoundError("todo", params.ID)), nil
+			}
+			results, err = similar.SimilarToText(text, params.Limit+1)
+			results = withoutResult(results, params.ID, params.Limit)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("similarity search failed: %w", err)
+	}
+
+	source := fmt.Sprintf("'%s'", params.ID)
+	if params.ID == "" {
+		source = fmt.Sprintf("%q", params.Text)
+	}
+	return FormatTodoSimilarResponse(source, results), nil
+}
+
+// findSimilarWork returns todos close enough to task to be worth a warning.
+// Engines without similarity search never warn.
+func findSimilarWork(search SearchEngine, task string) []core.SearchResult {
+	similar, ok := search.(similarSearcher)
+	if search == nil || !ok {
+		return nil
+	}
+	results, err := similar.SimilarToText(task, 3)
+	if err != nil {
+		return nil
+	}
+
+	var matches []core.SearchResult
+	for _, result := range results {
+		if result.Score >= similarWarnThreshold {
+			matches = append(matches, result)
+		}
+	}
+	return matches
+}
+
+// todoText returns a todo's task and markdown body, without frontmatter
+func todoText(manager TodoManager, id string) (string, error) {
+	todo, err := manager.ReadTodo(id)
+	if err != nil {
+		return "", err
+	}
+	content, err := manager.ReadTodoContent(id)
+	if err != nil {
+		return "", err
+	}
+	if parts := strings.SplitN(content, "---\n", 3); len(parts) == 3 {
+		content = parts[2]
+	}
+	return todo.Task + "\n" + content, nil
+}
+
+// withoutResult drops id from results and trims them to limit
+func withoutResult(results []core.SearchResult, id string, limit int) []core.SearchResult {
+	var kept []core.SearchResult
+	for _, result := range results {
+		if result.ID != id && len(kept) < limit {
+			kept = append(kept, result)
+		}
+	}
+	return kept
+}
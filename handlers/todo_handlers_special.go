@@ -12,47 +12,79 @@ import (
 
 // HandleTodoTemplate creates a todo from template
 func (h *TodoHandlers) HandleTodoTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	params, err := ExtractTodoTemplateParams(request)
+	if err != nil {
+		return HandleError(err), nil
+	}
+
 	// Get managers for the current context
 	manager, search, _, templates, err := h.factory.GetManagers(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get context-aware managers: %w", err)
 	}
 
-	// Extract template parameter
-	template := request.GetString("template", "")
-	if template == "" {
-		// List available templates
-		templateList, err := templates.ListTemplates()
-		if err != nil {
-			return HandleError(err), nil
+	switch params.Action {
+	case "list":
+		return listTemplates(templates)
+	case "preview", "validate", "save":
+		author, ok := templates.(templateAuthor)
+		if !ok {
+			return HandleError(fmt.Errorf("template %s is not available with the current template manager", params.Action)), nil
 		}
+		return authorTemplate(manager, author, params)
+	}
 
-		response := "Available templates:\n"
-		for _, t := range templateList {
-			response += fmt.Sprintf("- %s\n", t)
+	// Create from template, with any phases it declares
+	var todo *core.Todo
+	var children []*core.Todo
+	if tree, ok := templates.(interface {
+		CreateTreeFromTemplate(templateName, task, priority, todoType string, variables map[string]string) (*core.Todo, []*core.Todo, error)
+	}); ok {
+		todo, children, err = tree.CreateTreeFromTemplate(params.Template, params.Task, params.Priority, params.Type, params.Variables)
+	} else {
+		todoType := params.Type
+		if todoType == "" {
+			todoType = "feature"
+		}
+		todo, err = templates.CreateFromTemplate(params.Template, params.Task, params.Priority, todoType)
+	}
+	if err != nil {
+		if todo == nil {
+			return HandleError(err), nil
 		}
-		return mcp.NewToolResultText(response), nil
+		// Part of the tree was written; index it and say what is missing
+		fmt.Fprintf(os.Stderr, "Warning: template tree for %s is incomplete: %v\n", todo.ID, err)
 	}
 
-	// Create from template
-	task, _ := request.RequireString("task")
-	priority := request.GetString("priority", "high")
-	todoType := request.GetString("type", "feature")
+	// Index the rendered todos as saved
+	for _, created := range append([]*core.Todo{todo}, children...) {
+		indexCreatedTodo(manager, search, created)
+	}
 
-	todo, err := templates.CreateFromTemplate(template, task, priority, todoType)
-	if err != nil {
-		return HandleError(err), nil
+	if len(children) > 0 {
+		return FormatTodoCreateMultiResponse(todo, children), nil
 	}
 
-	// Write and index
 	filePath := filepath.Join(manager.GetBasePath(), todo.ID+".md")
-	content := fmt.Sprintf("# Task: %s\n\n", todo.Task) // Template content would be added
-	err = search.IndexTodo(todo, content)
+	if resolved, err := core.ResolveTodoPath(manager.GetBasePath(), todo.ID); err == nil {
+		filePath = resolved
+	}
+	return FormatTodoTemplateResponse(todo, filePath, params.Template), nil
+}
+
+// indexCreatedTodo indexes a todo just written from a template, falling back
+// to its heading when the file can't be read back
+func indexCreatedTodo(manager TodoManager, search SearchEngine, todo *core.Todo) {
+	if search == nil {
+		return
+	}
+	content, err := manager.ReadTodoContent(todo.ID)
 	if err != nil {
+		content = fmt.Sprintf("# Task: %s\n\n", todo.Task)
+	}
+	if err := search.IndexTodo(todo, content); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to index todo: %v\n", err)
 	}
-
-	return FormatTodoTemplateResponse(todo, filePath, template), nil
 }
 
 // HandleTodoLink links related todos
@@ -109,4 +141,31 @@ func (h *TodoHandlers) HandleTodoStats(ctx context.Context, request mcp.CallTool
 	}
 
 	return FormatTodoStatsResponse(statsResult), nil
-}
\ No newline at end of file
+}
+
+// HandleTodoGraph renders a todo tree and its dependency links as a diagram
+func (h *TodoHandlers) HandleTodoGraph(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	params, err := ExtractTodoGraphParams(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get managers for the current context
+	manager, _, _, _, err := h.factory.GetManagers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get context-aware managers: %w", err)
+	}
+
+	// Graph building walks the archive too - need concrete TodoManager
+	concreteManager, ok := manager.(*core.TodoManager)
+	if !ok {
+		return HandleError(fmt.Errorf("Graph feature not available with current manager")), nil
+	}
+
+	graph, err := concreteManager.BuildTodoGraph(params.ID, params.IncludeArchived)
+	if err != nil {
+		return HandleError(err), nil
+	}
+
+	return FormatTodoGraphResponse(graph, params.Format), nil
+}
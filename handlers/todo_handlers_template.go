@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/user/mcp-todo-server/core"
+)
+
+// templateAuthor is implemented by template managers that can describe,
+// check, render and write templates
+type templateAuthor interface {
+	ListTemplateInfo() ([]core.TemplateInfo, error)
+	PreviewTemplate(templateName, task, priority, todoType string, variables map[string]string) (*core.RenderedTodo, error)
+	ValidateTemplate(name string) ([]string, error)
+	SaveTemplateFromTodo(name, description string, todo *core.Todo, content string) (*core.Template, error)
+}
+
+// listTemplates lists the available templates, with their descriptions and
+// variables when the template manager can provide them
+func listTemplates(templates TemplateManager) (*mcp.CallToolResult, error) {
+	if author, ok := templates.(templateAuthor); ok {
+		infos, err := author.ListTemplateInfo()
+		if err != nil {
+			return HandleError(err), nil
+		}
+		return FormatTemplateListResponse(infos), nil
+	}
+
+	names, err := templates.ListTemplates()
+	if err != nil {
+		return HandleError(err), nil
+	}
+	infos := make([]core.TemplateInfo, len(names))
+	for i, name := range names {
+		infos[i] = core.TemplateInfo{Name: name}
+	}
+	return FormatTemplateListResponse(infos), nil
+}
+
+// authorTemplate runs the todo_template preview, validate and save actions
+func authorTemplate(manager TodoManager, author templateAuthor, params *TodoTemplateParams) (*mcp.CallToolResult, error) {
+	switch params.Action {
+	case "preview":
+		task := params.Task
+		if task == "" {
+			task = "Example task"
+		}
+		preview, err := author.PreviewTemplate(params.Template, task, params.Priority, params.Type, params.Variables)
+		if err != nil {
+			return HandleError(err), nil
+		}
+		return FormatTemplatePreviewResponse(params.Template, preview), nil
+
+	case "validate":
+		problems, err := author.ValidateTemplate(params.Template)
+		if err != nil {
+			return HandleError(err), nil
+		}
+		return FormatTemplateValidationResponse(params.Template, problems), nil
+	}
+
+	todo, err := manager.ReadTodo(params.ID)
+	if err != nil {
+		return HandleError(err), nil
+	}
+	content, err := manager.ReadTodoContent(params.ID)
+	if err != nil {
+		return HandleError(err), nil
+	}
+	tmpl, err := author.SaveTemplateFromTodo(params.Template, params.Description, todo, content)
+	if err != nil {
+		return HandleError(err), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Saved template '%s' from todo %s (%d sections). Create todos from it with todo_template template=%s.",
+		tmpl.Name, todo.ID, len(tmpl.Sections), tmpl.Name)), nil
+}
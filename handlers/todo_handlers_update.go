@@ -37,10 +37,32 @@ func (h *TodoHandlers) HandleTodoUpdate(ctx context.Context, request mcp.CallToo
 	if params.Metadata.CurrentTest != "" {
 		metadataMap["current_test"] = params.Metadata.CurrentTest
 	}
+	if params.Metadata.Due != "" {
+		metadataMap["due"] = params.Metadata.Due
+	}
+	if params.Metadata.Scheduled != "" {
+		metadataMap["scheduled"] = params.Metadata.Scheduled
+	}
+	if params.Metadata.Recurrence != "" {
+		metadataMap["recurrence"] = params.Metadata.Recurrence
+	}
+	if params.Metadata.Tags != nil {
+		metadataMap["tags"] = strings.Join(params.Metadata.Tags, ",")
+	}
+	if len(params.Metadata.AddTags) > 0 {
+		metadataMap["add_tags"] = strings.Join(params.Metadata.AddTags, ",")
+	}
+	if len(params.Metadata.RemoveTags) > 0 {
+		metadataMap["remove_tags"] = strings.Join(params.Metadata.RemoveTags, ",")
+	}
 
 	if len(metadataMap) > 0 {
 		err = manager.UpdateTodo(params.ID, "", "", "", metadataMap)
 		if err != nil {
+			// Refusals such as open blockers are reported back to the caller
+			if interrors.IsValidation(err) {
+				return HandleError(err), nil
+			}
 			return nil, interrors.Wrap(err, "failed to update metadata")
 		}
 
@@ -48,6 +70,7 @@ func (h *TodoHandlers) HandleTodoUpdate(ctx context.Context, request mcp.CallToo
 		if newStatus, hasStatus := metadataMap["status"]; hasStatus && newStatus == "completed" && !h.noAutoArchive {
 			// Read todo to get its metadata for archive path
 			todo, readErr := manager.ReadTodo(params.ID)
+			content, _ := manager.ReadTodoContent(params.ID)
 			
 			// Perform auto-archive
 			archiveErr := manager.ArchiveTodo(params.ID)
@@ -64,12 +87,12 @@ func (h *TodoHandlers) HandleTodoUpdate(ctx context.Context, request mcp.CallToo
 				archivePath = filepath.Join(".claude", "archive", dayPath, params.ID+".md")
 			}
 			
-			// Remove from search index if archived successfully
+			// Keep it searchable as an archived todo
 			if archiveErr == nil && search != nil {
-				deleteErr := search.DeleteTodo(params.ID)
-				if deleteErr != nil {
+				indexErr := indexArchivedTodo(manager, search, params.ID)
+				if indexErr != nil {
 					// Log but don't fail
-					fmt.Fprintf(os.Stderr, "Warning: failed to remove from search index: %v\n", deleteErr)
+					fmt.Fprintf(os.Stderr, "Warning: failed to update search index: %v\n", indexErr)
 				}
 			}
 			
@@ -81,15 +104,23 @@ func (h *TodoHandlers) HandleTodoUpdate(ctx context.Context, request mcp.CallToo
 				} else {
 					prompts = getCompletionPrompts("")
 				}
+
+				// Recurring todos get their next instance once archived
+				recurrenceNote := ""
+				if readErr == nil && todo != nil {
+					recurrenceNote = spawnNextOccurrence(manager, search, todo, content)
+				}
 				
 				return mcp.NewToolResultText(fmt.Sprintf(
-					"Todo '%s' has been completed and archived to %s.\n\n%s",
-					params.ID, archivePath, prompts)), nil
+					"Todo '%s' has been completed and archived to %s.%s\n\n%s",
+					params.ID, archivePath, recurrenceNote, prompts)), nil
 			}
 		}
 		
-		// Re-index if status changed (for non-completed statuses)
-		if _, hasStatus := metadataMap["status"]; hasStatus && search != nil {
+		// Re-index if status or tags changed (for non-completed statuses)
+		_, hasStatus := metadataMap["status"]
+		tagsChanged := params.Metadata.Tags != nil || len(params.Metadata.AddTags) > 0 || len(params.Metadata.RemoveTags) > 0
+		if (hasStatus || tagsChanged) && search != nil {
 			todo, _ := manager.ReadTodo(params.ID)
 			if todo != nil {
 				content, _ := manager.ReadTodoContent(params.ID)
@@ -122,8 +153,20 @@ func (h *TodoHandlers) HandleTodoUpdate(ctx context.Context, request mcp.CallToo
 
 	// Handle section updates
 	if params.Section != "" {
-		err = manager.UpdateTodo(params.ID, params.Section, params.Operation, params.Content, nil)
+		// Managers that check section schemas report what didn't fit
+		var warnings []string
+		if checked, ok := manager.(interface {
+			UpdateTodoSection(id, section, operation, content string) ([]string, error)
+		}); ok {
+			warnings, err = checked.UpdateTodoSection(params.ID, params.Section, params.Operation, params.Content)
+		} else {
+			err = manager.UpdateTodo(params.ID, params.Section, params.Operation, params.Content, nil)
+		}
 		if err != nil {
+			// Content rejected in strict mode goes back to the caller
+			if interrors.IsValidation(err) {
+				return HandleError(err), nil
+			}
 			return nil, interrors.Wrap(err, "failed to update section")
 		}
 
@@ -151,6 +194,10 @@ func (h *TodoHandlers) HandleTodoUpdate(ctx context.Context, request mcp.CallToo
 		
 		// Build response with contextual prompts
 		baseMessage := fmt.Sprintf("Todo '%s' %s section %s", params.ID, params.Section, opDesc)
+		if len(warnings) > 0 {
+			baseMessage += "\n\nSaved, but the content doesn't match the section schema:\n- " + strings.Join(warnings, "\n- ") +
+				"\nProjects with strict: true in .claude/" + core.ProjectSettingsFile + " reject such content."
+		}
 		prompts := getUpdatePrompts(params.Section, params.Operation, todoType)
 		
 		if prompts != "" {
@@ -203,28 +250,50 @@ func getCompletionPrompts(todoType string) string {
 	}
 }
 
-// HandleTodoSections handles the todo_sections tool
+// HandleTodoSections handles the todo_sections tool: it lists a todo's
+// sections, or adds, renames, reorders or removes them
 func (h *TodoHandlers) HandleTodoSections(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	// Extract ID parameter
-	id, err := request.RequireString("id")
+	params, err := ExtractTodoSectionsParams(request)
 	if err != nil {
-		return HandleError(interrors.NewValidationError("id", "", "missing required parameter")), nil
+		return HandleError(err), nil
 	}
 
 	// Get managers for the current context
-	manager, _, _, _, err := h.factory.GetManagers(ctx)
+	manager, search, _, _, err := h.factory.GetManagers(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get context-aware managers: %w", err)
 	}
 
-	// Read the todo
-	todo, content, err := manager.ReadTodoWithContent(id)
+	if params.Action == "list" {
+		todo, content, err := manager.ReadTodoWithContent(params.ID)
+		if err != nil {
+			return HandleError(err), nil
+		}
+		// Link lists are checked against the project's todos and files
+		if measurer, ok := manager.(interface {
+			SectionMetrics(todo *core.Todo, content string) map[string]map[string]interface{}
+		}); ok {
+			return FormatTodoSectionsResponseWithMetrics(todo, content, measurer.SectionMetrics(todo, content)), nil
+		}
+		return FormatTodoSectionsResponseWithContent(todo, content), nil
+	}
+
+	editor, ok := manager.(sectionEditor)
+	if !ok {
+		return HandleError(fmt.Errorf("section %s is not available with the current todo manager", params.Action)), nil
+	}
+	todo, message, err := editSections(editor, params)
 	if err != nil {
 		return HandleError(err), nil
 	}
 
-	// Format sections response
-	return FormatTodoSectionsResponseWithContent(todo, content), nil
+	if search != nil {
+		content, _ := manager.ReadTodoContent(todo.ID)
+		if err := search.IndexTodo(todo, content); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to index todo %s: %v\n", todo.ID, err)
+		}
+	}
+	return FormatSectionChangeResponse(message, todo), nil
 }
 
 // HandleTodoAddSection handles adding a new section to a todo
@@ -263,6 +332,9 @@ func (h *TodoHandlers) HandleTodoAddSection(ctx context.Context, request mcp.Cal
 		"research":   true,
 		"strategy":   true,
 		"results":    true,
+		"decisions":  true,
+		"table":      true,
+		"links":      true,
 	}
 
 	if !validSchemas[schema] {
@@ -374,4 +446,40 @@ func (h *TodoHandlers) HandleTodoReorderSections(ctx context.Context, request mc
 
 	// Return success response
 	return mcp.NewToolResultText(fmt.Sprintf("Sections reordered successfully for todo '%s'", id)), nil
-}
\ No newline at end of file
+}
+
+// spawnNextOccurrence creates and indexes the next instance of a recurring
+// todo that was just archived. It returns a note for the response, or an
+// empty string when the todo doesn't recur.
+func spawnNextOccurrence(manager TodoManager, search SearchEngine, todo *core.Todo, content string) string {
+	if todo.Recurrence == "" {
+		return ""
+	}
+
+	// Creating a todo with a given ID needs concrete TodoManager
+	concreteManager, ok := manager.(*core.TodoManager)
+	if !ok {
+		return ""
+	}
+
+	next, err := concreteManager.CreateNextOccurrence(todo, content)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to create next occurrence of %s: %v\n", todo.ID, err)
+		return ""
+	}
+
+	if search != nil {
+		nextContent, _ := manager.ReadTodoContent(next.ID)
+		if err := search.IndexTodo(next, nextContent); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to index todo %s: %v\n", next.ID, err)
+		}
+	}
+
+	when := ""
+	if next.HasDue() {
+		when = fmt.Sprintf(", due %s", core.FormatDueDate(next.Due))
+	} else if !next.Scheduled.IsZero() {
+		when = fmt.Sprintf(", scheduled %s", core.FormatDueDate(next.Scheduled))
+	}
+	return fmt.Sprintf("\nNext occurrence created: '%s'%s.", next.ID, when)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/user/mcp-todo-server/core"
+	interrors "github.com/user/mcp-todo-server/internal/errors"
+)
+
+func TestHandleTodoIndex(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := core.NewTodoManager(tempDir)
+	search, err := core.NewSearchEngine(filepath.Join(tempDir, ".claude", "index", "todos.bleve"), filepath.Join(tempDir, ".claude", "todos"))
+	if err != nil {
+		t.Fatalf("Failed to create search engine: %v", err)
+	}
+	defer search.Close()
+	h := NewTodoHandlersWithDependencies(manager, search, NewMockStatsEngine(), NewMockTemplateManager())
+
+	// Created behind the index's back
+	manager.CreateTodo("Fix login timeout", "high", "bug")
+	manager.CreateTodo("Add dark mode toggle", "low", "feature")
+
+	text := callText(t, h.HandleTodoIndex, map[string]interface{}{})
+	if !strings.HasPrefix(text, "Search index: healthy") ||
+		!strings.Contains(text, "- Not indexed: 2 (add-dark-mode-toggle, fix-login-timeout)") ||
+		!strings.Contains(text, "- Circuit breaker: closed (0 failures)") {
+		t.Errorf("Expected health with two unindexed todos, got:\n%s", text)
+	}
+
+	text = callText(t, h.HandleTodoIndex, map[string]interface{}{"action": "reindex"})
+	if !strings.HasPrefix(text, "Reindex (incremental) started in the background") {
+		t.Errorf("Expected the reindex to start in the background, got:\n%s", text)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for job := search.LastReindex(); job == nil || job.Running; job = search.LastReindex() {
+		if time.Now().After(deadline) {
+			t.Fatal("Reindex did not finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	text = callText(t, h.HandleTodoIndex, map[string]interface{}{"action": "health"})
+	if !strings.Contains(text, "- Files on disk: 2 (0 archived), 2 up to date") ||
+		!strings.Contains(text, "- Last reindex: incremental, 2/2 files") ||
+		strings.Contains(text, "Not indexed") {
+		t.Errorf("Expected an up-to-date index after reindexing, got:\n%s", text)
+	}
+	if results, _ := search.SearchTodos("login", nil, 10); len(results) != 1 {
+		t.Errorf("Expected the reindexed todo to be searchable, got %+v", results)
+	}
+
+	text = callText(t, h.HandleTodoIndex, map[string]interface{}{"action": "compact"})
+	if !strings.HasPrefix(text, "Index compacted.") {
+		t.Errorf("Expected compaction to be reported, got:\n%s", text)
+	}
+
+	// The HTTP admin endpoint shares the same actions
+	result, err := h.IndexAdmin("", "health", "")
+	if health, ok := result.(core.IndexHealth); err != nil || !ok || health.Indexed != 2 {
+		t.Errorf("Expected index health from IndexAdmin, got %+v (err %v)", result, err)
+	}
+	if _, err := h.IndexAdmin("", "reindex", "sideways"); !interrors.IsValidation(err) {
+		t.Errorf("Expected a validation error for an unknown mode, got %v", err)
+	}
+	if _, err := h.IndexAdmin(t.TempDir(), "health", ""); !interrors.IsNotFound(err) {
+		t.Errorf("Expected a project the server hasn't opened to be refused, got %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/user/mcp-todo-server/core"
 )
 
 func TestHandleTodoLink(t *testing.T) {
@@ -511,4 +512,28 @@ func TestHandleTodoLinkWithoutBaseManager(t *testing.T) {
 	if result == nil || !result.IsError {
 		t.Errorf("Expected error result when baseManager is nil")
 	}
-}
\ No newline at end of file
+}
+
+func TestReadListsOnlyOpenBlockers(t *testing.T) {
+	manager := core.NewTodoManager(t.TempDir())
+	h := NewTodoHandlersWithDependencies(manager, nil, NewMockStatsEngine(), NewMockTemplateManager())
+
+	schema, _ := manager.CreateTodo("Design schema", "high", "feature")
+	review, _ := manager.CreateTodo("Review schema", "high", "feature")
+	migrate, _ := manager.CreateTodo("Write migration", "high", "feature")
+	linker := core.NewTodoLinker(manager)
+	linker.LinkTodos(schema.ID, migrate.ID, "blocks")
+	linker.LinkTodos(review.ID, migrate.ID, "blocks")
+	manager.UpdateTodo(schema.ID, "", "", "", map[string]string{"status": "completed"})
+
+	text := callText(t, h.HandleTodoRead, map[string]interface{}{"filter": map[string]interface{}{"status": "in_progress"}})
+	if !strings.Contains(text, "(blocked by: review-schema)") || strings.Contains(text, "blocked by: design-schema") {
+		t.Errorf("Expected only the open blocker in the listing, got: %s", text)
+	}
+
+	manager.UpdateTodo(review.ID, "", "", "", map[string]string{"status": "completed"})
+	text = callText(t, h.HandleTodoRead, map[string]interface{}{"id": migrate.ID})
+	if strings.Contains(text, "blocked by") || strings.Contains(text, "Blocked by") {
+		t.Errorf("Expected no blockers once they are completed, got: %s", text)
+	}
+}
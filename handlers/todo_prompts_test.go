@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/user/mcp-todo-server/core"
+)
+
+func getPrompt(t *testing.T, handler func(context.Context, mcp.GetPromptRequest) (*mcp.GetPromptResult, error), args map[string]string) string {
+	t.Helper()
+	request := mcp.GetPromptRequest{}
+	request.Params.Arguments = args
+	result, err := handler(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Prompt failed: %v", err)
+	}
+	if len(result.Messages) != 1 {
+		t.Fatalf("Expected one message, got %d", len(result.Messages))
+	}
+	return result.Messages[0].Content.(mcp.TextContent).Text
+}
+
+func setupPromptHandlers(t *testing.T) (*TodoHandlers, *core.TodoManager) {
+	t.Helper()
+	manager := core.NewTodoManager(t.TempDir())
+	h := NewTodoHandlersWithDependencies(manager, NewMockSearchEngine(), core.NewStatsEngine(manager), NewMockTemplateManager())
+
+	shipped, _ := manager.CreateTodo("Ship login page", "high", "feature")
+	manager.UpdateTodo(shipped.ID, "test_results", "append", "login_test.go: 12 passed", nil)
+	if err := manager.ArchiveTodo(shipped.ID); err != nil {
+		t.Fatalf("Failed to archive: %v", err)
+	}
+
+	working, _ := manager.CreateTodo("Add password reset", "medium", "feature")
+	manager.UpdateTodo(working.ID, "findings", "append", "Reset tokens expire after 1h", nil)
+	manager.UpdateTodo(working.ID, "checklist", "append", "- [x] Token table\n- [ ] Email template\n- [ ] Rate limiting", nil)
+
+	waiting, _ := manager.CreateTodo("Migrate sessions", "low", "refactor")
+	manager.UpdateTodo(waiting.ID, "", "", "", map[string]string{"status": "blocked"})
+
+	return h, manager
+}
+
+func TestHandleStandupPrompt(t *testing.T) {
+	h, _ := setupPromptHandlers(t)
+	text := getPrompt(t, h.HandleStandupPrompt, nil)
+
+	sections := map[string]string{
+		"## Done since yesterday": "Ship login page (ship-login-page) [HIGH]",
+		"## In progress today":    "Add password reset (add-password-reset) - 1/3 done",
+		"## Blocked":              "Migrate sessions (migrate-sessions) [LOW]",
+	}
+	for heading, line := range sections {
+		section := text[strings.Index(text, heading):]
+		if !strings.Contains(section, line) {
+			t.Errorf("Expected %q under %q, got:\n%s", line, heading, text)
+		}
+	}
+}
+
+func TestStandupListsOnlyOpenBlockers(t *testing.T) {
+	manager := core.NewTodoManager(t.TempDir())
+	h := NewTodoHandlersWithDependencies(manager, NewMockSearchEngine(), core.NewStatsEngine(manager), NewMockTemplateManager())
+
+	schema, _ := manager.CreateTodo("Design schema", "high", "feature")
+	review, _ := manager.CreateTodo("Review schema", "high", "feature")
+	migrate, _ := manager.CreateTodo("Write migration", "high", "feature")
+	linker := core.NewTodoLinker(manager)
+	linker.LinkTodos(schema.ID, migrate.ID, "blocks")
+	linker.LinkTodos(review.ID, migrate.ID, "blocks")
+	manager.UpdateTodo(migrate.ID, "", "", "", map[string]string{"status": "blocked"})
+	manager.UpdateTodo(schema.ID, "", "", "", map[string]string{"status": "completed"})
+
+	text := getPrompt(t, h.HandleStandupPrompt, nil)
+	section := text[strings.Index(text, "## Blocked"):]
+	if !strings.Contains(section, "Write migration (write-migration) [HIGH] - blocked by review-schema") {
+		t.Errorf("Expected the open blocker under Blocked, got:\n%s", text)
+	}
+	if strings.Contains(section, "design-schema") {
+		t.Errorf("Expected completed blockers to be left out, got:\n%s", text)
+	}
+}
+
+func TestHandleHandoffPrompt(t *testing.T) {
+	h, _ := setupPromptHandlers(t)
+	text := getPrompt(t, h.HandleHandoffPrompt, map[string]string{"id": "add-password-reset"})
+
+	for _, want := range []string{
+		"# Handoff: Add password reset",
+		"Reset tokens expire after 1h",
+		"## Checklist (1/3 done)",
+		"- [x] Token table",
+		"## Next steps\n- Email template\n- Rate limiting",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in handoff, got:\n%s", want, text)
+		}
+	}
+
+	request := mcp.GetPromptRequest{}
+	if _, err := h.HandleHandoffPrompt(context.Background(), request); err == nil {
+		t.Error("Expected missing id to fail")
+	}
+}
+
+func TestHandleRetroPrompt(t *testing.T) {
+	h, _ := setupPromptHandlers(t)
+	text := getPrompt(t, h.HandleRetroPrompt, nil)
+
+	for _, want := range []string{
+		"# Retro: the last week",
+		"Completed: 1 todos. Still open from this period: 1 in progress, 1 blocked.",
+		"## Ship login page (ship-login-page)",
+		"login_test.go: 12 passed",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in retro, got:\n%s", want, text)
+		}
+	}
+
+	request := mcp.GetPromptRequest{}
+	request.Params.Arguments = map[string]string{"period": "fortnight"}
+	if _, err := h.HandleRetroPrompt(context.Background(), request); err == nil {
+		t.Error("Expected unknown period to fail")
+	}
+}
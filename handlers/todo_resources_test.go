@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/user/mcp-todo-server/core"
+)
+
+func readResource(h *TodoHandlers, uri string, handler func(context.Context, mcp.ReadResourceRequest) ([]mcp.ResourceContents, error)) (string, error) {
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = uri
+	contents, err := handler(context.Background(), request)
+	if err != nil {
+		return "", err
+	}
+	return contents[0].(mcp.TextResourceContents).Text, nil
+}
+
+func TestParseTodoResourceURI(t *testing.T) {
+	tests := []struct {
+		uri     string
+		project string
+		id      string
+		wantErr bool
+	}{
+		{uri: "todo://my-app/fix-login", project: "my-app", id: "fix-login"},
+		{uri: "todo://my%20app/fix-login", project: "my app", id: "fix-login"},
+		{uri: "todo://my-app/..", wantErr: true},
+		{uri: "todo://my-app/a/b", wantErr: true},
+		{uri: "todo://my-app/", wantErr: true},
+		{uri: "file:///etc/passwd", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			project, id, err := parseTodoResourceURI(tt.uri)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got %s/%s", project, id)
+				}
+				return
+			}
+			if err != nil || project != tt.project || id != tt.id {
+				t.Errorf("Expected %s/%s, got %s/%s (err %v)", tt.project, tt.id, project, id, err)
+			}
+		})
+	}
+}
+
+func TestProjectName(t *testing.T) {
+	name := ProjectName("/home/me/my-app")
+	if !strings.HasPrefix(name, "my-app-") {
+		t.Errorf("Expected the directory name with a suffix, got %s", name)
+	}
+	if got := ProjectName("/home/me/my-app/.claude/todos"); got != name {
+		t.Errorf("Expected todo directory to map to its project %s, got %s", name, got)
+	}
+	if got := ProjectName("/srv/my-app"); got == name {
+		t.Errorf("Expected projects with the same name in different places to differ, both got %s", got)
+	}
+}
+
+func TestHandleTodoResources(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := core.NewTodoManager(tempDir)
+	h := NewTodoHandlersWithDependencies(manager, NewMockSearchEngine(), NewMockStatsEngine(), NewMockTemplateManager())
+
+	todo, err := manager.CreateTodo("Readable task", "high", "feature")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resources := h.ListTodoResources()
+	uri := TodoResourceURI(ProjectName(tempDir), todo.ID)
+	if len(resources) != 1 || resources[0].URI != uri || resources[0].MIMEType != "text/markdown" {
+		t.Fatalf("Expected one resource %s, got %+v", uri, resources)
+	}
+
+	text, err := readResource(h, uri, h.HandleTodoResource)
+	if err != nil || !strings.Contains(text, "# Task: Readable task") {
+		t.Errorf("Expected todo markdown, got %q (err %v)", text, err)
+	}
+
+	if _, err := readResource(h, TodoResourceURI("other-project", todo.ID), h.HandleTodoResource); err == nil {
+		t.Error("Expected unknown project to fail")
+	}
+	if _, err := readResource(h, TodoResourceURI(ProjectName(tempDir), "missing"), h.HandleTodoResource); err == nil {
+		t.Error("Expected missing todo to fail")
+	}
+
+	// Archived todos are addressed by day
+	archiveDir := filepath.Join(tempDir, ".claude", "archive", "2025", "01", "15")
+	os.MkdirAll(archiveDir, 0755)
+	ioutil.WriteFile(filepath.Join(archiveDir, "old-task.md"), []byte("---\ntodo_id: old-task\n---\n\n# Task: Old task\n"), 0644)
+
+	text, err = readResource(h, "todo-archive://2025/01/15/old-task", h.HandleArchivedTodoResource)
+	if err != nil || !strings.Contains(text, "# Task: Old task") {
+		t.Errorf("Expected archived markdown, got %q (err %v)", text, err)
+	}
+
+	for _, bad := range []string{
+		"todo-archive://2025/01/16/old-task",
+		"todo-archive://2025/01/old-task",
+		"todo-archive://../../todos/x/old-task",
+	} {
+		if _, err := readResource(h, bad, h.HandleArchivedTodoResource); err == nil {
+			t.Errorf("Expected %s to fail", bad)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/user/mcp-todo-server/core"
+)
+
+func TestHandleTodoRestore(t *testing.T) {
+	manager := core.NewTodoManager(t.TempDir())
+	search := NewMockSearchEngine()
+	h := NewTodoHandlersWithDependencies(manager, search, NewMockStatsEngine(), NewMockTemplateManager())
+
+	parent, _ := manager.CreateTodo("Payment flow", "high", "multi-phase")
+	child, _ := manager.CreateTodoWithParent("Refunds", "high", "phase", parent.ID)
+	manager.UpdateTodo(child.ID, "", "", "", map[string]string{"status": "completed"})
+	manager.ArchiveTodo(child.ID)
+	manager.ArchiveTodo(parent.ID)
+
+	request := &MockCallToolRequest{Arguments: map[string]interface{}{"id": parent.ID, "cascade": true}}
+	result, err := h.HandleTodoRestore(context.Background(), request.ToCallToolRequest())
+	if err != nil || result.IsError {
+		t.Fatalf("Restore failed: %v %+v", err, result)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, "restored from the archive with 1 archived children") {
+		t.Errorf("Unexpected response: %s", text)
+	}
+
+	indexed := map[string]bool{}
+	for _, call := range search.GetCalls() {
+		if call.Method == "IndexTodo" {
+			indexed[call.Args[0].(*core.Todo).ID] = true
+		}
+	}
+	if !indexed[parent.ID] || !indexed[child.ID] {
+		t.Errorf("Expected both restored todos to be re-indexed, got %v", indexed)
+	}
+
+	// Not in the archive any more
+	result, _ = h.HandleTodoRestore(context.Background(), request.ToCallToolRequest())
+	if !result.IsError {
+		t.Error("Expected second restore to fail")
+	}
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/user/mcp-todo-server/core"
+)
+
+func TestArchivedTodosStaySearchable(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := core.NewTodoManager(tempDir)
+	search, err := core.NewSearchEngine(filepath.Join(tempDir, "index", "todos.bleve"), tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create search engine: %v", err)
+	}
+	defer search.Close()
+	h := NewTodoHandlersWithDependencies(manager, search, NewMockStatsEngine(), NewMockTemplateManager())
+
+	todo, _ := manager.CreateTodo("Rotate signing keys", "high", "feature")
+	content, _ := manager.ReadTodoContent(todo.ID)
+	search.IndexTodo(todo, content)
+
+	archive := &MockCallToolRequest{Arguments: map[string]interface{}{"id": todo.ID}}
+	if result, err := h.HandleTodoArchive(context.Background(), archive.ToCallToolRequest()); err != nil || result.IsError {
+		t.Fatalf("Archive failed: %v %+v", err, result)
+	}
+
+	searchText := func(args map[string]interface{}) string {
+		t.Helper()
+		args["query"] = "signing"
+		request := &MockCallToolRequest{Arguments: args}
+		result, err := h.HandleTodoSearch(context.Background(), request.ToCallToolRequest())
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	if text := searchText(map[string]interface{}{}); !strings.HasPrefix(text, "No matching todos found") {
+		t.Errorf("Expected archived todo to be hidden by default, got: %s", text)
+	}
+	for _, args := range []map[string]interface{}{{"include_archived": true}, {"archived_only": true}} {
+		text := searchText(args)
+		if !strings.Contains(text, todo.ID+" [archived: .claude/archive/") {
+			t.Errorf("Expected archived hit with its path for %v, got: %s", args, text)
+		}
+	}
+}
+
+func TestArchiveRespawnsOnlyCompletedRecurringTodos(t *testing.T) {
+	manager := core.NewTodoManager(t.TempDir())
+	h := NewTodoHandlersWithDependencies(manager, nil, NewMockStatsEngine(), NewMockTemplateManager())
+
+	archive := func(id string) string {
+		t.Helper()
+		request := &MockCallToolRequest{Arguments: map[string]interface{}{"id": id}}
+		result, err := h.HandleTodoArchive(context.Background(), request.ToCallToolRequest())
+		if err != nil {
+			t.Fatalf("Archive failed: %v", err)
+		}
+		var texts []string
+		for _, content := range result.Content {
+			texts = append(texts, content.(mcp.TextContent).Text)
+		}
+		return strings.Join(texts, "\n")
+	}
+	recurring := func(task, status string) *core.Todo {
+		todo, _ := manager.CreateTodo(task, "medium", "chore")
+		manager.UpdateTodoFrontmatter(todo.ID, func(todo *core.Todo) error {
+			todo.Recurrence = "weekly"
+			todo.Status = status
+			return nil
+		})
+		return todo
+	}
+
+	dropped := recurring("Rotate on-call", "in_progress")
+	if text := archive(dropped.ID); strings.Contains(text, "Next occurrence created") {
+		t.Errorf("Expected no new occurrence for an unfinished todo, got: %s", text)
+	}
+	done := recurring("Review dependencies", "completed")
+	if text := archive(done.ID); !strings.Contains(text, "Next occurrence created") {
+		t.Errorf("Expected a new occurrence for a completed todo, got: %s", text)
+	}
+
+	todos, _ := manager.ListTodos("", "", 0)
+	if len(todos) != 1 || todos[0].Task != "Review dependencies" {
+		t.Errorf("Expected only the completed todo to recur, got %d todos", len(todos))
+	}
+}
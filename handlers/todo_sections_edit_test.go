@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/user/mcp-todo-server/core"
+)
+
+func TestHandleTodoSectionsEditing(t *testing.T) {
+	manager := core.NewTodoManager(t.TempDir())
+	h := NewTodoHandlersWithDependencies(manager, nil, NewMockStatsEngine(), NewMockTemplateManager())
+
+	todo, _ := manager.CreateTodo("Ship audit log", "high", "feature")
+	manager.UpdateTodo(todo.ID, "checklist", "append", "- [x] Schema\n- [ ] Retention", nil)
+
+	text := callText(t, h.HandleTodoSections, map[string]interface{}{"id": todo.ID})
+	if !strings.Contains(text, `"completed": 1`) || !strings.Contains(text, `"pending": 1`) {
+		t.Errorf("Expected checklist metrics in the listing, got: %s", text)
+	}
+
+	text = callText(t, h.HandleTodoSections, map[string]interface{}{
+		"id": todo.ID, "action": "add", "key": "rollout", "schema": "checklist", "position": float64(1),
+	})
+	if !strings.HasPrefix(text, "Added checklist section 'rollout' to todo 'ship-audit-log'.") ||
+		!strings.Contains(text, "1. rollout: Rollout [checklist]") {
+		t.Errorf("Unexpected add response: %s", text)
+	}
+
+	text = callText(t, h.HandleTodoSections, map[string]interface{}{
+		"id": todo.ID, "action": "reorder", "order": []interface{}{"checklist"},
+	})
+	if !strings.Contains(text, "1. checklist: Checklist [checklist]\n2. rollout: Rollout [checklist]") {
+		t.Errorf("Unexpected reorder response: %s", text)
+	}
+	content, _ := manager.ReadTodoContent(todo.ID)
+	if !strings.Contains(content, "# Task: Ship audit log\n\n## Checklist\n\n- [x] Schema\n- [ ] Retention\n\n## Rollout") {
+		t.Errorf("Expected the checklist body to move with its heading, got:\n%s", content)
+	}
+
+	text = callText(t, h.HandleTodoSections, map[string]interface{}{"id": todo.ID, "action": "remove", "key": "checklist"})
+	if !strings.Contains(text, "section has content") {
+		t.Errorf("Expected removing a section with content to fail, got: %s", text)
+	}
+
+	text = callText(t, h.HandleTodoSections, map[string]interface{}{"id": todo.ID, "action": "rename", "key": "rollout"})
+	if !strings.Contains(text, "rename needs new_key, title or both") {
+		t.Errorf("Expected rename without a target to fail, got: %s", text)
+	}
+}
+
+func TestHandleTodoSectionsRecordSchemas(t *testing.T) {
+	manager := core.NewTodoManager(t.TempDir())
+	h := NewTodoHandlersWithDependencies(manager, nil, NewMockStatsEngine(), NewMockTemplateManager())
+	todo, _ := manager.CreateTodo("Plan storage", "high", "feature")
+
+	text := callText(t, h.HandleTodoSections, map[string]interface{}{
+		"id": todo.ID, "action": "add", "key": "owners", "schema": "table", "columns": []interface{}{"Area", "Owner"},
+	})
+	if !strings.Contains(text, "owners: Owners [table]") {
+		t.Errorf("Unexpected add response: %s", text)
+	}
+	content, _ := manager.ReadTodoContent(todo.ID)
+	if !strings.Contains(content, "## Owners\n\n| Area | Owner |\n| --- | --- |") {
+		t.Errorf("Expected the table header under the heading, got:\n%s", content)
+	}
+
+	text = callText(t, h.HandleTodoSections, map[string]interface{}{
+		"id": todo.ID, "action": "add", "key": "notes", "columns": []interface{}{"Area"},
+	})
+	if !strings.Contains(text, "columns only apply to table sections") {
+		t.Errorf("Expected columns without a table to fail, got: %s", text)
+	}
+
+	callText(t, h.HandleTodoSections, map[string]interface{}{"id": todo.ID, "action": "add", "key": "links", "schema": "links"})
+	manager.UpdateTodoSection(todo.ID, "links", "append", "- todo:gone-todo\n- https://sqlite.org")
+	text = callText(t, h.HandleTodoSections, map[string]interface{}{"id": todo.ID})
+	if !strings.Contains(text, `"missing_todos": [`) || !strings.Contains(text, `"gone-todo"`) {
+		t.Errorf("Expected the missing todo in the listing, got: %s", text)
+	}
+}
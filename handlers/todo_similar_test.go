@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/user/mcp-todo-server/core"
+)
+
+func setupSimilarHandlers(t *testing.T) (*TodoHandlers, *core.TodoManager, *core.SearchEngine) {
+	t.Helper()
+	tempDir := t.TempDir()
+	manager := core.NewTodoManager(tempDir)
+	search, err := core.NewSearchEngine(filepath.Join(tempDir, "index", "todos.bleve"), tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create search engine: %v", err)
+	}
+	t.Cleanup(func() { search.Close() })
+	h := NewTodoHandlersWithDependencies(manager, search, NewMockStatsEngine(), NewMockTemplateManager())
+
+	for _, task := range []string{"Fix login timeout on slow networks", "Add dark mode toggle"} {
+		todo, _ := manager.CreateTodo(task, "high", "bug")
+		content, _ := manager.ReadTodoContent(todo.ID)
+		search.IndexTodo(todo, content)
+	}
+	manager.UpdateTodo("fix-login-timeout-on-slow-networks", "findings", "append", "Raised the auth client timeout to 10s", nil)
+	if err := manager.ArchiveTodo("fix-login-timeout-on-slow-networks"); err != nil {
+		t.Fatalf("Failed to archive: %v", err)
+	}
+	if err := indexArchivedTodo(manager, search, "fix-login-timeout-on-slow-networks"); err != nil {
+		t.Fatalf("Failed to index archived todo: %v", err)
+	}
+	return h, manager, search
+}
+
+func callText(t *testing.T, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]interface{}) string {
+	t.Helper()
+	request := &MockCallToolRequest{Arguments: args}
+	result, err := handler(context.Background(), request.ToCallToolRequest())
+	if err != nil {
+		t.Fatalf("Handler failed: %v", err)
+	}
+	return result.Content[0].(mcp.TextContent).Text
+}
+
+func TestHandleTodoSimilar(t *testing.T) {
+	h, _, _ := setupSimilarHandlers(t)
+
+	text := callText(t, h.HandleTodoSimilar, map[string]interface{}{"text": "login times out"})
+	if !strings.Contains(text, "• fix-login-timeout-on-slow-networks - Fix login timeout on slow networks") ||
+		!strings.Contains(text, "[archived: .claude/archive/") {
+		t.Errorf("Expected the archived login todo, got:\n%s", text)
+	}
+	if strings.Contains(text, "add-dark-mode-toggle") {
+		t.Errorf("Expected unrelated todo to be left out, got:\n%s", text)
+	}
+
+	text = callText(t, h.HandleTodoSimilar, map[string]interface{}{"id": "add-dark-mode-toggle"})
+	if !strings.HasPrefix(text, "No todos similar to 'add-dark-mode-toggle' found.") {
+		t.Errorf("Expected no matches for an unrelated todo, got:\n%s", text)
+	}
+
+	request := &MockCallToolRequest{Arguments: map[string]interface{}{}}
+	if result, _ := h.HandleTodoSimilar(context.Background(), request.ToCallToolRequest()); result == nil || !result.IsError {
+		t.Error("Expected an error without id or text")
+	}
+}
+
+func TestHandleTodoCreateCheckSimilar(t *testing.T) {
+	h, manager, _ := setupSimilarHandlers(t)
+
+	text := callText(t, h.HandleTodoCreate, map[string]interface{}{"task": "Fix login timeout", "check_similar": true})
+	if !strings.Contains(text, "You solved something similar in fix-login-timeout-on-slow-networks") {
+		t.Errorf("Expected a similar-work warning, got:\n%s", text)
+	}
+	if _, err := manager.ReadTodo("fix-login-timeout"); err == nil {
+		t.Error("Expected the todo not to be created")
+	}
+
+	callText(t, h.HandleTodoCreate, map[string]interface{}{"task": "Fix login timeout"})
+	if _, err := manager.ReadTodo("fix-login-timeout"); err != nil {
+		t.Errorf("Expected the todo to be created without check_similar: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/user/mcp-todo-server/core"
+)
+
+func TestHandleTodoTemplateAuthoring(t *testing.T) {
+	tempDir := t.TempDir()
+	manager := core.NewTodoManager(tempDir)
+	templates := core.NewTemplateManagerWithTodos(filepath.Join(tempDir, ".claude", "templates"), manager)
+	h := NewTodoHandlersWithDependencies(manager, nil, NewMockStatsEngine(), templates)
+
+	todo, _ := manager.CreateTodo("Rotate API keys", "high", "feature")
+
+	text := callText(t, h.HandleTodoTemplate, map[string]interface{}{
+		"action": "save", "template": "key-rotation", "id": todo.ID, "description": "Credential rotation",
+	})
+	if !strings.HasPrefix(text, "Saved template 'key-rotation' from todo rotate-api-keys") {
+		t.Errorf("Expected the template to be saved, got: %s", text)
+	}
+
+	text = callText(t, h.HandleTodoTemplate, map[string]interface{}{})
+	if !strings.Contains(text, "- key-rotation: Credential rotation") {
+		t.Errorf("Expected the listing to include the description, got: %s", text)
+	}
+
+	text = callText(t, h.HandleTodoTemplate, map[string]interface{}{"action": "validate", "template": "key-rotation"})
+	if text != "Template 'key-rotation' is valid." {
+		t.Errorf("Expected the saved template to validate, got: %s", text)
+	}
+
+	text = callText(t, h.HandleTodoTemplate, map[string]interface{}{
+		"action": "preview", "template": "key-rotation", "task": "Rotate database passwords",
+	})
+	if !strings.HasPrefix(text, "Preview of template 'key-rotation' (nothing was created)") ||
+		!strings.Contains(text, "# Task: Rotate database passwords") || !strings.Contains(text, "- findings: Findings & Research (research)") {
+		t.Errorf("Unexpected preview: %s", text)
+	}
+	if todos, _ := manager.ListTodos("", "", 0); len(todos) != 1 {
+		t.Errorf("Expected preview not to create a todo, got %d todos", len(todos))
+	}
+
+	text = callText(t, h.HandleTodoTemplate, map[string]interface{}{"action": "save", "template": "key-rotation"})
+	if !strings.Contains(text, "id of the todo to save as a template is required") {
+		t.Errorf("Expected save without id to fail, got: %s", text)
+	}
+}
+
+func TestHandleTodoTemplateCreatesTree(t *testing.T) {
+	tempDir := t.TempDir()
+	templatesDir := filepath.Join(tempDir, ".claude", "templates")
+	manager := core.NewTodoManager(tempDir)
+	templates := core.NewTemplateManagerWithTodos(templatesDir, manager)
+	h := NewTodoHandlersWithDependencies(manager, nil, NewMockStatsEngine(), templates)
+
+	os.MkdirAll(templatesDir, 0755)
+	os.WriteFile(filepath.Join(templatesDir, "migration.md"), []byte(`---
+template_name: migration
+description: Data migration
+variables:
+  - table
+children:
+  - task: "Backfill {{.table}}"
+  - task: "Drop old {{.table}} columns"
+    priority: low
+---
+
+## Plan
+`), 0644)
+
+	text := callText(t, h.HandleTodoTemplate, map[string]interface{}{
+		"template": "migration", "task": "Migrate orders", "variables": map[string]interface{}{"table": "orders"},
+	})
+	if !strings.HasPrefix(text, "Created multi-phase project: migrate-orders") ||
+		!strings.Contains(text, "Backfill orders [HIGH] [phase]") || !strings.Contains(text, "Drop old orders columns [LOW] [phase]") {
+		t.Errorf("Expected the parent and both phases, got: %s", text)
+	}
+
+	text = callText(t, h.HandleTodoTemplate, map[string]interface{}{"action": "preview", "template": "migration", "task": "Migrate users"})
+	if !strings.Contains(text, "needs values for: table") {
+		t.Errorf("Expected preview without variables to fail, got: %s", text)
+	}
+}
@@ -261,6 +261,33 @@ func TestHandleTodoTemplate(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "create from template with invalid variables",
+			request: &MockCallToolRequest{
+				Arguments: map[string]interface{}{
+					"template":  "bug-fix",
+					"task":      "Fix bug",
+					"variables": map[string]interface{}{"component": []interface{}{"auth", "api"}},
+				},
+			},
+			setupMocks: func(tm *MockTodoManager, se *MockSearchEngine, tmpl *MockTemplateManager) {
+				tmpl.CreateFromTemplateFunc = func(templateName, task, priority, todoType string) (*core.Todo, error) {
+					t.Error("Expected no todo to be created with invalid variables")
+					return nil, nil
+				}
+			},
+			context:     context.Background(),
+			expectError: false,
+			expectedResult: func(t *testing.T, result *mcp.CallToolResult) {
+				if !result.IsError {
+					t.Errorf("Expected error result")
+				}
+				textContent, _ := result.Content[0].(mcp.TextContent)
+				if !strings.Contains(textContent.Text, "component must be a string, number or boolean") {
+					t.Errorf("Expected invalid variable error, got: %s", textContent.Text)
+				}
+			},
+		},
 		{
 			name: "create from template error",
 			request: &MockCallToolRequest{
@@ -218,3 +218,39 @@ sections:
 		})
 	}
 }
+
+func TestToggleNestedChecklistAndReadProgress(t *testing.T) {
+	manager := core.NewTodoManager(t.TempDir())
+	h := NewTodoHandlersWithDependencies(manager, nil, NewMockStatsEngine(), NewMockTemplateManager())
+	todo, _ := manager.CreateTodo("Ship export", "high", "feature")
+	manager.UpdateTodo(todo.ID, "checklist", "append", "- [ ] Export ^c1\n  - [x] CSV\n  - [ ] Upload ^c3\n- [ ] Docs\n- [ ] Docs", nil)
+
+	callText(t, h.HandleTodoUpdate, map[string]interface{}{
+		"id": todo.ID, "section": "checklist", "operation": "toggle", "content": "^c3",
+	})
+	callText(t, h.HandleTodoUpdate, map[string]interface{}{
+		"id": todo.ID, "section": "checklist", "operation": "toggle", "content": "^c3",
+	})
+	text := callText(t, h.HandleTodoUpdate, map[string]interface{}{
+		"id": todo.ID, "section": "checklist", "operation": "toggle", "content": "Docs",
+	})
+	if !strings.Contains(text, "toggle by position or ^id instead") {
+		t.Errorf("Expected the duplicate text to be refused, got: %s", text)
+	}
+
+	text = callText(t, h.HandleTodoRead, map[string]interface{}{"id": todo.ID, "format": "full"})
+	var data struct {
+		Progress struct {
+			Percent int                   `json:"percent"`
+			Items   []*core.ChecklistNode `json:"items"`
+		} `json:"checklist_progress"`
+	}
+	if err := json.Unmarshal([]byte(text), &data); err != nil {
+		t.Fatalf("Expected JSON, got %v: %s", err, text)
+	}
+	// Export is done through its children; the two Docs items are not
+	export := data.Progress.Items[0]
+	if data.Progress.Percent != 33 || export.Status != "completed" || export.Progress != 100 || export.Children[1].ID != "c3" {
+		t.Errorf("Unexpected checklist progress: %s", text)
+	}
+}
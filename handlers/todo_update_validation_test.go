@@ -2,71 +2,17 @@ package handlers
 
 import (
 	"context"
-	"fmt"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/user/mcp-todo-server/core"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
 // Test 11: Update section with schema validation enabled
 func TestUpdateSectionWithSchemaValidation(t *testing.T) {
-	t.Skip("Skipping test - schema validation not implemented in UpdateTodo")
-	// Create mock managers
-	mockManager := NewMockTodoManager()
-
-	// Setup todo with sections that have schemas
-	testTodo := &core.Todo{
-		ID:       "test-todo-validation",
-		Task:     "Test todo with validation",
-		Status:   "in_progress",
-		Priority: "high",
-		Type:     "feature",
-		Sections: map[string]*core.SectionDefinition{
-			"checklist": {
-				Title:    "## Checklist",
-				Order:    1,
-				Schema:   core.SchemaChecklist,
-				Required: true,
-			},
-			"test_cases": {
-				Title:    "## Test Cases",
-				Order:    2,
-				Schema:   core.SchemaTestCases,
-				Required: true,
-			},
-			"results": {
-				Title:    "## Test Results Log",
-				Order:    3,
-				Schema:   core.SchemaResults,
-				Required: false,
-			},
-		},
-	}
-
-	mockManager.ReadTodoFunc = func(id string) (*core.Todo, error) {
-		if id == "test-todo-validation" {
-			return testTodo, nil
-		}
-		return nil, nil
-	}
-
-	mockManager.UpdateTodoFunc = func(id, section, operation, content string, metadata map[string]string) error {
-		// The real UpdateTodo would validate against the hardcoded section map
-		// For now, just accept the section
-		return nil
-	}
-
-	mockSearch := &MockSearchEngine{}
-	mockStats := &MockStatsEngine{}
-	mockTemplates := &MockTemplateManager{}
-
-	// Create handlers with mocks
-	handlers := NewTodoHandlersWithDependencies(
-		mockManager,
-		mockSearch,
-		mockStats,
-		mockTemplates,
-	)
+	handlers, todo := newStrictSchemaHandlers(t, "Test todo with validation")
 
 	// Test cases
 	tests := []struct {
@@ -79,7 +25,7 @@ func TestUpdateSectionWithSchemaValidation(t *testing.T) {
 			name: "valid checklist content",
 			request: &MockCallToolRequest{
 				Arguments: map[string]interface{}{
-					"id":        "test-todo-validation",
+					"id":        todo.ID,
 					"section":   "checklist",
 					"operation": "append",
 					"content":   "\n- [ ] New task\n- [x] Completed task",
@@ -91,7 +37,7 @@ func TestUpdateSectionWithSchemaValidation(t *testing.T) {
 			name: "invalid checklist content",
 			request: &MockCallToolRequest{
 				Arguments: map[string]interface{}{
-					"id":        "test-todo-validation",
+					"id":        todo.ID,
 					"section":   "checklist",
 					"operation": "append",
 					"content":   "\nThis is not a checklist item",
@@ -104,7 +50,7 @@ func TestUpdateSectionWithSchemaValidation(t *testing.T) {
 			name: "invalid checkbox syntax",
 			request: &MockCallToolRequest{
 				Arguments: map[string]interface{}{
-					"id":        "test-todo-validation",
+					"id":        todo.ID,
 					"section":   "checklist",
 					"operation": "append",
 					"content":   "\n- [] Missing space in checkbox",
@@ -154,62 +100,7 @@ func TestUpdateSectionWithSchemaValidation(t *testing.T) {
 
 // Test 12: Reject update that violates schema
 func TestRejectUpdateThatViolatesSchema(t *testing.T) {
-	t.Skip("Skipping test - schema validation not implemented in UpdateTodo")
-	// Create mock managers
-	mockManager := NewMockTodoManager()
-
-	// Setup todo with various section schemas
-	testTodo := &core.Todo{
-		ID:       "test-todo-strict",
-		Task:     "Test todo with strict validation",
-		Status:   "in_progress",
-		Priority: "high",
-		Type:     "feature",
-		Sections: map[string]*core.SectionDefinition{
-			"tests": {
-				Title:    "## Test Cases",
-				Order:    1,
-				Schema:   core.SchemaTestCases,
-				Required: true,
-			},
-			"findings": {
-				Title:    "## Findings & Research",
-				Order:    2,
-				Schema:   core.SchemaResearch,
-				Required: false,
-			},
-			"checklist": {
-				Title:    "## Checklist",
-				Order:    3,
-				Schema:   core.SchemaChecklist,
-				Required: true,
-			},
-		},
-	}
-
-	mockManager.ReadTodoFunc = func(id string) (*core.Todo, error) {
-		if id == "test-todo-strict" {
-			return testTodo, nil
-		}
-		return nil, fmt.Errorf("todo not found: %s", id)
-	}
-
-	mockManager.UpdateTodoFunc = func(id, section, operation, content string, metadata map[string]string) error {
-		// Mock accepts the update since validation happens in handler
-		return nil
-	}
-
-	mockSearch := &MockSearchEngine{}
-	mockStats := &MockStatsEngine{}
-	mockTemplates := &MockTemplateManager{}
-
-	// Create handlers with mocks
-	handlers := NewTodoHandlersWithDependencies(
-		mockManager,
-		mockSearch,
-		mockStats,
-		mockTemplates,
-	)
+	handlers, todo := newStrictSchemaHandlers(t, "Test todo with strict validation")
 
 	// Test cases for different schema violations
 	tests := []struct {
@@ -221,7 +112,7 @@ func TestRejectUpdateThatViolatesSchema(t *testing.T) {
 			name: "tests section without code blocks",
 			request: &MockCallToolRequest{
 				Arguments: map[string]interface{}{
-					"id":        "test-todo-strict",
+					"id":        todo.ID,
 					"section":   "tests",
 					"operation": "replace",
 					"content":   "Just some plain text without any code blocks",
@@ -233,7 +124,7 @@ func TestRejectUpdateThatViolatesSchema(t *testing.T) {
 			name: "findings section validation (research allows any text)",
 			request: &MockCallToolRequest{
 				Arguments: map[string]interface{}{
-					"id":        "test-todo-strict",
+					"id":        todo.ID,
 					"section":   "findings",
 					"operation": "append",
 					"content":   "\nAny text is valid for research sections",
@@ -245,7 +136,7 @@ func TestRejectUpdateThatViolatesSchema(t *testing.T) {
 			name: "checklist with mixed content",
 			request: &MockCallToolRequest{
 				Arguments: map[string]interface{}{
-					"id":        "test-todo-strict",
+					"id":        todo.ID,
 					"section":   "checklist",
 					"operation": "append",
 					"content":   "\n- [x] Valid checkbox\nSome random text\n- [ ] Another checkbox",
@@ -257,7 +148,7 @@ func TestRejectUpdateThatViolatesSchema(t *testing.T) {
 			name: "checklist with malformed checkbox",
 			request: &MockCallToolRequest{
 				Arguments: map[string]interface{}{
-					"id":        "test-todo-strict",
+					"id":        todo.ID,
 					"section":   "checklist",
 					"operation": "append",
 					"content":   "\n- [] Missing space in checkbox",
@@ -306,3 +197,52 @@ func TestRejectUpdateThatViolatesSchema(t *testing.T) {
 		})
 	}
 }
+
+// newStrictSchemaHandlers returns handlers over a real manager whose project
+// is in strict mode, so schema violations are refused, and a todo with the
+// default sections
+func newStrictSchemaHandlers(t *testing.T, task string) (*TodoHandlers, *core.Todo) {
+	t.Helper()
+	basePath := t.TempDir()
+	manager := core.NewTodoManager(basePath)
+	todo, err := manager.CreateTodo(task, "high", "feature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(basePath, ".claude", core.ProjectSettingsFile), []byte("strict: true\n"), 0644)
+	return NewTodoHandlersWithDependencies(manager, nil, NewMockStatsEngine(), NewMockTemplateManager()), todo
+}
+
+func TestUpdateSectionSchemaWarningsAndStrictMode(t *testing.T) {
+	basePath := t.TempDir()
+	manager := core.NewTodoManager(basePath)
+	h := NewTodoHandlersWithDependencies(manager, nil, NewMockStatsEngine(), NewMockTemplateManager())
+	todo, _ := manager.CreateTodo("Add rate limiting", "high", "feature")
+
+	text := callText(t, h.HandleTodoUpdate, map[string]interface{}{
+		"id": todo.ID, "section": "checklist", "content": "Check nginx config",
+	})
+	if !strings.HasPrefix(text, "Todo 'add-rate-limiting' checklist section append\n\nSaved, but the content doesn't match the section schema:\n"+
+		`- checklist (checklist): non-checklist content found: "Check nginx config"`) {
+		t.Errorf("Expected a schema warning, got: %s", text)
+	}
+
+	os.WriteFile(filepath.Join(basePath, ".claude", core.ProjectSettingsFile), []byte("strict: true\n"), 0644)
+	text = callText(t, h.HandleTodoUpdate, map[string]interface{}{
+		"id": todo.ID, "section": "tests", "operation": "replace", "content": "limits per API key",
+	})
+	if !strings.Contains(text, "content doesn't match the test_cases schema: no code blocks found") {
+		t.Errorf("Expected strict mode to reject the content, got: %s", text)
+	}
+
+	manager.UpdateTodoFrontmatter(todo.ID, func(todo *core.Todo) error {
+		todo.Sections["findings"].Required = true
+		return nil
+	})
+	text = callText(t, h.HandleTodoUpdate, map[string]interface{}{
+		"id": todo.ID, "metadata": map[string]interface{}{"status": "completed"},
+	})
+	if !strings.Contains(text, "required sections are still empty: findings") {
+		t.Errorf("Expected completion to be refused, got: %s", text)
+	}
+}
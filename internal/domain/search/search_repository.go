@@ -1,6 +1,8 @@
 package search
 
 import (
+	"time"
+
 	"github.com/user/mcp-todo-server/internal/domain"
 )
 
@@ -10,6 +12,75 @@ type Result struct {
 	Task    string
 	Score   float64
 	Snippet string
+
+	Archived    bool
+	ArchivePath string // relative to the project, set for archived todos
+
+	Highlights []Highlight
+}
+
+// Highlight is a fragment of a todo with the matched terms marked
+type Highlight struct {
+	Field    string // index field the fragment came from
+	Section  string // todo section heading, e.g. "Findings & Research"
+	Fragment string
+}
+
+// Response is a page of results together with counts over every match
+type Response struct {
+	Results []Result
+	Total   uint64 // matches before the limit was applied
+	Facets  []Facet
+}
+
+// Facet counts matching todos by the values of one field
+type Facet struct {
+	Name    string // status, type, priority, tags or month
+	Missing int    // matches without a value for the field
+	Values  []FacetValue
+}
+
+// FacetValue is one value of a facet and how many matches carry it
+type FacetValue struct {
+	Value string
+	Count int
+}
+
+// IndexHealth compares the index with the todo files on disk
+type IndexHealth struct {
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"` // why the index could not be read
+
+	DocCount      uint64 `json:"docCount"` // documents in the index
+	Files         int    `json:"files"`    // todos on disk, active and archived
+	ArchivedFiles int    `json:"archivedFiles"`
+	Indexed       int    `json:"indexed"` // files with an up-to-date index entry
+
+	Missing  []string `json:"missing"`  // files on disk that were never indexed
+	Outdated []string `json:"outdated"` // files changed on disk since they were indexed
+	Orphaned []string `json:"orphaned"` // index entries whose file is gone
+
+	CircuitBreaker  string `json:"circuitBreaker"` // closed, open or half-open
+	CircuitFailures int    `json:"circuitFailures"`
+
+	LastReindex *ReindexStatus `json:"lastReindex,omitempty"` // nil until a reindex has been started
+}
+
+// Stale counts index entries that no longer match the files on disk
+func (h IndexHealth) Stale() int {
+	return len(h.Outdated) + len(h.Orphaned)
+}
+
+// ReindexStatus tracks a background reindex
+type ReindexStatus struct {
+	Mode       string    `json:"mode"` // full, incremental or rebuild
+	Running    bool      `json:"running"`
+	Total      int       `json:"total"` // files to index
+	Done       int       `json:"done"`
+	Deleted    int       `json:"deleted"` // orphaned entries removed
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	Error      string    `json:"error,omitempty"`
 }
 
 // Repository defines the interface for search operations
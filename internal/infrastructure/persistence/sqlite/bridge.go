@@ -0,0 +1,195 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ImportMarkdown loads every todo under claudeDir/todos and claudeDir/archive
+// into the database, replacing rows with the same ID. Frontmatter and body
+// are stored verbatim, so ExportMarkdown reproduces the files byte for byte.
+// Files that aren't valid todos are skipped with a warning. It returns the
+// number of todos imported.
+func (r *TodoRepository) ImportMarkdown(ctx context.Context, claudeDir string) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	imported := 0
+	for _, tree := range []string{"todos", "archive"} {
+		root := filepath.Join(claudeDir, tree)
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && path == root {
+					return filepath.SkipDir
+				}
+				return err
+			}
+			if info.IsDir() || !strings.HasSuffix(info.Name(), ".md") {
+				return nil
+			}
+
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			// One broken file shouldn't block the rest of the project
+			frontmatter, body, err := splitContent(string(content))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping %s: %v\n", path, err)
+				return nil
+			}
+
+			todo, err := parseFrontmatter(frontmatter, taskFromBody(body))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping %s: %v\n", path, err)
+				return nil
+			}
+			if todo.ID == "" {
+				todo.ID = strings.TrimSuffix(info.Name(), ".md")
+			}
+
+			rel, err := filepath.Rel(claudeDir, path)
+			if err != nil {
+				return err
+			}
+			row := &todoRow{
+				todo:        todo,
+				archived:    tree == "archive",
+				path:        filepath.ToSlash(rel),
+				frontmatter: frontmatter,
+				body:        body,
+			}
+			if err := upsertRow(ctx, tx, row); err != nil {
+				return err
+			}
+			imported++
+			return nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to import %s: %w", tree, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit import: %w", err)
+	}
+	return imported, nil
+}
+
+// ExportMarkdown writes every todo back to the markdown tree under
+// claudeDir, at the path it was imported from or archived to. Todos created
+// in the database are placed in the daily folder of their start date, and
+// active copies of archived todos are removed. It returns the number of
+// todos exported.
+func (r *TodoRepository) ExportMarkdown(ctx context.Context, claudeDir string) (int, error) {
+	active, err := activeTodoFiles(filepath.Join(claudeDir, "todos"))
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT id, started, archived, path, frontmatter, body FROM todos ORDER BY id")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read todos: %w", err)
+	}
+	defer rows.Close()
+
+	exported := 0
+	for rows.Next() {
+		var id, path, frontmatter, body string
+		var started int64
+		var archived bool
+		if err := rows.Scan(&id, &started, &archived, &path, &frontmatter, &body); err != nil {
+			return exported, fmt.Errorf("failed to read todo row: %w", err)
+		}
+
+		if path == "" {
+			tree := "todos"
+			if archived {
+				tree = "archive"
+			}
+			path = filepath.Join(tree, dailyPath(started), id+".md")
+		}
+
+		target := filepath.Join(claudeDir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return exported, fmt.Errorf("failed to create directory for %s: %w", id, err)
+		}
+		if err := ioutil.WriteFile(target, []byte(joinContent(frontmatter, body)), 0644); err != nil {
+			return exported, fmt.Errorf("failed to write %s: %w", id, err)
+		}
+		if archived {
+			for _, stale := range active[id] {
+				if err := os.Remove(stale); err != nil && !os.IsNotExist(err) {
+					return exported, fmt.Errorf("failed to remove active copy of %s: %w", id, err)
+				}
+			}
+		}
+		exported++
+	}
+	return exported, rows.Err()
+}
+
+// activeTodoFiles maps todo IDs to their files under the active tree
+func activeTodoFiles(root string) (map[string][]string, error) {
+	files := make(map[string][]string)
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !info.IsDir() && strings.HasSuffix(info.Name(), ".md") {
+			id := strings.TrimSuffix(info.Name(), ".md")
+			files[id] = append(files[id], path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan active todos: %w", err)
+	}
+	return files, nil
+}
+
+// splitContent separates a todo file into its frontmatter (without the ---
+// fences) and the markdown body that follows
+func splitContent(content string) (string, string, error) {
+	if !strings.HasPrefix(content, "---\n") {
+		return "", "", fmt.Errorf("no frontmatter found")
+	}
+	rest := content[len("---\n"):]
+
+	end := strings.Index(rest, "\n---\n")
+	if end == -1 {
+		if strings.HasSuffix(rest, "\n---") {
+			return rest[:len(rest)-len("---")], "", nil
+		}
+		return "", "", fmt.Errorf("unterminated frontmatter")
+	}
+	return rest[:end+1], rest[end+len("\n---\n"):], nil
+}
+
+// taskFromBody returns the task from the "# Task:" heading
+func taskFromBody(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "# ") {
+			return strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(line, "# "), "Task:"))
+		}
+	}
+	return ""
+}
+
+// dailyPath mirrors core.GetDailyPath for a stored start time
+func dailyPath(started int64) string {
+	return time.Unix(0, started).Format("2006/01/02")
+}
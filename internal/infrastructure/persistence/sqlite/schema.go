@@ -0,0 +1,34 @@
+package sqlite
+
+// schemaVersion is bumped whenever the statements below change shape
+const schemaVersion = 1
+
+// schema creates the todo tables. Frontmatter fields that are filtered or
+// aggregated on get their own indexed columns; the full frontmatter and
+// markdown body are kept verbatim so exports round-trip without loss.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS todos (
+		id          TEXT PRIMARY KEY,
+		task        TEXT NOT NULL,
+		status      TEXT NOT NULL,
+		priority    TEXT NOT NULL DEFAULT '',
+		type        TEXT NOT NULL DEFAULT '',
+		parent_id   TEXT NOT NULL DEFAULT '',
+		started     INTEGER NOT NULL DEFAULT 0,
+		completed   INTEGER NOT NULL DEFAULT 0,
+		archived    INTEGER NOT NULL DEFAULT 0,
+		path        TEXT NOT NULL DEFAULT '',
+		frontmatter TEXT NOT NULL,
+		body        TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_todos_status ON todos(archived, status)`,
+	`CREATE INDEX IF NOT EXISTS idx_todos_priority ON todos(archived, priority)`,
+	`CREATE INDEX IF NOT EXISTS idx_todos_started ON todos(archived, started)`,
+	`CREATE INDEX IF NOT EXISTS idx_todos_parent ON todos(parent_id)`,
+	`CREATE TABLE IF NOT EXISTS todo_tags (
+		todo_id TEXT NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+		tag     TEXT NOT NULL,
+		PRIMARY KEY (todo_id, tag)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_todo_tags_tag ON todo_tags(tag)`,
+}
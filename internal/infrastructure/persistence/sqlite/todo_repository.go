@@ -0,0 +1,501 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/user/mcp-todo-server/internal/domain"
+	"github.com/user/mcp-todo-server/internal/domain/repository"
+	"gopkg.in/yaml.v3"
+
+	// Pure-Go SQLite driver, registers as "sqlite"
+	_ "modernc.org/sqlite"
+)
+
+// TodoRepository implements the repository interface on top of SQLite
+type TodoRepository struct {
+	db *sql.DB
+}
+
+var _ repository.TodoRepository = (*TodoRepository)(nil)
+
+// NewTodoRepository opens (or creates) the database at dbPath and makes
+// sure the schema is in place
+func NewTodoRepository(dbPath string) (*TodoRepository, error) {
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	// WAL lets readers proceed while a write is in flight; the busy timeout
+	// covers the short window where two writers collide
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)", dbPath)
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// SQLite allows a single writer; one connection avoids SQLITE_BUSY churn
+	db.SetMaxOpenConns(1)
+
+	repo := &TodoRepository{db: db}
+	if err := repo.migrate(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return repo, nil
+}
+
+// Close closes the database
+func (r *TodoRepository) Close() error {
+	return r.db.Close()
+}
+
+// migrate creates the schema and records its version
+func (r *TodoRepository) migrate(ctx context.Context) error {
+	var version int
+	if err := r.db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if version > schemaVersion {
+		return fmt.Errorf("database schema version %d is newer than supported version %d", version, schemaVersion)
+	}
+
+	for _, stmt := range schema {
+		if _, err := r.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to apply schema: %w", err)
+		}
+	}
+
+	if _, err := r.db.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", schemaVersion)); err != nil {
+		return fmt.Errorf("failed to record schema version: %w", err)
+	}
+	return nil
+}
+
+// Save creates or updates a todo. Frontmatter keys the domain model doesn't
+// know about (due dates, links, ...) are kept when updating.
+func (r *TodoRepository) Save(ctx context.Context, todo *domain.Todo) error {
+	if err := todo.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var existing, body string
+	err = tx.QueryRowContext(ctx, "SELECT frontmatter, body FROM todos WHERE id = ?", todo.ID).Scan(&existing, &body)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		body = fmt.Sprintf("\n# Task: %s\n", todo.Task)
+	case err != nil:
+		return fmt.Errorf("failed to load todo: %w", err)
+	default:
+		body = setTaskHeading(body, todo.Task)
+	}
+
+	frontmatter, err := mergeFrontmatter(existing, todo)
+	if err != nil {
+		return err
+	}
+
+	row := &todoRow{
+		todo:        todo,
+		frontmatter: frontmatter,
+		body:        body,
+	}
+	if err := upsertRow(ctx, tx, row); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FindByID retrieves a todo by its ID
+func (r *TodoRepository) FindByID(ctx context.Context, id string) (*domain.Todo, error) {
+	todo, _, err := r.FindByIDWithContent(ctx, id)
+	return todo, err
+}
+
+// FindByIDWithContent retrieves a todo and its full markdown content
+func (r *TodoRepository) FindByIDWithContent(ctx context.Context, id string) (*domain.Todo, string, error) {
+	var task, frontmatter, body string
+	err := r.db.QueryRowContext(ctx,
+		"SELECT task, frontmatter, body FROM todos WHERE id = ?", id).Scan(&task, &frontmatter, &body)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, "", domain.ErrTodoNotFound
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load todo: %w", err)
+	}
+
+	todo, err := parseFrontmatter(frontmatter, task)
+	if err != nil {
+		return nil, "", err
+	}
+	return todo, joinContent(frontmatter, body), nil
+}
+
+// List retrieves active (non-archived) todos matching the filters, newest first
+func (r *TodoRepository) List(ctx context.Context, filters repository.ListFilters) ([]*domain.Todo, error) {
+	query := "SELECT task, frontmatter FROM todos WHERE archived = 0"
+	var args []interface{}
+
+	if filters.Status != "" {
+		query += " AND status = ?"
+		args = append(args, filters.Status)
+	}
+	if filters.Priority != "" {
+		query += " AND priority = ?"
+		args = append(args, filters.Priority)
+	}
+	if filters.Days > 0 {
+		query += " AND started >= ?"
+		args = append(args, time.Now().AddDate(0, 0, -filters.Days).UnixNano())
+	}
+	if filters.ParentID != "" {
+		query += " AND parent_id = ?"
+		args = append(args, filters.ParentID)
+	}
+	query += " ORDER BY started DESC, id"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list todos: %w", err)
+	}
+	defer rows.Close()
+
+	var todos []*domain.Todo
+	for rows.Next() {
+		var task, frontmatter string
+		if err := rows.Scan(&task, &frontmatter); err != nil {
+			return nil, fmt.Errorf("failed to read todo row: %w", err)
+		}
+		todo, err := parseFrontmatter(frontmatter, task)
+		if err != nil {
+			// Skip rows with frontmatter that no longer parses
+			continue
+		}
+		todos = append(todos, todo)
+	}
+	return todos, rows.Err()
+}
+
+// FindChildren returns the todos whose parent is parentID. Archived children
+// are only included when includeArchived is set.
+func (r *TodoRepository) FindChildren(ctx context.Context, parentID string, includeArchived bool) ([]*domain.Todo, error) {
+	query := "SELECT task, frontmatter FROM todos WHERE parent_id = ?"
+	if !includeArchived {
+		query += " AND archived = 0"
+	}
+	query += " ORDER BY started, id"
+
+	rows, err := r.db.QueryContext(ctx, query, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find children: %w", err)
+	}
+	defer rows.Close()
+
+	var children []*domain.Todo
+	for rows.Next() {
+		var task, frontmatter string
+		if err := rows.Scan(&task, &frontmatter); err != nil {
+			return nil, fmt.Errorf("failed to read todo row: %w", err)
+		}
+		child, err := parseFrontmatter(frontmatter, task)
+		if err != nil {
+			continue
+		}
+		children = append(children, child)
+	}
+	return children, rows.Err()
+}
+
+// Delete removes a todo
+func (r *TodoRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM todos WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete todo: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return domain.ErrTodoNotFound
+	}
+	return nil
+}
+
+// Archive marks a todo as archived. archivePath is the date folder used by
+// the markdown tree (e.g. 2026/10/16) and is kept so exports land there.
+func (r *TodoRepository) Archive(ctx context.Context, id string, archivePath string) error {
+	path := filepath.ToSlash(filepath.Join("archive", archivePath, id+".md"))
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE todos SET archived = 1, path = ? WHERE id = ?", path, id)
+	if err != nil {
+		return fmt.Errorf("failed to archive todo: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return domain.ErrTodoNotFound
+	}
+	return nil
+}
+
+// UpdateContent replaces the content under a section heading. section may be
+// a section key from the frontmatter or the heading text itself.
+func (r *TodoRepository) UpdateContent(ctx context.Context, id string, section string, content string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var task, frontmatter, body string
+	err = tx.QueryRowContext(ctx,
+		"SELECT task, frontmatter, body FROM todos WHERE id = ?", id).Scan(&task, &frontmatter, &body)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrTodoNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load todo: %w", err)
+	}
+
+	heading := section
+	if todo, err := parseFrontmatter(frontmatter, task); err == nil {
+		if def, ok := todo.Sections[section]; ok && def != nil && def.Title != "" {
+			heading = def.Title
+		}
+	}
+
+	updated, err := replaceSection(body, heading, content)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE todos SET body = ? WHERE id = ?", updated, id); err != nil {
+		return fmt.Errorf("failed to update content: %w", err)
+	}
+	return tx.Commit()
+}
+
+// GetContent retrieves the full markdown content of a todo
+func (r *TodoRepository) GetContent(ctx context.Context, id string) (string, error) {
+	_, content, err := r.FindByIDWithContent(ctx, id)
+	return content, err
+}
+
+// todoRow is a todo as stored in the todos table
+type todoRow struct {
+	todo        *domain.Todo
+	archived    bool
+	path        string
+	frontmatter string
+	body        string
+}
+
+// upsertRow writes a todo row and its tags. An empty path keeps the
+// existing one, so re-saving a todo doesn't forget where it was exported.
+func upsertRow(ctx context.Context, tx *sql.Tx, row *todoRow) error {
+	todo := row.todo
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO todos (id, task, status, priority, type, parent_id, started, completed, archived, path, frontmatter, body)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			task = excluded.task,
+			status = excluded.status,
+			priority = excluded.priority,
+			type = excluded.type,
+			parent_id = excluded.parent_id,
+			started = excluded.started,
+			completed = excluded.completed,
+			archived = CASE WHEN excluded.path = '' THEN todos.archived ELSE excluded.archived END,
+			path = CASE WHEN excluded.path = '' THEN todos.path ELSE excluded.path END,
+			frontmatter = excluded.frontmatter,
+			body = excluded.body`,
+		todo.ID, todo.Task, todo.Status, todo.Priority, todo.Type, todo.ParentID,
+		unixNano(todo.Started), unixNano(todo.Completed), row.archived, row.path,
+		row.frontmatter, row.body)
+	if err != nil {
+		return fmt.Errorf("failed to save todo %s: %w", todo.ID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM todo_tags WHERE todo_id = ?", todo.ID); err != nil {
+		return fmt.Errorf("failed to clear tags: %w", err)
+	}
+	for _, tag := range todo.Tags {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT OR IGNORE INTO todo_tags (todo_id, tag) VALUES (?, ?)", todo.ID, strings.ToLower(tag)); err != nil {
+			return fmt.Errorf("failed to save tag: %w", err)
+		}
+	}
+	return nil
+}
+
+// todoYAML holds the frontmatter keys the domain model maps to
+type todoYAML struct {
+	ID        string                               `yaml:"todo_id"`
+	Started   time.Time                            `yaml:"started"`
+	Completed time.Time                            `yaml:"completed,omitempty"`
+	Status    string                               `yaml:"status"`
+	Priority  string                               `yaml:"priority"`
+	Type      string                               `yaml:"type"`
+	ParentID  string                               `yaml:"parent_id,omitempty"`
+	Tags      []string                             `yaml:"tags,omitempty"`
+	Sections  map[string]*domain.SectionDefinition `yaml:"sections,omitempty"`
+}
+
+// managedKeys are the frontmatter keys owned by the domain model
+var managedKeys = map[string]bool{
+	"todo_id": true, "started": true, "completed": true, "status": true, "priority": true,
+	"type": true, "parent_id": true, "tags": true, "sections": true,
+}
+
+// parseFrontmatter converts stored frontmatter into the domain model
+func parseFrontmatter(frontmatter, task string) (*domain.Todo, error) {
+	var fm todoYAML
+	if err := yaml.Unmarshal([]byte(frontmatter), &fm); err != nil {
+		return nil, fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+	return &domain.Todo{
+		ID:        fm.ID,
+		Task:      task,
+		Started:   fm.Started,
+		Completed: fm.Completed,
+		Status:    fm.Status,
+		Priority:  fm.Priority,
+		Type:      fm.Type,
+		ParentID:  fm.ParentID,
+		Tags:      fm.Tags,
+		Sections:  fm.Sections,
+	}, nil
+}
+
+// mergeFrontmatter writes the domain fields into existing frontmatter,
+// keeping key order and any keys the domain model doesn't manage
+func mergeFrontmatter(existing string, todo *domain.Todo) (string, error) {
+	updated, err := yaml.Marshal(todoYAML{
+		ID:        todo.ID,
+		Started:   todo.Started,
+		Completed: todo.Completed,
+		Status:    todo.Status,
+		Priority:  todo.Priority,
+		Type:      todo.Type,
+		ParentID:  todo.ParentID,
+		Tags:      todo.Tags,
+		Sections:  todo.Sections,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal frontmatter: %w", err)
+	}
+	if strings.TrimSpace(existing) == "" {
+		return string(updated), nil
+	}
+
+	var current, changes yaml.Node
+	if err := yaml.Unmarshal([]byte(existing), &current); err != nil {
+		return "", fmt.Errorf("failed to parse existing frontmatter: %w", err)
+	}
+	if err := yaml.Unmarshal(updated, &changes); err != nil {
+		return "", fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+	if len(current.Content) == 0 || current.Content[0].Kind != yaml.MappingNode {
+		return string(updated), nil
+	}
+	currentMap, changesMap := current.Content[0], changes.Content[0]
+
+	values := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(changesMap.Content); i += 2 {
+		values[changesMap.Content[i].Value] = changesMap.Content[i+1]
+	}
+
+	// Update managed keys in place and drop ones that are now empty
+	merged := make([]*yaml.Node, 0, len(currentMap.Content))
+	for i := 0; i+1 < len(currentMap.Content); i += 2 {
+		key := currentMap.Content[i].Value
+		if !managedKeys[key] {
+			merged = append(merged, currentMap.Content[i], currentMap.Content[i+1])
+			continue
+		}
+		if value, ok := values[key]; ok {
+			merged = append(merged, currentMap.Content[i], value)
+			delete(values, key)
+		}
+	}
+	// Newly set keys go after the existing ones, in model order
+	for i := 0; i+1 < len(changesMap.Content); i += 2 {
+		if _, pending := values[changesMap.Content[i].Value]; pending {
+			merged = append(merged, changesMap.Content[i], changesMap.Content[i+1])
+		}
+	}
+	currentMap.Content = merged
+
+	out, err := yaml.Marshal(&current)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal frontmatter: %w", err)
+	}
+	return string(out), nil
+}
+
+// replaceSection replaces everything between a "## heading" line and the
+// next "## " heading
+func replaceSection(body, heading, content string) (string, error) {
+	target := "## " + strings.TrimSpace(strings.TrimPrefix(heading, "## "))
+	lines := strings.Split(body, "\n")
+
+	start := -1
+	for i, line := range lines {
+		if strings.EqualFold(strings.TrimSpace(line), target) {
+			start = i
+			break
+		}
+	}
+	if start == -1 {
+		return "", fmt.Errorf("section %q not found", heading)
+	}
+
+	end := len(lines)
+	for i := start + 1; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), "## ") {
+			end = i
+			break
+		}
+	}
+
+	replacement := []string{lines[start], ""}
+	if content = strings.TrimSpace(content); content != "" {
+		replacement = append(replacement, content, "")
+	}
+
+	result := append(append(append([]string{}, lines[:start]...), replacement...), lines[end:]...)
+	return strings.Join(result, "\n"), nil
+}
+
+// setTaskHeading points the body's "# Task:" heading at task, adding the
+// heading when the body has none
+func setTaskHeading(body, task string) string {
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "# ") {
+			lines[i] = "# Task: " + task
+			return strings.Join(lines, "\n")
+		}
+	}
+	return fmt.Sprintf("\n# Task: %s\n", task) + body
+}
+
+// joinContent rebuilds the markdown file from its stored parts
+func joinContent(frontmatter, body string) string {
+	return "---\n" + frontmatter + "---\n" + body
+}
+
+// unixNano stores zero times as 0 rather than the far-past UnixNano value
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
@@ -0,0 +1,232 @@
+package sqlite
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/user/mcp-todo-server/internal/domain"
+	"github.com/user/mcp-todo-server/internal/domain/repository"
+)
+
+func newTestRepository(t *testing.T) *TodoRepository {
+	t.Helper()
+	repo, err := NewTodoRepository(filepath.Join(t.TempDir(), "todos.db"))
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestRepository_SaveAndList(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	todos := []*domain.Todo{
+		{ID: "api-project", Task: "Build API", Status: "in_progress", Priority: "high", Type: "multi-phase", Started: now.Add(-72 * time.Hour), Tags: []string{"backend"}},
+		{ID: "design-api", Task: "Design API", Status: "completed", Priority: "medium", Type: "phase", ParentID: "api-project", Started: now.Add(-48 * time.Hour), Completed: now.Add(-24 * time.Hour), Tags: []string{"backend", "design"}},
+		{ID: "implement-api", Task: "Implement API", Status: "in_progress", Priority: "high", Type: "phase", ParentID: "api-project", Started: now.Add(-time.Hour)},
+		{ID: "old-bug", Task: "Old bug", Status: "completed", Priority: "low", Type: "bug", Started: now.AddDate(0, 0, -30), Completed: now.AddDate(0, 0, -29)},
+	}
+	for _, todo := range todos {
+		if err := repo.Save(ctx, todo); err != nil {
+			t.Fatalf("Failed to save %s: %v", todo.ID, err)
+		}
+	}
+
+	got, err := repo.FindByID(ctx, "design-api")
+	if err != nil {
+		t.Fatalf("Failed to find todo: %v", err)
+	}
+	if got.Task != "Design API" || got.ParentID != "api-project" || len(got.Tags) != 2 {
+		t.Errorf("Unexpected todo: %+v", got)
+	}
+
+	tests := []struct {
+		name    string
+		filters repository.ListFilters
+		want    int
+	}{
+		{"all", repository.ListFilters{}, 4},
+		{"status", repository.ListFilters{Status: "completed"}, 2},
+		{"priority", repository.ListFilters{Priority: "high"}, 2},
+		{"days", repository.ListFilters{Days: 7}, 3},
+		{"parent", repository.ListFilters{ParentID: "api-project"}, 2},
+	}
+	for _, tt := range tests {
+		list, err := repo.List(ctx, tt.filters)
+		if err != nil {
+			t.Fatalf("%s: list failed: %v", tt.name, err)
+		}
+		if len(list) != tt.want {
+			t.Errorf("%s: expected %d todos, got %d", tt.name, tt.want, len(list))
+		}
+	}
+
+	if err := repo.Archive(ctx, "old-bug", "2026/09/16"); err != nil {
+		t.Fatalf("Failed to archive: %v", err)
+	}
+	if list, _ := repo.List(ctx, repository.ListFilters{}); len(list) != 3 {
+		t.Errorf("Archived todos should not be listed, got %d", len(list))
+	}
+
+	children, err := repo.FindChildren(ctx, "api-project", false)
+	if err != nil || len(children) != 2 || children[0].ID != "design-api" {
+		t.Errorf("Unexpected children: %v (err %v)", children, err)
+	}
+
+	if err := repo.Delete(ctx, "implement-api"); err != nil {
+		t.Fatalf("Failed to delete: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, "implement-api"); err != domain.ErrTodoNotFound {
+		t.Errorf("Expected ErrTodoNotFound, got %v", err)
+	}
+}
+
+const sampleTodo = `---
+todo_id: fix-login
+started: 2026-10-14T09:30:00+02:00
+status: in_progress
+priority: high
+type: bug
+tags:
+    - auth
+due: 2026-10-20T00:00:00+02:00
+blocked_by:
+    - design-session-store
+---
+
+# Task: Fix login timeout
+
+## Findings & Research
+
+Sessions expire after 5 minutes.
+
+## Checklist
+
+- [x] Reproduce
+- [ ] Fix
+`
+
+func TestRepository_ImportExportRoundTrip(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	source := t.TempDir()
+	files := map[string]string{
+		"todos/2026/10/14/fix-login.md":  sampleTodo,
+		"archive/2026/09/01/old-task.md": strings.Replace(strings.Replace(sampleTodo, "fix-login", "old-task", 1), "in_progress", "completed", 1),
+	}
+	for path, content := range files {
+		full := filepath.Join(source, path)
+		os.MkdirAll(filepath.Dir(full), 0755)
+		if err := ioutil.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	imported, err := repo.ImportMarkdown(ctx, source)
+	if err != nil || imported != 2 {
+		t.Fatalf("Expected 2 imported todos, got %d (err %v)", imported, err)
+	}
+
+	todo, err := repo.FindByID(ctx, "fix-login")
+	if err != nil {
+		t.Fatalf("Failed to find imported todo: %v", err)
+	}
+	if todo.Task != "Fix login timeout" || todo.Priority != "high" {
+		t.Errorf("Unexpected imported todo: %+v", todo)
+	}
+	if list, _ := repo.List(ctx, repository.ListFilters{}); len(list) != 1 {
+		t.Errorf("Expected only the active todo to be listed, got %d", len(list))
+	}
+
+	// Updating through the domain model keeps frontmatter it doesn't know about
+	todo.Priority = "medium"
+	if err := repo.Save(ctx, todo); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+	if err := repo.UpdateContent(ctx, "fix-login", "Findings & Research", "Token refresh is skipped."); err != nil {
+		t.Fatalf("Failed to update content: %v", err)
+	}
+
+	target := t.TempDir()
+	exported, err := repo.ExportMarkdown(ctx, target)
+	if err != nil || exported != 2 {
+		t.Fatalf("Expected 2 exported todos, got %d (err %v)", exported, err)
+	}
+
+	archived, err := ioutil.ReadFile(filepath.Join(target, "archive/2026/09/01/old-task.md"))
+	if err != nil {
+		t.Fatalf("Archived todo not exported: %v", err)
+	}
+	if string(archived) != files["archive/2026/09/01/old-task.md"] {
+		t.Errorf("Untouched todo should round-trip unchanged, got:\n%s", archived)
+	}
+
+	updated, err := ioutil.ReadFile(filepath.Join(target, "todos/2026/10/14/fix-login.md"))
+	if err != nil {
+		t.Fatalf("Active todo not exported: %v", err)
+	}
+	for _, want := range []string{"priority: medium", "due: 2026-10-20", "blocked_by:", "Token refresh is skipped.", "- [x] Reproduce"} {
+		if !strings.Contains(string(updated), want) {
+			t.Errorf("Exported todo should contain %q, got:\n%s", want, updated)
+		}
+	}
+	if strings.Contains(string(updated), "5 minutes") {
+		t.Errorf("Replaced section content should be gone, got:\n%s", updated)
+	}
+}
+
+func TestRepository_BridgeKeepsTreeConsistent(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"todos/2026/10/14/fix-login.md": sampleTodo,
+		"todos/2026/10/14/broken.md":    "# Task: no frontmatter\n",
+	}
+	for path, content := range files {
+		full := filepath.Join(dir, path)
+		os.MkdirAll(filepath.Dir(full), 0755)
+		if err := ioutil.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	imported, err := repo.ImportMarkdown(ctx, dir)
+	if err != nil || imported != 1 {
+		t.Fatalf("Expected the malformed file to be skipped, got %d imported (err %v)", imported, err)
+	}
+
+	// A rename rewrites the heading, so exports keep it
+	todo, _ := repo.FindByID(ctx, "fix-login")
+	todo.Task = "Fix login timeout on mobile"
+	if err := repo.Save(ctx, todo); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+	if err := repo.Archive(ctx, "fix-login", "2026/10/16"); err != nil {
+		t.Fatalf("Failed to archive: %v", err)
+	}
+
+	if _, err := repo.ExportMarkdown(ctx, dir); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "todos/2026/10/14/fix-login.md")); !os.IsNotExist(err) {
+		t.Errorf("Expected the active copy of an archived todo to be removed, got %v", err)
+	}
+	archived, err := ioutil.ReadFile(filepath.Join(dir, "archive/2026/10/16/fix-login.md"))
+	if err != nil {
+		t.Fatalf("Archived todo not exported: %v", err)
+	}
+	if !strings.Contains(string(archived), "# Task: Fix login timeout on mobile\n") || strings.Count(string(archived), "# Task:") != 1 {
+		t.Errorf("Expected the renamed heading, got:\n%s", archived)
+	}
+}
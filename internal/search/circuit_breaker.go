@@ -16,6 +16,18 @@ const (
 	CircuitHalfOpen
 )
 
+// String returns the state as reported by health checks
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
 // CircuitBreaker protects against cascading failures
 type CircuitBreaker struct {
 	mu           sync.RWMutex
@@ -1,6 +1,11 @@
 package search
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -11,9 +16,73 @@ type Document struct {
 	Status    string    `json:"status"`
 	Priority  string    `json:"priority"`
 	Type      string    `json:"type"`
+	Tags      []string  `json:"tags,omitempty"`
 	Started   time.Time `json:"started"`
 	Completed time.Time `json:"completed,omitempty"`
 	Content   string    `json:"content"`
 	Findings  string    `json:"findings"`
 	Tests     string    `json:"tests"`
-}
\ No newline at end of file
+
+	// Archived todos stay searchable; ArchivePath is relative to the project
+	Archived    bool      `json:"archived"`
+	ArchivedAt  time.Time `json:"archived_at,omitempty"`
+	ArchivePath string    `json:"archive_path,omitempty"`
+
+	// The file as last indexed, to pick up changes made on disk: its
+	// modification time (RFC 3339 with nanoseconds) and a hash of its
+	// content. ModTime is empty when the document was written from memory.
+	ModTime string `json:"mod_time,omitempty"`
+	Hash    string `json:"hash"`
+}
+
+// indexTags lowercases tags for the index; tag filters are lowercased too,
+// so matching ignores case as it does elsewhere
+func indexTags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	lowered := make([]string, len(tags))
+	for i, tag := range tags {
+		lowered[i] = strings.ToLower(tag)
+	}
+	return lowered
+}
+
+// archiveDir is the path segment that marks a file as archived
+const archiveDir = "/.claude/archive/"
+
+// markArchived flags doc as archived when path lies in an archive directory.
+// The archive date is the completion time, which ArchiveTodo sets, falling
+// back to the file's modification time.
+func markArchived(doc *Document, path string, info os.FileInfo) {
+	slashed := filepath.ToSlash(path)
+	idx := strings.LastIndex(slashed, archiveDir)
+	if idx == -1 {
+		return
+	}
+
+	doc.Archived = true
+	doc.ArchivePath = slashed[idx+1:]
+	doc.ArchivedAt = doc.Completed
+	if doc.ArchivedAt.IsZero() && info != nil {
+		doc.ArchivedAt = info.ModTime()
+	}
+}
+
+// stampFile records the state of the file doc was read from
+func stampFile(doc *Document, info os.FileInfo, content []byte) {
+	doc.ModTime = formatModTime(info.ModTime())
+	doc.Hash = contentHash(content)
+}
+
+// formatModTime keeps full precision, which a stored datetime field would
+// round to the second
+func formatModTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// contentHash identifies a file's content
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/blevesearch/bleve/v2"
@@ -19,9 +20,15 @@ import (
 // Engine manages the bleve search index
 type Engine struct {
 	index           bleve.Index
+	indexMu         sync.RWMutex // guards index, which a rebuild replaces
+	indexPath       string
 	basePath        string
 	lock            *IndexLock
 	circuitBreaker  *CircuitBreaker
+
+	// Background reindex job; the last one is kept for health reports
+	jobMu sync.Mutex
+	job   *domainSearch.ReindexStatus
 }
 
 // NewEngine creates or opens a search index
@@ -46,8 +53,7 @@ func NewEngine(indexPath, todosPath string) (*Engine, error) {
 	if err == bleve.ErrorIndexPathDoesNotExist {
 		// Create new index
 		logging.Timingf("Index does not exist, creating new index at %s", indexPath)
-		mapping := buildIndexMapping()
-		index, err = bleve.New(indexPath, mapping)
+		index, err = newIndex(indexPath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create index: %w", err)
 		}
@@ -55,8 +61,7 @@ func NewEngine(indexPath, todosPath string) (*Engine, error) {
 		// Try to handle corruption by recreating
 		logging.Timingf("Index corrupted, recreating at %s", indexPath)
 		os.RemoveAll(indexPath)
-		mapping := buildIndexMapping()
-		index, err = bleve.New(indexPath, mapping)
+		index, err = newIndex(indexPath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to recreate corrupted index: %w", err)
 		}
@@ -66,6 +71,7 @@ func NewEngine(indexPath, todosPath string) (*Engine, error) {
 
 	engine := &Engine{
 		index:          index,
+		indexPath:      indexPath,
 		basePath:       todosPath,
 		lock:           indexLock,
 		circuitBreaker: NewCircuitBreaker(3, 15*time.Second, 30*time.Second),
@@ -94,6 +100,27 @@ func NewEngine(indexPath, todosPath string) (*Engine, error) {
 	return engine, nil
 }
 
+// withIndex runs fn on the current index under the read lock, so a rebuild
+// or Close can't close it while fn uses it. fn must not call withIndex.
+func (e *Engine) withIndex(fn func(index bleve.Index) error) error {
+	e.indexMu.RLock()
+	defer e.indexMu.RUnlock()
+	return fn(e.index)
+}
+
+// newBatch starts a batch to commit with withIndex; building one doesn't
+// touch the index's storage
+func (e *Engine) newBatch() *bleve.Batch {
+	e.indexMu.RLock()
+	defer e.indexMu.RUnlock()
+	return e.index.NewBatch()
+}
+
+// archivePath is the archive directory that sits next to the todos directory
+func (e *Engine) archivePath() string {
+	return filepath.Join(filepath.Dir(e.basePath), "archive")
+}
+
 // indexExistingTodosWithTimeout indexes all existing todo files with timeout protection
 func (e *Engine) indexExistingTodosWithTimeout(ctx context.Context) error {
 	// Channel to receive result from goroutine
@@ -133,7 +160,7 @@ func (e *Engine) indexExistingTodos() error {
 	}
 
 	// Create a batch for efficient indexing
-	batch := e.index.NewBatch()
+	batch := e.newBatch()
 
 	// Process files recursively
 	processStart := time.Now()
@@ -188,6 +215,7 @@ func (e *Engine) indexExistingTodos() error {
 			Status:    todo.Status,
 			Priority:  todo.Priority,
 			Type:      todo.Type,
+			Tags:      indexTags(todo.Tags),
 			Started:   todo.Started,
 			Completed: todo.Completed,
 			Content:   string(content),
@@ -196,6 +224,8 @@ func (e *Engine) indexExistingTodos() error {
 		// Extract sections for better search
 		doc.Findings = extractSection(string(content), "## Findings & Research")
 		doc.Tests = extractSection(string(content), "## Test Cases")
+		stampFile(&doc, info, content)
+		markArchived(&doc, path, info)
 
 		// Add to batch
 		batch.Index(todoID, doc)
@@ -253,7 +283,9 @@ func (e *Engine) indexExistingTodos() error {
 			return
 		default:
 			// Proceed with batch operation
-			err := e.index.Batch(batch)
+			err := e.withIndex(func(index bleve.Index) error {
+				return index.Batch(batch)
+			})
 			
 			// Only send result if context is still active
 			select {
@@ -292,6 +324,10 @@ func (e *Engine) Close() error {
 			}
 		}
 	}()
+
+	// Waits for calls still using the index
+	e.indexMu.Lock()
+	defer e.indexMu.Unlock()
 	return e.index.Close()
 }
 
@@ -314,7 +350,10 @@ func (e *Engine) HealthCheck() map[string]interface{} {
 	testRequest := bleve.NewSearchRequest(testQuery)
 	testRequest.Size = 1
 	
-	_, err := e.index.Search(testRequest)
+	err := e.withIndex(func(index bleve.Index) error {
+		_, err := index.Search(testRequest)
+		return err
+	})
 	health["index_healthy"] = err == nil
 	if err != nil {
 		health["index_error"] = err.Error()
@@ -325,7 +364,12 @@ func (e *Engine) HealthCheck() map[string]interface{} {
 
 // GetIndexedCount returns the number of indexed documents
 func (e *Engine) GetIndexedCount() (uint64, error) {
-	count, err := e.index.DocCount()
+	var count uint64
+	err := e.withIndex(func(index bleve.Index) error {
+		var err error
+		count, err = index.DocCount()
+		return err
+	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to get document count: %w", err)
 	}
@@ -334,47 +378,30 @@ func (e *Engine) GetIndexedCount() (uint64, error) {
 
 // Search performs a search with the given query and filters
 func (e *Engine) Search(queryStr string, filters map[string]string, limit int) ([]domainSearch.Result, error) {
-	// Build composite query
-	var searchQuery query.Query
-
-	// Handle text query
-	if queryStr != "" {
-		// Check if it's a phrase query (quoted)
-		if strings.HasPrefix(queryStr, "\"") && strings.HasSuffix(queryStr, "\"") {
-			// Keep phrase queries as-is for exact matching
-			searchQuery = bleve.NewQueryStringQuery(queryStr)
-		} else {
-			// Sanitize query to handle special characters safely
-			sanitized := sanitizeQuery(queryStr)
-
-			// If sanitization removed all content, return empty results
-			if sanitized == "" {
-				return []domainSearch.Result{}, nil
-			}
-
-			// For non-phrase queries, use field-specific queries with boosting
-			// Create separate queries for each field with different boost values
-			taskQuery := bleve.NewMatchQuery(sanitized)
-			taskQuery.SetField("task")
-			taskQuery.SetBoost(3.0) // Highest priority for task/title matches
-
-			findingsQuery := bleve.NewMatchQuery(sanitized)
-			findingsQuery.SetField("findings")
-			findingsQuery.SetBoost(1.5) // Medium priority for findings
-
-			testsQuery := bleve.NewMatchQuery(sanitized)
-			testsQuery.SetField("tests")
-			testsQuery.SetBoost(1.0) // Lower priority for test cases
+	response, err := e.search(queryStr, filters, limit, false)
+	if err != nil {
+		return nil, err
+	}
+	return response.Results, nil
+}
 
-			contentQuery := bleve.NewMatchQuery(sanitized)
-			contentQuery.SetField("content")
-			contentQuery.SetBoost(0.5) // Lowest priority for full content
+// SearchWithFacets performs a search and also counts every matching todo by
+// status, type, priority, tag and start month
+func (e *Engine) SearchWithFacets(queryStr string, filters map[string]string, limit int) (*domainSearch.Response, error) {
+	return e.search(queryStr, filters, limit, true)
+}
 
-			// Use DisjunctionQuery for "best match" behavior
-			searchQuery = bleve.NewDisjunctionQuery(taskQuery, findingsQuery, testsQuery, contentQuery)
+func (e *Engine) search(queryStr string, filters map[string]string, limit int, withFacets bool) (*domainSearch.Response, error) {
+	// Parse the query language into bleve queries
+	searchQuery, err := parseSearchQuery(queryStr, scopeFields(filters["scope"]))
+	if err != nil {
+		return nil, err
+	}
+	if searchQuery == nil {
+		// Nothing searchable left, e.g. only punctuation
+		if strings.TrimSpace(queryStr) != "" {
+			return &domainSearch.Response{Results: []domainSearch.Result{}}, nil
 		}
-	} else {
-		// If no query string, match all documents
 		searchQuery = bleve.NewMatchAllQuery()
 	}
 
@@ -383,11 +410,42 @@ func (e *Engine) Search(queryStr string, filters map[string]string, limit int) (
 		var queries []query.Query
 		queries = append(queries, searchQuery)
 
-		// Status filter
-		if status, ok := filters["status"]; ok && status != "" {
-			statusQuery := bleve.NewTermQuery(status)
-			statusQuery.SetField("status")
-			queries = append(queries, statusQuery)
+		// Status, priority and type filters, e.g. a facet picked from a previous search
+		for _, field := range []string{"status", "priority", "type"} {
+			if value, ok := filters[field]; ok && value != "" {
+				termQuery := bleve.NewTermQuery(value)
+				termQuery.SetField(field)
+				queries = append(queries, termQuery)
+			}
+		}
+
+		// Month filter (YYYY-MM) on the started date
+		if month, ok := filters["month"]; ok && month != "" {
+			monthQuery, err := dateQuery("month", "started", month)
+			if err != nil {
+				return nil, err
+			}
+			queries = append(queries, monthQuery)
+		}
+
+		// Tag filters: "tags" requires every tag, "any_tags" at least one
+		if tags, ok := filters["tags"]; ok && tags != "" {
+			for _, tag := range splitTags(tags) {
+				tagQuery := bleve.NewTermQuery(tag)
+				tagQuery.SetField("tags")
+				queries = append(queries, tagQuery)
+			}
+		}
+		if anyTags, ok := filters["any_tags"]; ok && anyTags != "" {
+			var tagQueries []query.Query
+			for _, tag := range splitTags(anyTags) {
+				tagQuery := bleve.NewTermQuery(tag)
+				tagQuery.SetField("tags")
+				tagQueries = append(tagQueries, tagQuery)
+			}
+			if len(tagQueries) > 0 {
+				queries = append(queries, bleve.NewDisjunctionQuery(tagQueries...))
+			}
 		}
 
 		// Date range filter using bleve native support
@@ -441,18 +499,29 @@ func (e *Engine) Search(queryStr string, filters map[string]string, limit int) (
 		searchQuery = bleve.NewConjunctionQuery(queries...)
 	}
 
+	// Archived todos are left out unless asked for
+	searchQuery, err = applyArchivedFilter(searchQuery, filters["archived"])
+	if err != nil {
+		return nil, err
+	}
+
 	// Create search request
 	searchRequest := bleve.NewSearchRequest(searchQuery)
 	searchRequest.Size = limit
-	searchRequest.Fields = []string{"task", "id", "started", "status"}
-	searchRequest.Highlight = bleve.NewHighlight() // Enable snippets
+	searchRequest.Fields = []string{"task", "id", "started", "status", "archived", "archive_path", "content"}
+	searchRequest.Highlight = newHighlightRequest()
+	if withFacets {
+		addFacetRequests(searchRequest, time.Now())
+	}
 
 	// Execute search with circuit breaker protection
 	var searchResults *bleve.SearchResult
-	err := e.circuitBreaker.Execute(context.Background(), func() error {
-		var searchErr error
-		searchResults, searchErr = e.index.Search(searchRequest)
-		return searchErr
+	err = e.circuitBreaker.Execute(context.Background(), func() error {
+		return e.withIndex(func(index bleve.Index) error {
+			var searchErr error
+			searchResults, searchErr = index.Search(searchRequest)
+			return searchErr
+		})
 	})
 	if err != nil {
 		return nil, fmt.Errorf("search failed: %w", err)
@@ -471,48 +540,102 @@ func (e *Engine) Search(queryStr string, filters map[string]string, limit int) (
 		if task, ok := hit.Fields["task"].(string); ok {
 			result.Task = task
 		}
+		if archived, ok := hit.Fields["archived"].(bool); ok {
+			result.Archived = archived
+		}
+		if archivePath, ok := hit.Fields["archive_path"].(string); ok {
+			result.ArchivePath = archivePath
+		}
 
-		// Get snippet from highlights
-		if len(hit.Fragments) > 0 {
-			for _, fragments := range hit.Fragments {
-				if len(fragments) > 0 {
-					result.Snippet = fragments[0]
-					break
-				}
-			}
+		// Best fragment doubles as the snippet
+		result.Highlights = collectHighlights(hit)
+		if len(result.Highlights) > 0 {
+			result.Snippet = result.Highlights[0].Fragment
 		}
 
 		results = append(results, result)
 	}
 
-	return results, nil
+	response := &domainSearch.Response{Results: results, Total: searchResults.Total}
+	if withFacets {
+		response.Facets = convertFacets(searchResults.Facets)
+	}
+	return response, nil
+}
+
+// applyArchivedFilter restricts q by the "archived" filter: "exclude" (the
+// default) drops archived todos, "include" keeps them and "only" returns
+// nothing else. Documents indexed before the flag existed count as active.
+func applyArchivedFilter(q query.Query, mode string) (query.Query, error) {
+	archivedQuery := bleve.NewBoolFieldQuery(true)
+	archivedQuery.SetField("archived")
+
+	switch mode {
+	case "", "exclude":
+		filtered := bleve.NewBooleanQuery()
+		filtered.AddMust(q)
+		filtered.AddMustNot(archivedQuery)
+		return filtered, nil
+	case "include":
+		return q, nil
+	case "only":
+		return bleve.NewConjunctionQuery(q, archivedQuery), nil
+	default:
+		return nil, fmt.Errorf("invalid archived filter %q: must be exclude, include or only", mode)
+	}
 }
 
 // Index adds or updates a todo in the search index
 func (e *Engine) Index(todo *domain.Todo, content string) error {
+	return e.indexDocument(todo, content, "")
+}
+
+// IndexArchived indexes a todo that was moved to the archive, keeping it
+// searchable with its archive location (relative to the project)
+func (e *Engine) IndexArchived(todo *domain.Todo, content, archivePath string) error {
+	return e.indexDocument(todo, content, archivePath)
+}
+
+// indexDocument writes a todo's document; a non-empty archivePath marks it archived
+func (e *Engine) indexDocument(todo *domain.Todo, content, archivePath string) error {
 	doc := Document{
 		ID:        todo.ID,
 		Task:      todo.Task,
 		Status:    todo.Status,
 		Priority:  todo.Priority,
 		Type:      todo.Type,
+		Tags:      indexTags(todo.Tags),
 		Started:   todo.Started,
 		Completed: todo.Completed,
 		Content:   content,
+		Hash:      contentHash([]byte(content)),
 	}
 
 	// Extract sections for better search
 	doc.Findings = extractSection(content, "## Findings & Research")
 	doc.Tests = extractSection(content, "## Test Cases")
 
+	if archivePath != "" {
+		doc.Archived = true
+		doc.ArchivePath = filepath.ToSlash(archivePath)
+		doc.ArchivedAt = todo.Completed
+		if doc.ArchivedAt.IsZero() {
+			doc.ArchivedAt = time.Now()
+		}
+	}
+
 	return e.circuitBreaker.Execute(context.Background(), func() error {
-		return e.index.Index(todo.ID, doc)
+		return e.withIndex(func(index bleve.Index) error {
+			return index.Index(todo.ID, doc)
+		})
 	})
 }
 
 // Delete removes a todo from the index
 func (e *Engine) Delete(id string) error {
 	return e.circuitBreaker.Execute(context.Background(), func() error {
-		return e.index.Delete(id)
+		return e.withIndex(func(index bleve.Index) error {
+			return index.Delete(id)
+		})
 	})
 }
\ No newline at end of file
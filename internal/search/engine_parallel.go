@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 	
+	"github.com/blevesearch/bleve/v2"
 	"github.com/user/mcp-todo-server/internal/logging"
 )
 
@@ -19,39 +20,34 @@ type fileInfo struct {
 	info os.FileInfo
 }
 
-// indexExistingTodosParallel indexes all existing todo files using parallel processing
+// indexExistingTodosParallel brings the index up to date with the todo
+// files using parallel processing. Only files that are new or changed since
+// they were last indexed are read, and entries whose files are gone are
+// removed, so a restart costs in proportion to what changed.
 func (e *Engine) indexExistingTodosParallel() error {
 	totalStart := time.Now()
 	
-	// Check if todos directory exists
+	// Compare the markdown files in the todos directory and the archive
+	// next to it with what the index recorded
 	logging.Infof("Starting parallel recursive index of todos directory: %s", e.basePath)
-	if _, err := os.Stat(e.basePath); os.IsNotExist(err) {
-		logging.Infof("Todos directory doesn't exist yet, skipping indexing")
-		return nil
-	}
-
-	// Collect all markdown files recursively
 	collectStart := time.Now()
-	var mdFiles []fileInfo
-	
-	err := filepath.Walk(e.basePath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			logging.Warnf("Error accessing path %s: %v", path, err)
-			return nil // Continue walking
-		}
-		
-		if !info.IsDir() && strings.HasSuffix(info.Name(), ".md") {
-			mdFiles = append(mdFiles, fileInfo{path: path, info: info})
-		}
-		return nil
-	})
+	plan, err := e.planOnCurrentMapping()
+	if err != nil {
+		return err
+	}
+	if err := e.deleteOrphans(plan.orphaned); err != nil {
+		return err
+	}
 	
-	if err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("error walking directory: %w", err)
+	changed := plan.changed()
+	mdFiles := make([]fileInfo, 0, len(changed))
+	for _, id := range changed {
+		mdFiles = append(mdFiles, plan.files[id])
 	}
 	
 	collectTime := time.Since(collectStart)
-	logging.Timingf("Found %d markdown files (collection took %v)", len(mdFiles), collectTime)
+	logging.Timingf("Found %d markdown files, %d new or changed, %d removed (planning took %v)",
+		len(plan.files), len(mdFiles), len(plan.orphaned), collectTime)
 	
 	if len(mdFiles) == 0 {
 		return nil
@@ -84,7 +80,7 @@ func (e *Engine) indexExistingTodosParallel() error {
 	}()
 	
 	// Collect results and create batch
-	batch := e.index.NewBatch()
+	batch := e.newBatch()
 	processedCount := 0
 	skippedCount := 0
 	totalFileSize := int64(0)
@@ -162,7 +158,9 @@ func (e *Engine) indexExistingTodosParallel() error {
 			return
 		default:
 			// Proceed with batch operation
-			err := e.index.Batch(batch)
+			err := e.withIndex(func(index bleve.Index) error {
+				return index.Batch(batch)
+			})
 			
 			// Only send result if context is still active
 			select {
@@ -194,6 +192,35 @@ func (e *Engine) indexExistingTodosParallel() error {
 	return nil
 }
 
+// collectTodoFiles lists the markdown files in the todos directory and the
+// archive next to it
+func (e *Engine) collectTodoFiles() ([]fileInfo, error) {
+	var mdFiles []fileInfo
+	for _, root := range []string{e.basePath, e.archivePath()} {
+		if _, err := os.Stat(root); os.IsNotExist(err) {
+			logging.Infof("%s doesn't exist yet, skipping", root)
+			continue
+		}
+
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				logging.Warnf("Error accessing path %s: %v", path, err)
+				return nil // Continue walking
+			}
+			
+			if !info.IsDir() && strings.HasSuffix(info.Name(), ".md") {
+				mdFiles = append(mdFiles, fileInfo{path: path, info: info})
+			}
+			return nil
+		})
+		
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("error walking directory: %w", err)
+		}
+	}
+	return mdFiles, nil
+}
+
 // indexResult holds the result of indexing a single file
 type indexResult struct {
 	doc      *Document
@@ -248,6 +275,7 @@ func (e *Engine) processFile(file fileInfo) *indexResult {
 		Status:    todo.Status,
 		Priority:  todo.Priority,
 		Type:      todo.Type,
+		Tags:      indexTags(todo.Tags),
 		Started:   todo.Started,
 		Completed: todo.Completed,
 		Content:   string(content),
@@ -256,6 +284,8 @@ func (e *Engine) processFile(file fileInfo) *indexResult {
 	// Extract sections for better search
 	doc.Findings = extractSection(string(content), "## Findings & Research")
 	doc.Tests = extractSection(string(content), "## Test Cases")
+	stampFile(doc, file.info, content)
+	markArchived(doc, filePath, file.info)
 	
 	return &indexResult{
 		doc:      doc,
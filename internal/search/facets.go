@@ -0,0 +1,72 @@
+package search
+
+import (
+	"sort"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search"
+
+	domainSearch "github.com/user/mcp-todo-server/internal/domain/search"
+)
+
+// facetSize caps the values reported per term facet
+const facetSize = 10
+
+// facetMonths is how many calendar months the month facet covers
+const facetMonths = 12
+
+// termFacetFields are faceted by value, in display order
+var termFacetFields = []string{"status", "type", "priority", "tags"}
+
+// addFacetRequests asks for counts by keyword field and by start month over
+// the last facetMonths months, ending with the month of now
+func addFacetRequests(req *bleve.SearchRequest, now time.Time) {
+	for _, field := range termFacetFields {
+		req.AddFacet(field, bleve.NewFacetRequest(field, facetSize))
+	}
+
+	months := bleve.NewFacetRequest("started", facetMonths)
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < facetMonths; i++ {
+		start := current.AddDate(0, -i, 0)
+		months.AddDateTimeRange(start.Format("2006-01"), start, start.AddDate(0, 1, 0))
+	}
+	req.AddFacet("month", months)
+}
+
+// convertFacets turns bleve facet results into domain facets. Term values
+// come most frequent first, months newest first; empty buckets are dropped.
+func convertFacets(results search.FacetResults) []domainSearch.Facet {
+	var facets []domainSearch.Facet
+
+	names := append([]string{}, termFacetFields...)
+	for _, name := range append(names, "month") {
+		result, ok := results[name]
+		if !ok {
+			continue
+		}
+		facet := domainSearch.Facet{Name: name, Missing: result.Missing}
+
+		if result.Terms != nil {
+			for _, term := range result.Terms.Terms() {
+				facet.Values = append(facet.Values, domainSearch.FacetValue{Value: term.Term, Count: term.Count})
+			}
+		}
+		for _, dateRange := range result.DateRanges {
+			if dateRange.Count > 0 {
+				facet.Values = append(facet.Values, domainSearch.FacetValue{Value: dateRange.Name, Count: dateRange.Count})
+			}
+		}
+		if name == "month" {
+			sort.Slice(facet.Values, func(i, j int) bool {
+				return facet.Values[i].Value > facet.Values[j].Value
+			})
+		}
+
+		if len(facet.Values) > 0 {
+			facets = append(facets, facet)
+		}
+	}
+	return facets
+}
@@ -0,0 +1,102 @@
+package search
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/user/mcp-todo-server/internal/domain"
+	domainSearch "github.com/user/mcp-todo-server/internal/domain/search"
+)
+
+func TestSearchWithFacets(t *testing.T) {
+	tempDir := t.TempDir()
+	engine, err := NewEngine(filepath.Join(tempDir, "index", "todos.bleve"), filepath.Join(tempDir, "todos"))
+	if err != nil {
+		t.Fatalf("Failed to create search engine: %v", err)
+	}
+	defer engine.Close()
+
+	thisMonth := time.Now().UTC()
+	lastMonth := time.Date(thisMonth.Year(), thisMonth.Month(), 1, 12, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	todos := []*domain.Todo{
+		{ID: "login-bug", Task: "Fix login timeout", Status: "in_progress", Priority: "high", Type: "bug", Tags: []string{"auth"}, Started: thisMonth},
+		{ID: "signup-bug", Task: "Fix signup timeout", Status: "blocked", Priority: "high", Type: "bug", Tags: []string{"auth", "web"}, Started: lastMonth},
+		{ID: "timeout-docs", Task: "Document timeout settings", Status: "in_progress", Priority: "low", Type: "feature", Started: thisMonth},
+		{ID: "unrelated", Task: "Refresh logo", Status: "in_progress", Priority: "low", Type: "feature", Started: thisMonth},
+	}
+	for _, todo := range todos {
+		if err := engine.Index(todo, "# Task: "+todo.Task); err != nil {
+			t.Fatalf("Failed to index %s: %v", todo.ID, err)
+		}
+	}
+
+	response, err := engine.SearchWithFacets("timeout", nil, 1)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(response.Results) != 1 || response.Total != 3 {
+		t.Fatalf("Expected 1 of 3 results, got %d of %d", len(response.Results), response.Total)
+	}
+
+	facets := map[string]domainSearch.Facet{}
+	for _, facet := range response.Facets {
+		facets[facet.Name] = facet
+	}
+	counts := func(name string) map[string]int {
+		m := map[string]int{}
+		for _, v := range facets[name].Values {
+			m[v.Value] = v.Count
+		}
+		return m
+	}
+
+	expected := map[string]map[string]int{
+		"status":   {"in_progress": 2, "blocked": 1},
+		"type":     {"bug": 2, "feature": 1},
+		"priority": {"high": 2, "low": 1},
+		"tags":     {"auth": 2, "web": 1},
+		"month":    {thisMonth.Format("2006-01"): 2, lastMonth.Format("2006-01"): 1},
+	}
+	for name, want := range expected {
+		got := counts(name)
+		if len(got) != len(want) {
+			t.Errorf("Facet %s: expected %v, got %v", name, want, got)
+			continue
+		}
+		for value, count := range want {
+			if got[value] != count {
+				t.Errorf("Facet %s: expected %v, got %v", name, want, got)
+			}
+		}
+	}
+	if facets["tags"].Missing != 1 {
+		t.Errorf("Expected one match without tags, got %d", facets["tags"].Missing)
+	}
+	if months := facets["month"].Values; months[0].Value != thisMonth.Format("2006-01") {
+		t.Errorf("Expected newest month first, got %v", months)
+	}
+
+	// Facet values work as follow-up filters and query qualifiers
+	followUps := []struct {
+		query   string
+		filters map[string]string
+		want    []string
+	}{
+		{"timeout", map[string]string{"priority": "high", "type": "bug"}, []string{"login-bug", "signup-bug"}},
+		{"timeout", map[string]string{"month": lastMonth.Format("2006-01")}, []string{"signup-bug"}},
+		{"timeout started:" + thisMonth.Format("2006-01"), nil, []string{"login-bug", "timeout-docs"}},
+	}
+	for _, tt := range followUps {
+		got := searchIDs(t, engine, tt.query, tt.filters)
+		if len(got) != len(tt.want) {
+			t.Errorf("%q %v: expected %v, got %v", tt.query, tt.filters, tt.want, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q %v: expected %v, got %v", tt.query, tt.filters, tt.want, got)
+			}
+		}
+	}
+}
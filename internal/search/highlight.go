@@ -0,0 +1,121 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/registry"
+	"github.com/blevesearch/bleve/v2/search"
+	"github.com/blevesearch/bleve/v2/search/highlight"
+	"github.com/blevesearch/bleve/v2/search/highlight/format/plain"
+	simpleFragmenter "github.com/blevesearch/bleve/v2/search/highlight/fragmenter/simple"
+	simpleHighlighter "github.com/blevesearch/bleve/v2/search/highlight/highlighter/simple"
+
+	domainSearch "github.com/user/mcp-todo-server/internal/domain/search"
+)
+
+// markdownHighlighter marks matched terms as **bold**, which reads well in
+// tool output, unlike the default <mark> tags
+const markdownHighlighter = "todo-markdown"
+
+// maxHighlights caps the fragments returned per result
+const maxHighlights = 3
+
+// highlightFields are highlighted in this order; content comes last since
+// it repeats whatever the other sections matched
+var highlightFields = []string{"task", "findings", "tests", "content"}
+
+// fieldSections names the todo section each indexed field comes from
+var fieldSections = map[string]string{
+	"task":     "Task",
+	"findings": "Findings & Research",
+	"tests":    "Test Cases",
+}
+
+func init() {
+	err := registry.RegisterHighlighter(markdownHighlighter, func(config map[string]interface{}, cache *registry.Cache) (highlight.Highlighter, error) {
+		fragmenter, err := cache.FragmenterNamed(simpleFragmenter.Name)
+		if err != nil {
+			return nil, fmt.Errorf("error building fragmenter: %v", err)
+		}
+		formatter := plain.NewFragmentFormatter("**", "**")
+		return simpleHighlighter.NewHighlighter(fragmenter, formatter, simpleHighlighter.DefaultSeparator), nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// newHighlightRequest asks for highlighted fragments in every text field
+func newHighlightRequest() *bleve.HighlightRequest {
+	req := bleve.NewHighlightWithStyle(markdownHighlighter)
+	for _, field := range highlightFields {
+		req.AddField(field)
+	}
+	return req
+}
+
+// collectHighlights turns a hit's fragments into highlights labelled with
+// the section they came from. Content fragments are traced back to their
+// "## " heading and dropped when that section already produced a match.
+func collectHighlights(hit *search.DocumentMatch) []domainSearch.Highlight {
+	content, _ := hit.Fields["content"].(string)
+	var highlights []domainSearch.Highlight
+	seenSections := make(map[string]bool)
+
+	for _, field := range highlightFields {
+		// Fields without a match still get a leading fragment; skip those
+		if len(hit.Locations[field]) == 0 {
+			continue
+		}
+		for _, fragment := range hit.Fragments[field] {
+			fragment = strings.TrimSpace(fragment)
+			if fragment == "" {
+				continue
+			}
+
+			section := fieldSections[field]
+			if field == "content" {
+				section = sectionOf(content, fragment)
+				if seenSections[section] {
+					continue
+				}
+			}
+			seenSections[section] = true
+
+			highlights = append(highlights, domainSearch.Highlight{
+				Field:    field,
+				Section:  section,
+				Fragment: fragment,
+			})
+			if len(highlights) == maxHighlights {
+				return highlights
+			}
+		}
+	}
+	return highlights
+}
+
+// sectionOf returns the heading above the first marked term of a content
+// fragment, or "Content" when the fragment can't be placed
+func sectionOf(content, fragment string) string {
+	fragment = strings.TrimPrefix(strings.TrimSuffix(fragment, simpleHighlighter.DefaultSeparator), simpleHighlighter.DefaultSeparator)
+	idx := strings.Index(content, strings.ReplaceAll(fragment, "**", ""))
+	if idx < 0 {
+		return "Content"
+	}
+	if mark := strings.Index(fragment, "**"); mark > 0 {
+		idx += mark
+	}
+
+	section := "Content"
+	for _, line := range strings.Split(content[:idx], "\n") {
+		if strings.HasPrefix(line, "## ") {
+			section = strings.TrimSpace(strings.TrimPrefix(line, "## "))
+		} else if strings.HasPrefix(line, "# Task:") {
+			section = "Task"
+		}
+	}
+	return section
+}
@@ -0,0 +1,77 @@
+package search
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/user/mcp-todo-server/internal/domain"
+)
+
+func TestSearchHighlightsBySection(t *testing.T) {
+	tempDir := t.TempDir()
+	engine, err := NewEngine(filepath.Join(tempDir, "index", "todos.bleve"), filepath.Join(tempDir, "todos"))
+	if err != nil {
+		t.Fatalf("Failed to create search engine: %v", err)
+	}
+	defer engine.Close()
+
+	todo := &domain.Todo{ID: "cache-race", Task: "Fix eviction race", Status: "in_progress", Started: time.Now()}
+	content := `# Task: Fix eviction race
+
+## Findings & Research
+
+The mutex is released before the timer fires.
+
+## Test Cases
+
+TestEvictionUnderLoad reproduces it with a deadline.
+
+## Notes
+
+Ask about the deadline in the sync meeting.
+`
+	if err := engine.Index(todo, content); err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	tests := []struct {
+		query    string
+		section  string
+		fragment string
+	}{
+		{"eviction", "Task", "Fix **eviction** race"},
+		{"mutex", "Findings & Research", "The **mutex** is released"},
+		{"reproduces", "Test Cases", "**reproduces** it"},
+		{"meeting", "Notes", "sync **meeting**"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results, err := engine.Search(tt.query, nil, 10)
+			if err != nil || len(results) != 1 {
+				t.Fatalf("Expected one result, got %v (err %v)", results, err)
+			}
+			highlights := results[0].Highlights
+			if len(highlights) == 0 {
+				t.Fatal("Expected highlights")
+			}
+			if highlights[0].Section != tt.section || !strings.Contains(highlights[0].Fragment, tt.fragment) {
+				t.Errorf("Expected %q in section %q, got %+v", tt.fragment, tt.section, highlights)
+			}
+			if results[0].Snippet != highlights[0].Fragment {
+				t.Errorf("Expected snippet to be the first fragment, got %q", results[0].Snippet)
+			}
+		})
+	}
+
+	// Sections also live in content; a match should be reported once
+	results, _ := engine.Search("deadline", nil, 10)
+	sections := map[string]int{}
+	for _, h := range results[0].Highlights {
+		sections[h.Section]++
+	}
+	if sections["Test Cases"] != 1 {
+		t.Errorf("Expected a single Test Cases highlight, got %+v", results[0].Highlights)
+	}
+}
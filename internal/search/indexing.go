@@ -1,12 +1,41 @@
 package search
 
 import (
+	"fmt"
 	"github.com/blevesearch/bleve/v2"
 	"github.com/blevesearch/bleve/v2/analysis/analyzer/standard"
 	"github.com/blevesearch/bleve/v2/mapping"
 	"strings"
 )
 
+// indexMappingVersion is recorded in every index built from
+// buildIndexMapping. bleve keeps the mapping an index was created with, so
+// bump this whenever the mapping or the indexed values change and older
+// indexes get rebuilt.
+const indexMappingVersion = "2"
+
+// mappingVersionKey is the internal key the mapping version is stored under
+var mappingVersionKey = []byte("mapping_version")
+
+// newIndex creates an index at path with the current mapping
+func newIndex(path string) (bleve.Index, error) {
+	index, err := bleve.New(path, buildIndexMapping())
+	if err != nil {
+		return nil, err
+	}
+	if err := index.SetInternal(mappingVersionKey, []byte(indexMappingVersion)); err != nil {
+		index.Close()
+		return nil, fmt.Errorf("failed to record mapping version: %w", err)
+	}
+	return index, nil
+}
+
+// mappingCurrent reports whether index was built with the current mapping
+func mappingCurrent(index bleve.Index) bool {
+	version, err := index.GetInternal(mappingVersionKey)
+	return err == nil && string(version) == indexMappingVersion
+}
+
 // buildIndexMapping creates the index mapping for todos
 func buildIndexMapping() mapping.IndexMapping {
 	// Create a standard document mapping
@@ -38,6 +67,9 @@ func buildIndexMapping() mapping.IndexMapping {
 	todoMapping.AddFieldMappingsAt("priority", keywordFieldMapping)
 	todoMapping.AddFieldMappingsAt("type", keywordFieldMapping)
 
+	// Tags are matched exactly, so "area/api" isn't split into terms
+	todoMapping.AddFieldMappingsAt("tags", keywordFieldMapping)
+
 	// Date fields
 	dateFieldMapping := bleve.NewDateTimeFieldMapping()
 	dateFieldMapping.Store = true
@@ -47,6 +79,21 @@ func buildIndexMapping() mapping.IndexMapping {
 
 	todoMapping.AddFieldMappingsAt("started", dateFieldMapping)
 	todoMapping.AddFieldMappingsAt("completed", dateFieldMapping)
+	todoMapping.AddFieldMappingsAt("archived_at", dateFieldMapping)
+
+	// Archive flag and location
+	archivedFieldMapping := bleve.NewBooleanFieldMapping()
+	archivedFieldMapping.Store = true
+	todoMapping.AddFieldMappingsAt("archived", archivedFieldMapping)
+	todoMapping.AddFieldMappingsAt("archive_path", keywordFieldMapping)
+
+	// File state for incremental indexing; stored, never searched
+	fileStateMapping := bleve.NewKeywordFieldMapping()
+	fileStateMapping.Store = true
+	fileStateMapping.Index = false
+	fileStateMapping.IncludeInAll = false
+	todoMapping.AddFieldMappingsAt("mod_time", fileStateMapping)
+	todoMapping.AddFieldMappingsAt("hash", fileStateMapping)
 
 	// Create index mapping
 	indexMapping := bleve.NewIndexMapping()
@@ -0,0 +1,395 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/index/scorch"
+
+	domainSearch "github.com/user/mcp-todo-server/internal/domain/search"
+	"github.com/user/mcp-todo-server/internal/logging"
+)
+
+// Reindex modes
+const (
+	ReindexFull        = "full"        // re-index every file
+	ReindexIncremental = "incremental" // only new and changed files
+	ReindexRebuild     = "rebuild"     // recreate the index, then re-index every file
+)
+
+// reindexBatchSize is how many files are committed together; progress is
+// reported after each batch
+const reindexBatchSize = 100
+
+// reindexPlan is the difference between the files on disk and the index
+type reindexPlan struct {
+	files    map[string]fileInfo // by todo ID; an active todo wins over an archived copy
+	current  int                 // files with an up-to-date entry
+	missing  []string
+	outdated []string
+	orphaned []string
+
+	// Files whose content is unchanged but whose modification time is not
+	// the one recorded; re-indexing them lets the next run skip the hash
+	touched []string
+
+	// The index was built with an older mapping, so every entry is
+	// outdated and re-indexing into it wouldn't help: it must be recreated
+	rebuild bool
+}
+
+// changed returns the IDs an incremental reindex writes, in order
+func (p *reindexPlan) changed() []string {
+	ids := make([]string, 0, len(p.missing)+len(p.outdated)+len(p.touched))
+	ids = append(append(append(ids, p.missing...), p.outdated...), p.touched...)
+	sort.Strings(ids)
+	return ids
+}
+
+// indexedDoc is the file state recorded for a document
+type indexedDoc struct {
+	modTime  string
+	hash     string
+	archived bool
+}
+
+// Health compares the index with the todo files on disk and reports the
+// circuit breaker and the last reindex
+func (e *Engine) Health() domainSearch.IndexHealth {
+	health := domainSearch.IndexHealth{
+		CircuitBreaker:  e.circuitBreaker.GetState().String(),
+		CircuitFailures: e.circuitBreaker.GetFailureCount(),
+		LastReindex:     e.LastReindex(),
+	}
+
+	var count uint64
+	err := e.withIndex(func(index bleve.Index) error {
+		var err error
+		count, err = index.DocCount()
+		return err
+	})
+	if err != nil {
+		health.Error = fmt.Sprintf("failed to count documents: %v", err)
+		return health
+	}
+	health.DocCount = count
+
+	plan, err := e.planReindex()
+	if err != nil {
+		health.Error = err.Error()
+		return health
+	}
+	health.Healthy = true
+
+	health.Files = len(plan.files)
+	for _, file := range plan.files {
+		if isArchivedFile(file.path) {
+			health.ArchivedFiles++
+		}
+	}
+	health.Indexed = plan.current
+	health.Missing = plan.missing
+	health.Outdated = plan.outdated
+	health.Orphaned = plan.orphaned
+	return health
+}
+
+// LastReindex returns a copy of the running or most recent reindex, or nil
+func (e *Engine) LastReindex() *domainSearch.ReindexStatus {
+	e.jobMu.Lock()
+	defer e.jobMu.Unlock()
+	if e.job == nil {
+		return nil
+	}
+	job := *e.job
+	return &job
+}
+
+// StartReindex re-indexes in the background and returns a channel that is
+// closed when it finishes. progress, if set, is called as batches complete.
+// Only one reindex runs at a time.
+func (e *Engine) StartReindex(mode string, progress func(done, total int)) (<-chan struct{}, error) {
+	if mode != ReindexFull && mode != ReindexIncremental && mode != ReindexRebuild {
+		return nil, fmt.Errorf("invalid reindex mode %q: must be full, incremental or rebuild", mode)
+	}
+
+	e.jobMu.Lock()
+	if e.job != nil && e.job.Running {
+		running := *e.job
+		e.jobMu.Unlock()
+		return nil, fmt.Errorf("a %s reindex is already running (%d/%d files)", running.Mode, running.Done, running.Total)
+	}
+	e.job = &domainSearch.ReindexStatus{Mode: mode, Running: true, StartedAt: time.Now()}
+	e.jobMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := e.reindex(mode, progress)
+		e.updateJob(func(job *domainSearch.ReindexStatus) {
+			job.Running = false
+			job.FinishedAt = time.Now()
+			if err != nil {
+				job.Error = err.Error()
+			}
+		})
+		if err != nil {
+			logging.Warnf("%s reindex failed: %v", mode, err)
+		}
+	}()
+	return done, nil
+}
+
+// Compact merges the index segments into one, reclaiming the space held by
+// deleted and replaced documents
+func (e *Engine) Compact() error {
+	return e.withIndex(func(index bleve.Index) error {
+		advanced, err := index.Advanced()
+		if err != nil {
+			return fmt.Errorf("failed to open index: %w", err)
+		}
+		scorchIndex, ok := advanced.(*scorch.Scorch)
+		if !ok {
+			return fmt.Errorf("compaction is not supported by this index type")
+		}
+		return scorchIndex.ForceMerge(context.Background(), nil)
+	})
+}
+
+// updateJob applies update to the current job under its lock
+func (e *Engine) updateJob(update func(job *domainSearch.ReindexStatus)) {
+	e.jobMu.Lock()
+	defer e.jobMu.Unlock()
+	if e.job != nil {
+		update(e.job)
+	}
+}
+
+// reindex brings the index in line with the files on disk. Entries whose
+// file is gone are always dropped.
+func (e *Engine) reindex(mode string, progress func(done, total int)) error {
+	if mode == ReindexRebuild {
+		if err := e.recreateIndex(); err != nil {
+			return err
+		}
+	}
+
+	plan, err := e.planOnCurrentMapping()
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	if mode == ReindexIncremental {
+		ids = plan.changed()
+	} else {
+		for id := range plan.files {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+	}
+
+	e.updateJob(func(job *domainSearch.ReindexStatus) { job.Total = len(ids) })
+	if progress != nil {
+		progress(0, len(ids))
+	}
+
+	commit := func(batch *bleve.Batch) error {
+		return e.circuitBreaker.Execute(context.Background(), func() error {
+			return e.withIndex(func(index bleve.Index) error {
+				return index.Batch(batch)
+			})
+		})
+	}
+
+	if err := e.deleteOrphans(plan.orphaned); err != nil {
+		return err
+	}
+	e.updateJob(func(job *domainSearch.ReindexStatus) { job.Deleted = len(plan.orphaned) })
+
+	for start := 0; start < len(ids); start += reindexBatchSize {
+		end := start + reindexBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		batch := e.newBatch()
+		for _, id := range ids[start:end] {
+			result := e.processFile(plan.files[id])
+			if result.err != nil {
+				logging.Warnf("Skipping %s: %v", result.fileName, result.err)
+				continue
+			}
+			batch.Index(id, result.doc)
+		}
+		if err := commit(batch); err != nil {
+			return fmt.Errorf("failed to index batch: %w", err)
+		}
+
+		e.updateJob(func(job *domainSearch.ReindexStatus) { job.Done = end })
+		if progress != nil {
+			progress(end, len(ids))
+		}
+	}
+	return nil
+}
+
+// planOnCurrentMapping plans a reindex, first recreating the index if it
+// was built with an older mapping; every file is then missing
+func (e *Engine) planOnCurrentMapping() (*reindexPlan, error) {
+	plan, err := e.planReindex()
+	if err != nil || !plan.rebuild {
+		return plan, err
+	}
+	logging.Infof("Index at %s was built with an older mapping, rebuilding", e.indexPath)
+	if err := e.recreateIndex(); err != nil {
+		return nil, err
+	}
+	return e.planReindex()
+}
+
+// planReindex compares the files on disk with the index entries. A file
+// whose modification time matches the recorded one is taken as unchanged
+// without being read; otherwise its content hash decides. Under an older
+// mapping every indexed file is outdated.
+func (e *Engine) planReindex() (*reindexPlan, error) {
+	files, err := e.collectTodoFiles()
+	if err != nil {
+		return nil, err
+	}
+	indexed, err := e.indexedDocs()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &reindexPlan{files: make(map[string]fileInfo, len(files))}
+	e.withIndex(func(index bleve.Index) error {
+		plan.rebuild = !mappingCurrent(index)
+		return nil
+	})
+	for _, file := range files {
+		id := strings.TrimSuffix(file.info.Name(), ".md")
+		if existing, seen := plan.files[id]; seen && !isArchivedFile(existing.path) {
+			continue
+		}
+		plan.files[id] = file
+	}
+
+	for id, file := range plan.files {
+		doc, ok := indexed[id]
+		switch {
+		case !ok:
+			plan.missing = append(plan.missing, id)
+		case plan.rebuild || doc.hash == "" || doc.archived != isArchivedFile(file.path):
+			plan.outdated = append(plan.outdated, id)
+		case doc.modTime == formatModTime(file.info.ModTime()):
+			plan.current++
+		default:
+			content, err := os.ReadFile(file.path)
+			switch {
+			case err != nil:
+				logging.Warnf("Failed to read %s: %v", file.path, err)
+				plan.outdated = append(plan.outdated, id)
+			case contentHash(content) != doc.hash:
+				plan.outdated = append(plan.outdated, id)
+			default:
+				plan.current++
+				plan.touched = append(plan.touched, id)
+			}
+		}
+	}
+	for id := range indexed {
+		if _, ok := plan.files[id]; !ok {
+			plan.orphaned = append(plan.orphaned, id)
+		}
+	}
+
+	sort.Strings(plan.missing)
+	sort.Strings(plan.outdated)
+	sort.Strings(plan.orphaned)
+	sort.Strings(plan.touched)
+	return plan, nil
+}
+
+// indexedDocs returns the recorded file state of every document in the index
+func (e *Engine) indexedDocs() (map[string]indexedDoc, error) {
+	var res *bleve.SearchResult
+	err := e.withIndex(func(index bleve.Index) error {
+		count, err := index.DocCount()
+		if err != nil {
+			return fmt.Errorf("failed to count documents: %w", err)
+		}
+
+		req := bleve.NewSearchRequest(bleve.NewMatchAllQuery())
+		req.Size = int(count)
+		req.Fields = []string{"mod_time", "hash", "archived"}
+		if res, err = index.Search(req); err != nil {
+			return fmt.Errorf("failed to list indexed documents: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string]indexedDoc, len(res.Hits))
+	for _, hit := range res.Hits {
+		var doc indexedDoc
+		doc.modTime, _ = hit.Fields["mod_time"].(string)
+		doc.hash, _ = hit.Fields["hash"].(string)
+		doc.archived, _ = hit.Fields["archived"].(bool)
+		docs[hit.ID] = doc
+	}
+	return docs, nil
+}
+
+// deleteOrphans removes the entries of todos whose files are gone
+func (e *Engine) deleteOrphans(ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	batch := e.newBatch()
+	for _, id := range ids {
+		batch.Delete(id)
+	}
+	err := e.circuitBreaker.Execute(context.Background(), func() error {
+		return e.withIndex(func(index bleve.Index) error {
+			return index.Batch(batch)
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove orphaned entries: %w", err)
+	}
+	return nil
+}
+
+// recreateIndex replaces the index with an empty one, dropping whatever was
+// on disk; used to recover from corruption without a restart
+func (e *Engine) recreateIndex() error {
+	e.indexMu.Lock()
+	defer e.indexMu.Unlock()
+
+	if err := e.index.Close(); err != nil {
+		logging.Warnf("Failed to close index before rebuild: %v", err)
+	}
+	if err := os.RemoveAll(e.indexPath); err != nil {
+		return fmt.Errorf("failed to remove index: %w", err)
+	}
+	index, err := newIndex(e.indexPath)
+	if err != nil {
+		return fmt.Errorf("failed to recreate index: %w", err)
+	}
+	e.index = index
+	return nil
+}
+
+// isArchivedFile reports whether path lies in an archive directory
+func isArchivedFile(path string) bool {
+	return strings.Contains(filepath.ToSlash(path), archiveDir)
+}
@@ -0,0 +1,222 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+)
+
+func TestIndexHealthAndReindex(t *testing.T) {
+	tempDir := t.TempDir()
+	todosPath := filepath.Join(tempDir, ".claude", "todos")
+	archivePath := filepath.Join(tempDir, ".claude", "archive")
+	dayPath := filepath.Join("2025", "01", "18")
+
+	writeTodoFile(t, filepath.Join(todosPath, dayPath, "cache-eviction.md"), "cache-eviction", "Tune cache eviction", "in_progress")
+	writeTodoFile(t, filepath.Join(todosPath, dayPath, "login-timeout.md"), "login-timeout", "Fix login timeout", "in_progress")
+	writeTodoFile(t, filepath.Join(archivePath, dayPath, "old-cache.md"), "old-cache", "Add cache layer", "completed")
+
+	engine, err := NewEngine(filepath.Join(tempDir, ".claude", "index", "todos.bleve"), todosPath)
+	if err != nil {
+		t.Fatalf("Failed to create search engine: %v", err)
+	}
+	defer engine.Close()
+
+	health := engine.Health()
+	if !health.Healthy || health.DocCount != 3 || health.Files != 3 || health.ArchivedFiles != 1 || health.Indexed != 3 {
+		t.Fatalf("Expected a healthy, complete index, got %+v", health)
+	}
+	if health.Stale() != 0 || len(health.Missing) != 0 || health.CircuitBreaker != "closed" || health.LastReindex != nil {
+		t.Errorf("Expected nothing stale and no reindex yet, got %+v", health)
+	}
+
+	// Drift: a new file, an edited file, a deleted file and a file that was
+	// only touched, which is not stale but gets its time recorded
+	writeTodoFile(t, filepath.Join(todosPath, dayPath, "dark-mode.md"), "dark-mode", "Add dark mode toggle", "in_progress")
+	writeTodoFile(t, filepath.Join(todosPath, dayPath, "cache-eviction.md"), "cache-eviction", "Tune LRU cache eviction", "in_progress")
+	os.Remove(filepath.Join(todosPath, dayPath, "login-timeout.md"))
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(filepath.Join(archivePath, dayPath, "old-cache.md"), later, later)
+
+	health = engine.Health()
+	if len(health.Missing) != 1 || health.Missing[0] != "dark-mode" ||
+		len(health.Outdated) != 1 || health.Outdated[0] != "cache-eviction" ||
+		len(health.Orphaned) != 1 || health.Orphaned[0] != "login-timeout" {
+		t.Fatalf("Expected one missing, outdated and orphaned entry, got %+v", health)
+	}
+
+	var reports [][2]int
+	done, err := engine.StartReindex(ReindexIncremental, func(done, total int) {
+		reports = append(reports, [2]int{done, total})
+	})
+	if err != nil {
+		t.Fatalf("StartReindex failed: %v", err)
+	}
+	<-done
+
+	job := engine.LastReindex()
+	if job == nil || job.Running || job.Error != "" || job.Total != 3 || job.Done != 3 || job.Deleted != 1 {
+		t.Errorf("Unexpected reindex status %+v", job)
+	}
+	if len(reports) != 2 || reports[0] != [2]int{0, 3} || reports[1] != [2]int{3, 3} {
+		t.Errorf("Expected start and end progress reports, got %v", reports)
+	}
+	if ids := searchIDs(t, engine, "lru", nil); len(ids) != 1 || ids[0] != "cache-eviction" {
+		t.Errorf("Expected the edited todo to be re-indexed, got %v", ids)
+	}
+	if ids := searchIDs(t, engine, "dark", nil); len(ids) != 1 {
+		t.Errorf("Expected the new todo to be indexed, got %v", ids)
+	}
+	if health = engine.Health(); health.Stale() != 0 || len(health.Missing) != 0 || health.Indexed != 3 {
+		t.Errorf("Expected the index to match the disk after reindexing, got %+v", health)
+	}
+
+	if _, err := engine.StartReindex("partial", nil); err == nil {
+		t.Error("Expected an unknown mode to fail")
+	}
+
+	// A rebuild starts from an empty index and restores everything
+	done, err = engine.StartReindex(ReindexRebuild, nil)
+	if err != nil {
+		t.Fatalf("Rebuild failed to start: %v", err)
+	}
+	<-done
+	if job := engine.LastReindex(); job.Error != "" || job.Total != 3 {
+		t.Errorf("Unexpected rebuild status %+v", job)
+	}
+	if health = engine.Health(); !health.Healthy || health.DocCount != 3 || health.Indexed != 3 {
+		t.Errorf("Expected a complete index after rebuilding, got %+v", health)
+	}
+	if ids := searchIDs(t, engine, "cache", map[string]string{"archived": "include"}); len(ids) != 2 {
+		t.Errorf("Expected active and archived cache todos after rebuilding, got %v", ids)
+	}
+
+	if err := engine.Compact(); err != nil {
+		t.Errorf("Compact failed: %v", err)
+	}
+}
+
+func TestStartupIndexesOnlyChangedFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	todosPath := filepath.Join(tempDir, ".claude", "todos", "2025", "01", "18")
+	indexPath := filepath.Join(tempDir, ".claude", "index", "todos.bleve")
+	path := func(id string) string { return filepath.Join(todosPath, id+".md") }
+
+	writeTodoFile(t, path("cache-eviction"), "cache-eviction", "Tune cache eviction", "in_progress")
+	writeTodoFile(t, path("login-timeout"), "login-timeout", "Fix login timeout", "in_progress")
+	writeTodoFile(t, path("old-flag"), "old-flag", "Remove legacy flag", "in_progress")
+
+	engine, err := NewEngine(indexPath, filepath.Join(tempDir, ".claude", "todos"))
+	if err != nil {
+		t.Fatalf("Failed to create search engine: %v", err)
+	}
+	engine.Close()
+
+	// An edit that keeps the modification time is invisible to the catch-up,
+	// which shows unchanged files are not read again
+	info, _ := os.Stat(path("cache-eviction"))
+	writeTodoFile(t, path("cache-eviction"), "cache-eviction", "Tune LRU cache eviction", "in_progress")
+	os.Chtimes(path("cache-eviction"), info.ModTime(), info.ModTime())
+
+	writeTodoFile(t, path("login-timeout"), "login-timeout", "Fix session login timeout", "in_progress")
+	writeTodoFile(t, path("dark-mode"), "dark-mode", "Add dark mode toggle", "in_progress")
+	os.Remove(path("old-flag"))
+
+	engine, err = NewEngine(indexPath, filepath.Join(tempDir, ".claude", "todos"))
+	if err != nil {
+		t.Fatalf("Failed to reopen search engine: %v", err)
+	}
+	defer engine.Close()
+
+	if ids := searchIDs(t, engine, "lru", nil); len(ids) != 0 {
+		t.Errorf("Expected the file with an unchanged time to be skipped, got %v", ids)
+	}
+	if ids := searchIDs(t, engine, "session", nil); len(ids) != 1 || ids[0] != "login-timeout" {
+		t.Errorf("Expected the edited todo to be re-indexed, got %v", ids)
+	}
+	if ids := searchIDs(t, engine, "dark", nil); len(ids) != 1 {
+		t.Errorf("Expected the new todo to be indexed, got %v", ids)
+	}
+	if count, _ := engine.GetIndexedCount(); count != 3 {
+		t.Errorf("Expected the deleted todo to be dropped, got %d documents", count)
+	}
+}
+
+func TestRebuildWaitsForCallsUsingTheIndex(t *testing.T) {
+	tempDir := t.TempDir()
+	todosPath := filepath.Join(tempDir, ".claude", "todos")
+	writeTodoFile(t, filepath.Join(todosPath, "2025", "01", "18", "cache-eviction.md"), "cache-eviction", "Tune cache eviction", "in_progress")
+
+	engine, err := NewEngine(filepath.Join(tempDir, ".claude", "index", "todos.bleve"), todosPath)
+	if err != nil {
+		t.Fatalf("Failed to create search engine: %v", err)
+	}
+	defer engine.Close()
+
+	inFlight := make(chan struct{})
+	release := make(chan struct{})
+	searched := make(chan error, 1)
+	go func() {
+		searched <- engine.withIndex(func(index bleve.Index) error {
+			close(inFlight)
+			<-release
+			_, err := index.DocCount()
+			return err
+		})
+	}()
+	<-inFlight
+
+	rebuilt := make(chan error, 1)
+	go func() { rebuilt <- engine.recreateIndex() }()
+	select {
+	case <-rebuilt:
+		t.Fatal("Expected the rebuild to wait for the call using the index")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-searched; err != nil {
+		t.Errorf("Expected the call to finish on the index it started with, got %v", err)
+	}
+	if err := <-rebuilt; err != nil {
+		t.Errorf("Rebuild failed: %v", err)
+	}
+}
+
+func TestIncrementalReindexRebuildsOlderMapping(t *testing.T) {
+	tempDir := t.TempDir()
+	todosPath := filepath.Join(tempDir, ".claude", "todos")
+	writeTodoFile(t, filepath.Join(todosPath, "2025", "01", "18", "cache-eviction.md"), "cache-eviction", "Tune cache eviction", "in_progress")
+
+	engine, err := NewEngine(filepath.Join(tempDir, ".claude", "index", "todos.bleve"), todosPath)
+	if err != nil {
+		t.Fatalf("Failed to create search engine: %v", err)
+	}
+	defer engine.Close()
+
+	engine.withIndex(func(index bleve.Index) error {
+		return index.SetInternal(mappingVersionKey, []byte("0"))
+	})
+	health := engine.Health()
+	if len(health.Outdated) != 1 || health.Indexed != 0 {
+		t.Errorf("Expected every entry to be outdated under an older mapping, got %+v", health)
+	}
+
+	done, err := engine.StartReindex(ReindexIncremental, nil)
+	if err != nil {
+		t.Fatalf("StartReindex failed: %v", err)
+	}
+	<-done
+
+	var current bool
+	engine.withIndex(func(index bleve.Index) error {
+		current = mappingCurrent(index)
+		return nil
+	})
+	if health := engine.Health(); !current || health.Indexed != 1 || health.Stale() != 0 {
+		t.Errorf("Expected the index rebuilt on the current mapping, got %+v", health)
+	}
+}
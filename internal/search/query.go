@@ -53,4 +53,16 @@ func sanitizeQuery(query string) string {
 	cleaned = strings.TrimSpace(cleaned)
 
 	return cleaned
-}
\ No newline at end of file
+}
+
+// splitTags splits a comma-separated tag filter into lowercase terms
+func splitTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,464 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+// The todo_search query language. Bare words are matched across the text
+// fields and ranked, as before. On top of that:
+//
+//	status:blocked priority:high type:bug tag:backend id:fix-login
+//	findings:timeout task:"race condition"   field-scoped text
+//	"race condition"                         exact phrase
+//	-flaky, NOT flaky                        exclusion
+//	a OR b, a AND b, (a OR b) c              boolean operators and grouping
+//	started:>2026-01-01 completed:<=2026-03-31 started:2026-02
+//
+// Queries are built from bleve query objects only; user input never reaches
+// bleve's query string syntax.
+
+// fieldBoosts ranks matches in the text fields, title first
+var fieldBoosts = map[string]float64{
+	"task":     3.0,
+	"findings": 1.5,
+	"tests":    1.0,
+	"content":  0.5,
+}
+
+// defaultTextFields are searched by unqualified terms
+var defaultTextFields = []string{"task", "findings", "tests", "content"}
+
+type fieldKind int
+
+const (
+	textField fieldKind = iota
+	keywordField
+	dateField
+)
+
+// queryFields maps the qualifiers users may type to index fields
+var queryFields = map[string]struct {
+	name string
+	kind fieldKind
+}{
+	"task":      {"task", textField},
+	"title":     {"task", textField},
+	"findings":  {"findings", textField},
+	"tests":     {"tests", textField},
+	"content":   {"content", textField},
+	"status":    {"status", keywordField},
+	"priority":  {"priority", keywordField},
+	"type":      {"type", keywordField},
+	"tag":       {"tags", keywordField},
+	"tags":      {"tags", keywordField},
+	"id":        {"id", keywordField},
+	"started":   {"started", dateField},
+	"completed": {"completed", dateField},
+	"archived":  {"archived_at", dateField},
+}
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokPhrase
+	tokLParen
+	tokRParen
+	tokNot
+)
+
+type token struct {
+	kind  tokenKind
+	field string // qualifier of a field:"phrase"
+	text  string
+}
+
+// parseSearchQuery turns a todo_search query into a bleve query. It returns
+// nil when the query holds nothing searchable.
+func parseSearchQuery(input string, textFields []string) (query.Query, error) {
+	if len(textFields) == 0 {
+		textFields = defaultTextFields
+	}
+	p := &queryParser{tokens: lexQuery(input), fields: textFields}
+
+	var clauses []query.Query
+	for !p.done() {
+		q, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if q != nil {
+			clauses = append(clauses, q)
+		}
+		// A stray ")" ends parseOr early; skip it and keep going
+		if !p.done() && p.peek().kind == tokRParen {
+			p.pos++
+		}
+	}
+	return conjunction(clauses), nil
+}
+
+// scopeFields maps todo_search's scope values onto text fields
+func scopeFields(scope string) []string {
+	var fields []string
+	for _, s := range strings.Split(scope, ",") {
+		switch s = strings.ToLower(strings.TrimSpace(s)); s {
+		case "all":
+			return defaultTextFields
+		case "task", "findings", "tests", "content":
+			fields = append(fields, s)
+		}
+	}
+	return fields
+}
+
+func isQuerySpace(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsControl(r)
+}
+
+// startsClause reports whether r can follow a "-" used for exclusion
+func startsClause(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '"' || r == '('
+}
+
+// lexQuery splits a query into words, phrases, parentheses and "-" markers.
+// Anything unbalanced is read leniently rather than rejected.
+func lexQuery(input string) []token {
+	runes := []rune(input)
+	var tokens []token
+	depth := 0
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case isQuerySpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokLParen})
+			depth++
+			i++
+		case r == ')' && depth > 0:
+			tokens = append(tokens, token{kind: tokRParen})
+			depth--
+			i++
+		case r == '-' && i+1 < len(runes) && startsClause(runes[i+1]):
+			tokens = append(tokens, token{kind: tokNot})
+			i++
+		case r == '"':
+			text, next := readPhrase(runes, i+1)
+			tokens = append(tokens, token{kind: tokPhrase, text: text})
+			i = next
+		default:
+			start := i
+			for i < len(runes) && !isQuerySpace(runes[i]) && !(runes[i] == ')' && depth > 0) {
+				if runes[i] == ':' && i+1 < len(runes) && runes[i+1] == '"' {
+					break
+				}
+				i++
+			}
+			word := string(runes[start:i])
+
+			// field:"phrase"
+			if i < len(runes) && runes[i] == ':' {
+				if _, ok := queryFields[strings.ToLower(word)]; ok {
+					text, next := readPhrase(runes, i+2)
+					tokens = append(tokens, token{kind: tokPhrase, field: strings.ToLower(word), text: text})
+					i = next
+					continue
+				}
+				i++ // unknown qualifier: keep the word, read the phrase on its own
+			}
+			tokens = append(tokens, token{kind: tokWord, text: word})
+		}
+	}
+	return tokens
+}
+
+// readPhrase reads up to the closing quote, or the end of input if there is none
+func readPhrase(runes []rune, start int) (string, int) {
+	for i := start; i < len(runes); i++ {
+		if runes[i] == '"' {
+			return string(runes[start:i]), i + 1
+		}
+	}
+	return string(runes[start:]), len(runes)
+}
+
+type queryParser struct {
+	tokens []token
+	pos    int
+	fields []string
+}
+
+func (p *queryParser) done() bool  { return p.pos >= len(p.tokens) }
+func (p *queryParser) peek() token { return p.tokens[p.pos] }
+
+func (p *queryParser) isKeyword(tok token, keyword string) bool {
+	return tok.kind == tokWord && tok.text == keyword
+}
+
+// parseOr parses clauses separated by OR
+func (p *queryParser) parseOr() (query.Query, error) {
+	var alternatives []query.Query
+	for {
+		q, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if q != nil {
+			alternatives = append(alternatives, q)
+		}
+		if p.done() || !p.isKeyword(p.peek(), "OR") {
+			break
+		}
+		p.pos++
+	}
+
+	switch len(alternatives) {
+	case 0:
+		return nil, nil
+	case 1:
+		return alternatives[0], nil
+	default:
+		return bleve.NewDisjunctionQuery(alternatives...), nil
+	}
+}
+
+// parseAnd parses a run of clauses that must all hold. Adjacent bare words
+// are matched together so plain multi-word searches rank as they always did;
+// an explicit AND between them makes each one required.
+func (p *queryParser) parseAnd() (query.Query, error) {
+	var must, mustNot []query.Query
+	var words []string
+	flush := func() {
+		if len(words) > 0 {
+			must = append(must, textQuery(strings.Join(words, " "), p.fields, false))
+			words = nil
+		}
+	}
+
+	for !p.done() {
+		tok := p.peek()
+		if tok.kind == tokRParen || p.isKeyword(tok, "OR") {
+			break
+		}
+		if p.isKeyword(tok, "AND") {
+			p.pos++
+			flush()
+			continue
+		}
+
+		negated := false
+		for !p.done() && (p.peek().kind == tokNot || p.isKeyword(p.peek(), "NOT")) {
+			negated = !negated
+			p.pos++
+		}
+		if p.done() {
+			break
+		}
+		tok = p.peek()
+
+		if !negated && tok.kind == tokWord {
+			if _, _, qualified := splitQualifier(tok.text); !qualified {
+				if text := sanitizeQuery(tok.text); hasSearchableText(text) {
+					words = append(words, text)
+				}
+				p.pos++
+				continue
+			}
+		}
+
+		flush()
+		q, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		if q == nil {
+			continue
+		}
+		if negated {
+			mustNot = append(mustNot, q)
+		} else {
+			must = append(must, q)
+		}
+	}
+	flush()
+
+	if len(mustNot) == 0 {
+		return conjunction(must), nil
+	}
+	b := bleve.NewBooleanQuery()
+	if len(must) == 0 {
+		b.AddMust(bleve.NewMatchAllQuery())
+	} else {
+		b.AddMust(must...)
+	}
+	b.AddMustNot(mustNot...)
+	return b, nil
+}
+
+// parsePrimary parses a group, a phrase or a single word
+func (p *queryParser) parsePrimary() (query.Query, error) {
+	tok := p.peek()
+	p.pos++
+
+	switch tok.kind {
+	case tokLParen:
+		q, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.done() && p.peek().kind == tokRParen {
+			p.pos++
+		}
+		return q, nil
+	case tokPhrase:
+		if tok.field != "" {
+			return fieldQuery(tok.field, tok.text, true)
+		}
+		text := sanitizeQuery(tok.text)
+		if !hasSearchableText(text) {
+			return nil, nil
+		}
+		return textQuery(text, p.fields, true), nil
+	case tokWord:
+		if field, value, ok := splitQualifier(tok.text); ok {
+			return fieldQuery(field, value, false)
+		}
+		text := sanitizeQuery(tok.text)
+		if !hasSearchableText(text) {
+			return nil, nil
+		}
+		return textQuery(text, p.fields, false), nil
+	}
+	return nil, nil
+}
+
+// splitQualifier splits "field:value" when field is one we know
+func splitQualifier(word string) (string, string, bool) {
+	idx := strings.Index(word, ":")
+	if idx <= 0 || idx == len(word)-1 {
+		return "", "", false
+	}
+	field := strings.ToLower(word[:idx])
+	if _, ok := queryFields[field]; !ok {
+		return "", "", false
+	}
+	return field, word[idx+1:], true
+}
+
+// fieldQuery builds the query for one field:value clause
+func fieldQuery(qualifier, value string, phrase bool) (query.Query, error) {
+	field := queryFields[qualifier]
+
+	switch field.kind {
+	case keywordField:
+		value = strings.ToLower(strings.TrimSpace(value))
+		if field.name == "tags" {
+			value = strings.TrimPrefix(value, "#")
+		}
+		if value == "" {
+			return nil, nil
+		}
+		q := bleve.NewTermQuery(value)
+		q.SetField(field.name)
+		return q, nil
+	case dateField:
+		return dateQuery(qualifier, field.name, value)
+	default:
+		text := sanitizeQuery(value)
+		if !hasSearchableText(text) {
+			return nil, nil
+		}
+		return textQuery(text, []string{field.name}, phrase), nil
+	}
+}
+
+// textQuery matches text (or the exact phrase) in any of fields
+func textQuery(text string, fields []string, phrase bool) query.Query {
+	var queries []query.Query
+	for _, field := range fields {
+		if phrase {
+			q := bleve.NewMatchPhraseQuery(text)
+			q.SetField(field)
+			q.SetBoost(fieldBoosts[field])
+			queries = append(queries, q)
+		} else {
+			q := bleve.NewMatchQuery(text)
+			q.SetField(field)
+			q.SetBoost(fieldBoosts[field])
+			queries = append(queries, q)
+		}
+	}
+	if len(queries) == 1 {
+		return queries[0]
+	}
+	// Disjunction gives "best match" across fields
+	return bleve.NewDisjunctionQuery(queries...)
+}
+
+// dateQuery handles >, >=, <, <= and a bare date, where a date is a day
+// (YYYY-MM-DD) or a whole month (YYYY-MM), in UTC
+func dateQuery(qualifier, field, value string) (query.Query, error) {
+	op := ""
+	for _, prefix := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(value, prefix) {
+			op = prefix
+			value = value[len(prefix):]
+			break
+		}
+	}
+
+	period, err := time.ParseInLocation("2006-01-02", value, time.UTC)
+	next := period.AddDate(0, 0, 1)
+	if err != nil {
+		period, err = time.ParseInLocation("2006-01", value, time.UTC)
+		next = period.AddDate(0, 1, 0)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q for %s: use YYYY-MM-DD or YYYY-MM, optionally prefixed with >, >=, < or <=", value, qualifier)
+	}
+
+	var start, end time.Time
+	switch op {
+	case ">":
+		start = next
+	case ">=":
+		start = period
+	case "<":
+		end = period
+	case "<=":
+		end = next
+	default:
+		start, end = period, next
+	}
+
+	inclusive, exclusive := true, false
+	q := bleve.NewDateRangeInclusiveQuery(start, end, &inclusive, &exclusive)
+	q.SetField(field)
+	return q, nil
+}
+
+// hasSearchableText reports whether sanitized text has a letter or digit left
+func hasSearchableText(text string) bool {
+	return strings.IndexFunc(text, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}) >= 0
+}
+
+func conjunction(queries []query.Query) query.Query {
+	switch len(queries) {
+	case 0:
+		return nil
+	case 1:
+		return queries[0]
+	default:
+		return bleve.NewConjunctionQuery(queries...)
+	}
+}
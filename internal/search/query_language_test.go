@@ -0,0 +1,94 @@
+package search
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/user/mcp-todo-server/internal/domain"
+)
+
+func TestSearchQueryLanguage(t *testing.T) {
+	tempDir := t.TempDir()
+	engine, err := NewEngine(filepath.Join(tempDir, "index", "todos.bleve"), filepath.Join(tempDir, "todos"))
+	if err != nil {
+		t.Fatalf("Failed to create search engine: %v", err)
+	}
+	defer engine.Close()
+
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d.Add(12 * time.Hour)
+	}
+	todos := []struct {
+		todo     *domain.Todo
+		findings string
+	}{
+		{&domain.Todo{ID: "cache-race", Task: "Fix race condition in cache", Status: "blocked", Priority: "high", Type: "bug", Tags: []string{"backend"}, Started: day("2026-02-10")},
+			"Eviction times out under load"},
+		{&domain.Todo{ID: "flaky-login", Task: "Flaky login test", Status: "in_progress", Priority: "high", Type: "bug", Started: day("2026-01-05")},
+			"The condition is a race between two timers"},
+		{&domain.Todo{ID: "cache-docs", Task: "Document cache settings", Status: "in_progress", Priority: "low", Type: "feature", Tags: []string{"docs"}, Started: day("2025-12-20")},
+			"Nothing surprising"},
+	}
+	for _, tt := range todos {
+		content := "# Task: " + tt.todo.Task + "\n\n## Findings & Research\n\n" + tt.findings + "\n"
+		if err := engine.Index(tt.todo, content); err != nil {
+			t.Fatalf("Failed to index %s: %v", tt.todo.ID, err)
+		}
+	}
+
+	tests := []struct {
+		query   string
+		filters map[string]string
+		want    []string
+	}{
+		{"cache", nil, []string{"cache-docs", "cache-race"}},
+		{"status:blocked", nil, []string{"cache-race"}},
+		{"priority:high type:bug", nil, []string{"cache-race", "flaky-login"}},
+		{`"race condition"`, nil, []string{"cache-race"}},
+		{"race condition", nil, []string{"cache-race", "flaky-login"}},
+		{"race -flaky", nil, []string{"cache-race"}},
+		{"race NOT flaky", nil, []string{"cache-race"}},
+		{"findings:eviction", nil, []string{"cache-race"}},
+		{"task:eviction", nil, []string{}},
+		{`task:"login test"`, nil, []string{"flaky-login"}},
+		{"tag:docs OR tag:backend", nil, []string{"cache-docs", "cache-race"}},
+		{"cache AND settings", nil, []string{"cache-docs"}},
+		{"(login OR settings) priority:low", nil, []string{"cache-docs"}},
+		{"started:>2026-01-05", nil, []string{"cache-race"}},
+		{"started:>=2026-01-05", nil, []string{"cache-race", "flaky-login"}},
+		{"started:<2026-01-01", nil, []string{"cache-docs"}},
+		{"started:2026-01-05", nil, []string{"flaky-login"}},
+		{"-status:blocked", nil, []string{"cache-docs", "flaky-login"}},
+		{"unknown:cache", nil, []string{"cache-docs", "cache-race"}},
+		{"race", map[string]string{"scope": "task"}, []string{"cache-race"}},
+		{"race", map[string]string{"scope": "findings"}, []string{"flaky-login"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got := searchIDs(t, engine, tt.query, tt.filters)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+
+	if _, err := engine.Search("started:>yesterday", nil, 10); err == nil {
+		t.Error("Expected an invalid date to fail")
+	}
+}
+
+func TestLexQueryIsLenient(t *testing.T) {
+	for _, input := range []string{`"unclosed phrase`, "(unclosed group", "stray)", "()", "-", "NOT", "OR AND", `status:""`} {
+		if _, err := parseSearchQuery(input, nil); err != nil {
+			t.Errorf("Expected %q to parse, got %v", input, err)
+		}
+	}
+}
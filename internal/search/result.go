@@ -1,9 +1,16 @@
 package search
 
+import domainSearch "github.com/user/mcp-todo-server/internal/domain/search"
+
 // Result represents a search result
 type Result struct {
 	ID      string
 	Task    string
 	Score   float64
 	Snippet string
+
+	Archived    bool
+	ArchivePath string // relative to the project, set for archived todos
+
+	Highlights []domainSearch.Highlight
 }
\ No newline at end of file
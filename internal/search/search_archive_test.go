@@ -0,0 +1,124 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/user/mcp-todo-server/internal/domain"
+)
+
+func writeTodoFile(t *testing.T, path, id, task, status string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	content := "---\ntodo_id: " + id + "\ntask: " + task + "\nstarted: 2025-01-18T10:00:00Z\n"
+	if status == "completed" {
+		content += "completed: 2025-01-19T10:00:00Z\n"
+	}
+	content += "status: " + status + "\npriority: high\ntype: feature\n---\n\n# Task: " + task + "\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", id, err)
+	}
+}
+
+func searchIDs(t *testing.T, engine *Engine, query string, filters map[string]string) []string {
+	t.Helper()
+	results, err := engine.Search(query, filters, 10)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	var ids []string
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestSearchArchivedTodos(t *testing.T) {
+	tempDir := t.TempDir()
+	todosPath := filepath.Join(tempDir, ".claude", "todos")
+	archivePath := filepath.Join(tempDir, ".claude", "archive")
+
+	writeTodoFile(t, filepath.Join(todosPath, "2025", "01", "18", "active-cache.md"), "active-cache", "Tune cache eviction", "in_progress")
+	writeTodoFile(t, filepath.Join(archivePath, "2025", "01", "18", "old-cache.md"), "old-cache", "Add cache layer", "completed")
+
+	engine, err := NewEngine(filepath.Join(tempDir, ".claude", "index", "todos.bleve"), todosPath)
+	if err != nil {
+		t.Fatalf("Failed to create search engine: %v", err)
+	}
+	defer engine.Close()
+
+	tests := []struct {
+		name    string
+		filters map[string]string
+		want    []string
+	}{
+		{"active only by default", nil, []string{"active-cache"}},
+		{"include archived", map[string]string{"archived": "include"}, []string{"active-cache", "old-cache"}},
+		{"archived only", map[string]string{"archived": "only"}, []string{"old-cache"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := searchIDs(t, engine, "cache", tt.filters)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+
+	results, err := engine.Search("cache", map[string]string{"archived": "only"}, 10)
+	if err != nil || len(results) != 1 {
+		t.Fatalf("Expected one archived result, got %v (err %v)", results, err)
+	}
+	if !results[0].Archived {
+		t.Error("Expected result to be flagged as archived")
+	}
+	if want := ".claude/archive/2025/01/18/old-cache.md"; results[0].ArchivePath != want {
+		t.Errorf("Expected archive path %q, got %q", want, results[0].ArchivePath)
+	}
+
+	if _, err := engine.Search("cache", map[string]string{"archived": "sometimes"}, 10); err == nil {
+		t.Error("Expected unknown archived mode to fail")
+	}
+}
+
+func TestIndexArchivedReplacesActiveDocument(t *testing.T) {
+	tempDir := t.TempDir()
+	engine, err := NewEngine(filepath.Join(tempDir, "index", "todos.bleve"), filepath.Join(tempDir, "todos"))
+	if err != nil {
+		t.Fatalf("Failed to create search engine: %v", err)
+	}
+	defer engine.Close()
+
+	todo := &domain.Todo{ID: "ship-login", Task: "Ship login page", Status: "in_progress", Started: time.Now()}
+	if err := engine.Index(todo, "# Task: Ship login page"); err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	todo.Status = "completed"
+	todo.Completed = time.Now()
+	if err := engine.IndexArchived(todo, "# Task: Ship login page", filepath.Join(".claude", "archive", "2025", "01", "18", "ship-login.md")); err != nil {
+		t.Fatalf("Failed to index archived todo: %v", err)
+	}
+
+	if got := searchIDs(t, engine, "login", nil); len(got) != 0 {
+		t.Errorf("Expected archived todo to drop out of default search, got %v", got)
+	}
+	results, err := engine.Search("login", map[string]string{"archived": "include"}, 10)
+	if err != nil || len(results) != 1 {
+		t.Fatalf("Expected one result, got %v (err %v)", results, err)
+	}
+	if !results[0].Archived || results[0].ArchivePath != ".claude/archive/2025/01/18/ship-login.md" {
+		t.Errorf("Unexpected archive fields: %+v", results[0])
+	}
+}
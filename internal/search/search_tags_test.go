@@ -0,0 +1,99 @@
+package search
+
+import (
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/user/mcp-todo-server/internal/domain"
+)
+
+func TestSearchTagFilters(t *testing.T) {
+	tempDir := t.TempDir()
+	engine, err := NewEngine(filepath.Join(tempDir, "index", "todos.bleve"), filepath.Join(tempDir, "todos"))
+	if err != nil {
+		t.Fatalf("Failed to create search engine: %v", err)
+	}
+	defer engine.Close()
+
+	todos := []*domain.Todo{
+		{ID: "auth-api", Task: "Add token refresh", Status: "in_progress", Tags: []string{"backend", "area/auth"}},
+		{ID: "login-page", Task: "Redesign login page", Status: "in_progress", Tags: []string{"frontend", "area/auth"}},
+		{ID: "metrics", Task: "Export metrics", Status: "in_progress", Tags: []string{"Backend"}}, // hand-edited case
+		{ID: "untagged", Task: "Tidy up", Status: "in_progress"},
+	}
+	for _, todo := range todos {
+		todo.Started = time.Now()
+		if err := engine.Index(todo, "# Task: "+todo.Task); err != nil {
+			t.Fatalf("Failed to index %s: %v", todo.ID, err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		filters map[string]string
+		want    []string
+	}{
+		{"all tags", map[string]string{"tags": "backend,area/auth"}, []string{"auth-api"}},
+		{"namespaced tag kept whole", map[string]string{"tags": "area/auth"}, []string{"auth-api", "login-page"}},
+		{"any tags", map[string]string{"any_tags": "frontend,backend"}, []string{"auth-api", "login-page", "metrics"}},
+		{"case-insensitive", map[string]string{"tags": "Backend"}, []string{"auth-api", "metrics"}},
+		{"combined", map[string]string{"tags": "area/auth", "any_tags": "frontend"}, []string{"login-page"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := engine.Search("", tt.filters, 10)
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			var got []string
+			for _, r := range results {
+				got = append(got, r.ID)
+			}
+			sort.Strings(got)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestEngineRebuildsIndexWithOlderMapping(t *testing.T) {
+	tempDir := t.TempDir()
+	indexPath := filepath.Join(tempDir, "index", "todos.bleve")
+	todosPath := filepath.Join(tempDir, "todos")
+
+	// An index from before tags were keyword fields, with no mapping version
+	old, err := bleve.New(indexPath, bleve.NewIndexMapping())
+	if err != nil {
+		t.Fatalf("Failed to create old index: %v", err)
+	}
+	old.Index("stale", map[string]interface{}{"task": "Stale entry", "tags": []string{"area/auth"}})
+	old.Close()
+
+	engine, err := NewEngine(indexPath, todosPath)
+	if err != nil {
+		t.Fatalf("Failed to open search engine: %v", err)
+	}
+	defer engine.Close()
+
+	todo := &domain.Todo{ID: "auth-api", Task: "Add token refresh", Status: "in_progress", Started: time.Now(), Tags: []string{"area/auth"}}
+	if err := engine.Index(todo, "# Task: "+todo.Task); err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	if ids := searchIDs(t, engine, "", map[string]string{"tags": "area/auth"}); len(ids) != 1 || ids[0] != "auth-api" {
+		t.Errorf("Expected the rebuilt index to match the whole tag, got %v", ids)
+	}
+	if ids := searchIDs(t, engine, "", map[string]string{"tags": "auth"}); len(ids) != 0 {
+		t.Errorf("Expected tags not to be split into terms, got %v", ids)
+	}
+}
@@ -0,0 +1,221 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/standard"
+	"github.com/blevesearch/bleve/v2/search/query"
+	index "github.com/blevesearch/bleve_index_api"
+
+	domainSearch "github.com/user/mcp-todo-server/internal/domain/search"
+)
+
+// similarTerms is how many of the most distinctive terms drive the
+// candidate query
+const similarTerms = 25
+
+// similarCandidates is the minimum number of candidates re-ranked by cosine
+const similarCandidates = 20
+
+// similarFields are compared between todos; the title counts double
+var similarFields = map[string]int{"task": 2, "findings": 1, "tests": 1}
+
+// termVector maps analysed terms to their tf-idf weight
+type termVector map[string]float64
+
+// SimilarTodos returns the indexed todos most like the todo with the given
+// ID, archived ones included. Scores are cosine similarities in [0, 1].
+func (e *Engine) SimilarTodos(id string, limit int) ([]domainSearch.Result, error) {
+	req := bleve.NewSearchRequest(bleve.NewDocIDQuery([]string{id}))
+	req.Fields = []string{"task", "findings", "tests"}
+
+	var res *bleve.SearchResult
+	err := e.circuitBreaker.Execute(context.Background(), func() error {
+		return e.withIndex(func(idx bleve.Index) error {
+			var searchErr error
+			res, searchErr = idx.Search(req)
+			return searchErr
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load todo %s: %w", id, err)
+	}
+	if len(res.Hits) == 0 {
+		return nil, fmt.Errorf("todo %s is not in the search index", id)
+	}
+
+	return e.similar(res.Hits[0].Fields, id, limit)
+}
+
+// SimilarToText returns the indexed todos most like free text, archived
+// ones included
+func (e *Engine) SimilarToText(text string, limit int) ([]domainSearch.Result, error) {
+	return e.similar(map[string]interface{}{"task": text}, "", limit)
+}
+
+// similar finds candidates with a query built from the source's most
+// distinctive terms, then re-ranks them by cosine similarity of tf-idf vectors
+func (e *Engine) similar(source map[string]interface{}, excludeID string, limit int) ([]domainSearch.Result, error) {
+	var results []domainSearch.Result
+	err := e.withIndex(func(idx bleve.Index) error {
+		var err error
+		results, err = e.similarIn(idx, source, excludeID, limit)
+		return err
+	})
+	return results, err
+}
+
+// similarIn is similar on an index the caller holds; the reader it opens
+// is used throughout
+func (e *Engine) similarIn(idx bleve.Index, source map[string]interface{}, excludeID string, limit int) ([]domainSearch.Result, error) {
+	advanced, err := idx.Advanced()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index: %w", err)
+	}
+	reader, err := advanced.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index reader: %w", err)
+	}
+	defer reader.Close()
+
+	docCount, err := reader.DocCount()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count documents: %w", err)
+	}
+
+	analyzer := idx.Mapping().AnalyzerNamed(standard.Name)
+	idf := make(map[string]float64)
+	sourceVector := e.termVector(source, analyzer, reader, docCount, idf)
+	if len(sourceVector) == 0 {
+		return []domainSearch.Result{}, nil
+	}
+
+	candidateQuery := moreLikeThisQuery(sourceVector)
+	if excludeID != "" {
+		withoutSource := bleve.NewBooleanQuery()
+		withoutSource.AddMust(candidateQuery)
+		withoutSource.AddMustNot(bleve.NewDocIDQuery([]string{excludeID}))
+		candidateQuery = withoutSource
+	}
+
+	req := bleve.NewSearchRequest(candidateQuery)
+	req.Size = limit * 3
+	if req.Size < similarCandidates {
+		req.Size = similarCandidates
+	}
+	req.Fields = []string{"task", "findings", "tests", "archived", "archive_path"}
+
+	var res *bleve.SearchResult
+	err = e.circuitBreaker.Execute(context.Background(), func() error {
+		var searchErr error
+		res, searchErr = idx.Search(req)
+		return searchErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("similarity search failed: %w", err)
+	}
+
+	results := []domainSearch.Result{}
+	for _, hit := range res.Hits {
+		score := cosine(sourceVector, e.termVector(hit.Fields, analyzer, reader, docCount, idf))
+		if score <= 0 {
+			continue
+		}
+		result := domainSearch.Result{ID: hit.ID, Score: score}
+		result.Task, _ = hit.Fields["task"].(string)
+		result.Archived, _ = hit.Fields["archived"].(bool)
+		result.ArchivePath, _ = hit.Fields["archive_path"].(string)
+		results = append(results, result)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// termVector weighs the analysed terms of a todo's fields by tf-idf, with
+// document frequencies taken from the content field, which holds every term.
+// idf caches lookups across calls.
+func (e *Engine) termVector(fields map[string]interface{}, analyzer analysis.Analyzer, reader index.IndexReader, docCount uint64, idf map[string]float64) termVector {
+	vector := make(termVector)
+
+	for field, weight := range similarFields {
+		text, _ := fields[field].(string)
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		for _, token := range analyzer.Analyze([]byte(text)) {
+			vector[string(token.Term)] += float64(weight)
+		}
+	}
+
+	for term, tf := range vector {
+		weight, ok := idf[term]
+		if !ok {
+			var df uint64
+			if tfr, err := reader.TermFieldReader(context.Background(), []byte(term), "content", false, false, false); err == nil {
+				df = tfr.Count()
+				tfr.Close()
+			}
+			weight = math.Log(1 + float64(docCount+1)/float64(df+1))
+			idf[term] = weight
+		}
+		vector[term] = (1 + math.Log(tf)) * weight
+	}
+	return vector
+}
+
+// moreLikeThisQuery matches any of the vector's heaviest terms in the
+// compared fields, boosted by their weight
+func moreLikeThisQuery(vector termVector) query.Query {
+	terms := make([]string, 0, len(vector))
+	for term := range vector {
+		terms = append(terms, term)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if vector[terms[i]] == vector[terms[j]] {
+			return terms[i] < terms[j]
+		}
+		return vector[terms[i]] > vector[terms[j]]
+	})
+	if len(terms) > similarTerms {
+		terms = terms[:similarTerms]
+	}
+
+	var queries []query.Query
+	for _, term := range terms {
+		for field := range similarFields {
+			q := bleve.NewTermQuery(term)
+			q.SetField(field)
+			q.SetBoost(vector[term])
+			queries = append(queries, q)
+		}
+	}
+	return bleve.NewDisjunctionQuery(queries...)
+}
+
+// cosine is the cosine similarity of two term vectors
+func cosine(a, b termVector) float64 {
+	var dot, normA, normB float64
+	for term, weight := range a {
+		normA += weight * weight
+		dot += weight * b[term]
+	}
+	for _, weight := range b {
+		normB += weight * weight
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,88 @@
+package search
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/user/mcp-todo-server/internal/domain"
+)
+
+func TestSimilarTodos(t *testing.T) {
+	tempDir := t.TempDir()
+	engine, err := NewEngine(filepath.Join(tempDir, "index", "todos.bleve"), filepath.Join(tempDir, "todos"))
+	if err != nil {
+		t.Fatalf("Failed to create search engine: %v", err)
+	}
+	defer engine.Close()
+
+	todos := []struct {
+		todo     *domain.Todo
+		findings string
+		tests    string
+		archived string
+	}{
+		{&domain.Todo{ID: "checkout-session", Task: "Session expires during checkout"},
+			"The session cookie max-age is shorter than the payment redirect timeout.",
+			"TestCheckoutKeepsSession", ""},
+		{&domain.Todo{ID: "login-session", Task: "Login session expires too early"},
+			"Session cookie max-age was set to 5 minutes instead of 5 hours.",
+			"TestSessionCookieMaxAge", ".claude/archive/2026/01/10/login-session.md"},
+		{&domain.Todo{ID: "dark-mode", Task: "Add dark mode toggle"},
+			"Use CSS variables for the theme colors.",
+			"TestThemeToggle", ""},
+		{&domain.Todo{ID: "payment-retry", Task: "Retry failed payment webhooks"},
+			"Webhooks time out when the payment provider is slow.",
+			"TestWebhookRetry", ""},
+	}
+	for _, tt := range todos {
+		tt.todo.Started = time.Now()
+		tt.todo.Completed = time.Now()
+		content := "# Task: " + tt.todo.Task + "\n\n## Findings & Research\n\n" + tt.findings + "\n\n## Test Cases\n\n" + tt.tests + "\n"
+		if tt.archived != "" {
+			err = engine.IndexArchived(tt.todo, content, tt.archived)
+		} else {
+			err = engine.Index(tt.todo, content)
+		}
+		if err != nil {
+			t.Fatalf("Failed to index %s: %v", tt.todo.ID, err)
+		}
+	}
+
+	results, err := engine.SimilarTodos("checkout-session", 5)
+	if err != nil {
+		t.Fatalf("SimilarTodos failed: %v", err)
+	}
+	if len(results) == 0 || results[0].ID != "login-session" {
+		t.Fatalf("Expected the archived login-session todo first, got %+v", results)
+	}
+	if !results[0].Archived || results[0].ArchivePath != ".claude/archive/2026/01/10/login-session.md" {
+		t.Errorf("Expected archive details on the archived match, got %+v", results[0])
+	}
+	for i, r := range results {
+		if r.ID == "checkout-session" {
+			t.Error("Expected the source todo to be left out")
+		}
+		if r.ID == "dark-mode" {
+			t.Errorf("Expected unrelated todo to be left out, got %+v", r)
+		}
+		if r.Score <= 0 || r.Score > 1 || (i > 0 && r.Score > results[i-1].Score) {
+			t.Errorf("Expected descending scores in (0, 1], got %+v", results)
+		}
+	}
+
+	results, err = engine.SimilarToText("checkout session keeps expiring", 1)
+	if err != nil {
+		t.Fatalf("SimilarToText failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "checkout-session" {
+		t.Errorf("Expected checkout-session for free text, got %+v", results)
+	}
+
+	if _, err := engine.SimilarTodos("missing", 5); err == nil {
+		t.Error("Expected an unindexed todo to fail")
+	}
+	if results, err := engine.SimilarToText("the and of", 5); err != nil || len(results) != 0 {
+		t.Errorf("Expected stop words alone to match nothing, got %+v (err %v)", results, err)
+	}
+}
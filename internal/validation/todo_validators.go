@@ -24,6 +24,8 @@ const (
 	FormatFull    = "full"
 	FormatSummary = "summary"
 	FormatList    = "list"
+	FormatMermaid = "mermaid"
+	FormatDOT     = "dot"
 )
 
 // Operation constants
@@ -47,7 +49,8 @@ func IsValidTodoType(t string) bool {
 
 // IsValidFormat validates format values
 func IsValidFormat(f string) bool {
-	return f == FormatFull || f == FormatSummary || f == FormatList
+	return f == FormatFull || f == FormatSummary || f == FormatList ||
+		f == FormatMermaid || f == FormatDOT
 }
 
 // IsValidOperation validates operation values
@@ -67,7 +70,7 @@ func GetValidTodoTypes() []string {
 
 // GetValidFormats returns all valid format values
 func GetValidFormats() []string {
-	return []string{FormatFull, FormatSummary, FormatList}
+	return []string{FormatFull, FormatSummary, FormatList, FormatMermaid, FormatDOT}
 }
 
 // GetValidOperations returns all valid operation values
@@ -1,14 +1,17 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
+	"github.com/user/mcp-todo-server/internal/infrastructure/persistence/sqlite"
 	"github.com/user/mcp-todo-server/internal/lock"
 	"github.com/user/mcp-todo-server/internal/logging"
 	"github.com/user/mcp-todo-server/server"
@@ -53,6 +56,9 @@ func main() {
 		httpReadTimeout  = flag.Duration("http-read-timeout", 60*time.Second, "HTTP server read timeout (default: 60s)")
 		httpWriteTimeout = flag.Duration("http-write-timeout", 60*time.Second, "HTTP server write timeout (default: 60s)")
 		httpIdleTimeout  = flag.Duration("http-idle-timeout", 120*time.Second, "HTTP server idle timeout (default: 120s)")
+		importDB         = flag.String("import-db", "", "Import ./.claude todos and archive into this SQLite database, then exit")
+		exportDB         = flag.String("export-db", "", "Export todos from this SQLite database into ./.claude, then exit")
+		adminToken       = flag.String("admin-token", "", "Bearer token for the /admin/index HTTP endpoint (default: endpoint disabled)")
 	)
 	flag.Parse()
 
@@ -62,6 +68,14 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle one-off migrations between the markdown tree and SQLite
+	if *importDB != "" || *exportDB != "" {
+		if err := runDatabaseBridge(*importDB, *exportDB); err != nil {
+			log.Fatalf("Database migration failed: %v", err)
+		}
+		os.Exit(0)
+	}
+
 	// Check environment variable for auto-archive override
 	if envNoAutoArchive := os.Getenv("CLAUDE_TODO_NO_AUTO_ARCHIVE"); envNoAutoArchive != "" {
 		if envNoAutoArchive == "true" || envNoAutoArchive == "1" {
@@ -97,6 +111,7 @@ func main() {
 		server.WithHTTPReadTimeout(*httpReadTimeout),
 		server.WithHTTPWriteTimeout(*httpWriteTimeout),
 		server.WithHTTPIdleTimeout(*httpIdleTimeout),
+		server.WithAdminToken(*adminToken),
 	)
 	if err != nil {
 		if serverLock != nil {
@@ -156,3 +171,39 @@ func main() {
 		os.Exit(0)
 	}
 }
+
+// runDatabaseBridge copies todos between the ./.claude markdown tree and a
+// SQLite database
+func runDatabaseBridge(importPath, exportPath string) error {
+	if importPath != "" && exportPath != "" {
+		return fmt.Errorf("-import-db and -export-db cannot be used together")
+	}
+
+	dbPath := importPath
+	if dbPath == "" {
+		dbPath = exportPath
+	}
+	repo, err := sqlite.NewTodoRepository(dbPath)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	claudeDir := filepath.Join(".", ".claude")
+	ctx := context.Background()
+	if importPath != "" {
+		count, err := repo.ImportMarkdown(ctx, claudeDir)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Imported %d todos from %s into %s\n", count, claudeDir, dbPath)
+		return nil
+	}
+
+	count, err := repo.ExportMarkdown(ctx, claudeDir)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d todos from %s into %s\n", count, dbPath, claudeDir)
+	return nil
+}
@@ -90,7 +90,7 @@ func TestListTools(t *testing.T) {
 
 	// Check we have the expected number of tools
 	// With auto-archive enabled by default, todo_archive is not included
-	expectedTools := 9 // Excluding todo_archive
+	expectedTools := 15 // Excluding todo_archive
 	if len(tools) != expectedTools {
 		t.Errorf("Expected %d tools, got %d", expectedTools, len(tools))
 	}
@@ -101,11 +101,17 @@ func TestListTools(t *testing.T) {
 		"todo_create_multi": false,
 		"todo_read":         false,
 		"todo_update":       false,
+		"todo_agenda":       false,
 		"todo_search":       false,
+		"todo_similar":      false,
+		"todo_restore":      false,
 		"todo_template":     false,
+		"todo_sections":     false,
 		"todo_link":         false,
+		"todo_graph":        false,
 		"todo_stats":        false,
 		"todo_clean":        false,
+		"todo_index":        false,
 	}
 
 	for _, tool := range tools {
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+)
+
+func TestIndexAdminEndpoint(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("CLAUDE_TODO_PATH", filepath.Join(tempDir, "todos"))
+	t.Setenv("CLAUDE_TEMPLATE_PATH", filepath.Join(tempDir, "templates"))
+
+	ts, err := NewTodoServer(WithTransport("http"), WithAdminToken("secret"))
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer ts.Close()
+
+	project := t.TempDir()
+	tests := []struct {
+		name           string
+		method         string
+		query          string
+		auth           string
+		expectedStatus int
+		expectedField  string
+	}{
+		{"GET reports health", "GET", "", "Bearer secret", http.StatusOK, "circuitBreaker"},
+		{"POST starts a reindex", "POST", "action=reindex&mode=full", "Bearer secret", http.StatusAccepted, "mode"},
+		{"POST without action", "POST", "", "Bearer secret", http.StatusBadRequest, ""},
+		{"POST with unknown mode", "POST", "action=reindex&mode=sideways", "Bearer secret", http.StatusBadRequest, ""},
+		{"PUT is not allowed", "PUT", "", "Bearer secret", http.StatusMethodNotAllowed, ""},
+		{"missing token", "GET", "", "", http.StatusUnauthorized, ""},
+		{"wrong token", "POST", "action=rebuild", "Bearer guess", http.StatusUnauthorized, ""},
+		{"token without scheme", "GET", "", "secret", http.StatusUnauthorized, ""},
+		{"project not open", "POST", "dir=" + url.QueryEscape(project) + "&action=rebuild", "Bearer secret", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/admin/index?"+tt.query, nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			w := httptest.NewRecorder()
+			ts.handleIndexAdmin(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedField == "" {
+				return
+			}
+			var body map[string]interface{}
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if _, ok := body[tt.expectedField]; !ok {
+				t.Errorf("Expected %q in response, got %v", tt.expectedField, body)
+			}
+		})
+	}
+
+	if entries, _ := filepath.Glob(filepath.Join(project, "*")); len(entries) != 0 {
+		t.Errorf("Expected the endpoint to leave an unopened project alone, found %v", entries)
+	}
+}
+
+func TestIndexAdminEndpointDisabledWithoutToken(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("CLAUDE_TODO_PATH", filepath.Join(tempDir, "todos"))
+	t.Setenv("CLAUDE_TEMPLATE_PATH", filepath.Join(tempDir, "templates"))
+
+	ts, err := NewTodoServer(WithTransport("http"))
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	defer ts.Close()
+
+	req := httptest.NewRequest("GET", "/admin/index", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	w := httptest.NewRecorder()
+	ts.handleIndexAdmin(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected the endpoint to refuse everyone without a configured token, got %d", w.Code)
+	}
+}
@@ -0,0 +1,227 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/user/mcp-todo-server/handlers"
+	"github.com/user/mcp-todo-server/internal/logging"
+)
+
+// MCP methods mcp-go doesn't route itself
+const (
+	methodResourcesSubscribe   = "resources/subscribe"
+	methodResourcesUnsubscribe = "resources/unsubscribe"
+)
+
+// stdioSessionID is the session mcp-go registers for the stdio transport
+const stdioSessionID = "stdio"
+
+// TodoResources keeps the MCP resource list in step with the todo files and
+// tracks which sessions subscribed to which resource URIs
+type TodoResources struct {
+	mcpServer *server.MCPServer
+	handlers  *handlers.TodoHandlers
+
+	mu          sync.Mutex
+	listed      map[string]bool            // URIs registered with mcp-go
+	subscribers map[string]map[string]bool // URI -> session IDs
+}
+
+// NewTodoResources creates the resource registry for a server
+func NewTodoResources(mcpServer *server.MCPServer, todoHandlers *handlers.TodoHandlers) *TodoResources {
+	return &TodoResources{
+		mcpServer:   mcpServer,
+		handlers:    todoHandlers,
+		listed:      make(map[string]bool),
+		subscribers: make(map[string]map[string]bool),
+	}
+}
+
+// Register adds the resource templates and the todos known at startup, and
+// starts following todo changes
+func (tr *TodoResources) Register() {
+	tr.mcpServer.AddResourceTemplate(
+		mcp.NewResourceTemplate(handlers.TodoResourceTemplate, "Todo",
+			mcp.WithTemplateDescription("An active todo as markdown, including frontmatter"),
+			mcp.WithTemplateMIMEType("text/markdown")),
+		tr.handlers.HandleTodoResource,
+	)
+	tr.mcpServer.AddResourceTemplate(
+		mcp.NewResourceTemplate(handlers.ArchiveResourceTemplate, "Archived todo",
+			mcp.WithTemplateDescription("An archived todo, addressed by the day it was started"),
+			mcp.WithTemplateMIMEType("text/markdown")),
+		tr.handlers.HandleArchivedTodoResource,
+	)
+
+	var initial []server.ServerResource
+	tr.mu.Lock()
+	for _, resource := range tr.handlers.ListTodoResources() {
+		tr.listed[resource.URI] = true
+		initial = append(initial, server.ServerResource{Resource: resource, Handler: tr.handlers.HandleTodoResource})
+	}
+	tr.mu.Unlock()
+	if len(initial) > 0 {
+		tr.mcpServer.AddResources(initial...)
+	}
+
+	tr.handlers.OnResourceChange(tr.apply)
+}
+
+// apply updates the resource list for a change and notifies subscribers
+func (tr *TodoResources) apply(change handlers.TodoResourceChange) {
+	uri := change.Resource.URI
+
+	tr.mu.Lock()
+	wasListed := tr.listed[uri]
+	if change.Removed {
+		delete(tr.listed, uri)
+	} else {
+		tr.listed[uri] = true
+	}
+	tr.mu.Unlock()
+
+	// mcp-go sends notifications/resources/list_changed for these
+	if change.Removed && wasListed {
+		tr.mcpServer.RemoveResource(uri)
+	} else if !change.Removed && !wasListed {
+		tr.mcpServer.AddResource(change.Resource, tr.handlers.HandleTodoResource)
+	}
+
+	tr.notifyUpdated(uri)
+}
+
+// notifyUpdated sends notifications/resources/updated to every session
+// subscribed to uri, forgetting sessions that have gone away
+func (tr *TodoResources) notifyUpdated(uri string) {
+	tr.mu.Lock()
+	var sessions []string
+	for sessionID := range tr.subscribers[uri] {
+		sessions = append(sessions, sessionID)
+	}
+	tr.mu.Unlock()
+
+	for _, sessionID := range sessions {
+		err := tr.mcpServer.SendNotificationToSpecificClient(sessionID, mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri})
+		if err == server.ErrSessionNotFound {
+			tr.Unsubscribe(sessionID, uri)
+		} else if err != nil {
+			logging.Warnf("Failed to notify session %s about %s: %v", sessionID, uri, err)
+		}
+	}
+}
+
+// Subscribe records that a session wants updates for uri
+func (tr *TodoResources) Subscribe(sessionID, uri string) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if tr.subscribers[uri] == nil {
+		tr.subscribers[uri] = make(map[string]bool)
+	}
+	tr.subscribers[uri][sessionID] = true
+}
+
+// Unsubscribe stops updates for uri to a session
+func (tr *TodoResources) Unsubscribe(sessionID, uri string) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	delete(tr.subscribers[uri], sessionID)
+	if len(tr.subscribers[uri]) == 0 {
+		delete(tr.subscribers, uri)
+	}
+}
+
+// InterceptMessage handles resources/subscribe and resources/unsubscribe,
+// which mcp-go answers with "method not found". The subscription is recorded
+// and the request is rewritten into a ping carrying the same ID, so mcp-go
+// replies with the empty result both methods expect. Other messages are
+// returned unchanged.
+func (tr *TodoResources) InterceptMessage(sessionID string, message []byte) []byte {
+	var request struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Method  string          `json:"method"`
+		Params  struct {
+			URI string `json:"uri"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(message, &request); err != nil || len(request.ID) == 0 || request.Params.URI == "" {
+		return message
+	}
+
+	switch request.Method {
+	case methodResourcesSubscribe:
+		tr.Subscribe(sessionID, request.Params.URI)
+	case methodResourcesUnsubscribe:
+		tr.Unsubscribe(sessionID, request.Params.URI)
+	default:
+		return message
+	}
+
+	ping, err := json.Marshal(map[string]any{
+		"jsonrpc": request.JSONRPC,
+		"id":      request.ID,
+		"method":  string(mcp.MethodPing),
+	})
+	if err != nil {
+		return message
+	}
+	return ping
+}
+
+// interceptReader applies InterceptMessage to each line of a stdio stream
+func (tr *TodoResources) interceptReader(input io.Reader, sessionID string) io.Reader {
+	reader, writer := io.Pipe()
+	go func() {
+		lines := bufio.NewReader(input)
+		for {
+			line, err := lines.ReadBytes('\n')
+			if len(line) > 0 {
+				rewritten := tr.InterceptMessage(sessionID, line)
+				if !bytes.Equal(rewritten, line) {
+					rewritten = append(rewritten, '\n')
+				}
+				if _, werr := writer.Write(rewritten); werr != nil {
+					return
+				}
+			}
+			if err != nil {
+				writer.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return reader
+}
+
+// Middleware applies InterceptMessage to MCP requests sent over HTTP
+func (tr *TodoResources) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID := r.Header.Get("Mcp-Session-Id")
+		if r.Method != http.MethodPost || sessionID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+
+		body = tr.InterceptMessage(sessionID, body)
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+		next.ServeHTTP(w, r)
+	})
+}
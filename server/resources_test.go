@@ -0,0 +1,197 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// fakeSession is a client session that records notifications
+type fakeSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *fakeSession) Initialize()                                         {}
+func (s *fakeSession) Initialized() bool                                   { return true }
+func (s *fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s *fakeSession) SessionID() string                                   { return s.id }
+
+func newResourceTestServer(t *testing.T) *TodoServer {
+	t.Helper()
+	tempDir := t.TempDir()
+	todosDir := filepath.Join(tempDir, ".claude", "todos")
+	templatesDir := filepath.Join(tempDir, ".claude", "templates")
+	os.MkdirAll(todosDir, 0755)
+	os.MkdirAll(templatesDir, 0755)
+
+	oldTodoPath := os.Getenv("CLAUDE_TODO_PATH")
+	oldTemplatePath := os.Getenv("CLAUDE_TEMPLATE_PATH")
+	os.Setenv("CLAUDE_TODO_PATH", todosDir)
+	os.Setenv("CLAUDE_TEMPLATE_PATH", templatesDir)
+	t.Cleanup(func() {
+		os.Setenv("CLAUDE_TODO_PATH", oldTodoPath)
+		os.Setenv("CLAUDE_TEMPLATE_PATH", oldTemplatePath)
+	})
+
+	ts, err := NewTodoServer()
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	t.Cleanup(func() { ts.Close() })
+	return ts
+}
+
+// call sends a JSON-RPC request through the MCP server and decodes the result
+func call(t *testing.T, ts *TodoServer, ctx context.Context, method string, params interface{}) map[string]interface{} {
+	t.Helper()
+	message, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	response, _ := json.Marshal(ts.mcpServer.HandleMessage(ctx, message))
+
+	var decoded struct {
+		Result map[string]interface{} `json:"result"`
+		Error  interface{}            `json:"error"`
+	}
+	json.Unmarshal(response, &decoded)
+	if decoded.Error != nil {
+		t.Fatalf("%s failed: %s", method, response)
+	}
+	return decoded.Result
+}
+
+func listedURIs(t *testing.T, ts *TodoServer) []string {
+	t.Helper()
+	result := call(t, ts, context.Background(), "resources/list", map[string]interface{}{})
+	var uris []string
+	resources, _ := result["resources"].([]interface{})
+	for _, resource := range resources {
+		uris = append(uris, resource.(map[string]interface{})["uri"].(string))
+	}
+	return uris
+}
+
+func TestTodoResources_ListReadAndSubscribe(t *testing.T) {
+	ts := newResourceTestServer(t)
+
+	session := &fakeSession{id: "test-session", notifications: make(chan mcp.JSONRPCNotification, 100)}
+	ctx := context.Background()
+	if err := ts.mcpServer.RegisterSession(ctx, session); err != nil {
+		t.Fatalf("Failed to register session: %v", err)
+	}
+	ctx = ts.mcpServer.WithContext(ctx, session)
+
+	call(t, ts, ctx, "tools/call", map[string]interface{}{
+		"name":      "todo_create",
+		"arguments": map[string]interface{}{"task": "Resource backed task", "priority": "high", "type": "feature"},
+	})
+
+	// The new todo shows up in the resource list once its change is delivered
+	var uri string
+	deadline := time.Now().Add(2 * time.Second)
+	for uri == "" && time.Now().Before(deadline) {
+		for _, listed := range listedURIs(t, ts) {
+			if strings.HasPrefix(listed, "todo://") && strings.HasSuffix(listed, "/resource-backed-task") {
+				uri = listed
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if uri == "" {
+		t.Fatal("Created todo was not listed as a resource")
+	}
+
+	result := call(t, ts, ctx, "resources/read", map[string]interface{}{"uri": uri})
+	contents, _ := result["contents"].([]interface{})
+	if len(contents) != 1 || !strings.Contains(contents[0].(map[string]interface{})["text"].(string), "# Task: Resource backed task") {
+		t.Fatalf("Expected todo markdown, got %v", result)
+	}
+
+	// Subscribing is answered with an empty result
+	subscribe, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0", "id": 7, "method": "resources/subscribe",
+		"params": map[string]interface{}{"uri": uri},
+	})
+	response, _ := json.Marshal(ts.mcpServer.HandleMessage(ctx, ts.resources.InterceptMessage(session.id, subscribe)))
+	if !strings.Contains(string(response), `"id":7`) || strings.Contains(string(response), `"error"`) {
+		t.Fatalf("Unexpected subscribe response: %s", response)
+	}
+
+	waitForUpdate := func(what string) {
+		t.Helper()
+		timeout := time.After(2 * time.Second)
+		for {
+			select {
+			case notification := <-session.notifications:
+				if notification.Method == string(mcp.MethodNotificationResourceUpdated) &&
+					notification.Params.AdditionalFields["uri"] == uri {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("No resources/updated notification after %s", what)
+			}
+		}
+	}
+
+	call(t, ts, ctx, "tools/call", map[string]interface{}{
+		"name":      "todo_update",
+		"arguments": map[string]interface{}{"id": "resource-backed-task", "metadata": map[string]interface{}{"priority": "low"}},
+	})
+	waitForUpdate("update")
+
+	// Completing archives the todo, which removes the resource
+	call(t, ts, ctx, "tools/call", map[string]interface{}{
+		"name":      "todo_update",
+		"arguments": map[string]interface{}{"id": "resource-backed-task", "metadata": map[string]interface{}{"status": "completed"}},
+	})
+	waitForUpdate("archive")
+	for _, listed := range listedURIs(t, ts) {
+		if listed == uri {
+			t.Errorf("Archived todo %s is still listed", uri)
+		}
+	}
+}
+
+func TestTodoResources_InterceptMessage(t *testing.T) {
+	tr := NewTodoResources(nil, nil)
+
+	subscribe := []byte(`{"jsonrpc":"2.0","id":"abc","method":"resources/subscribe","params":{"uri":"todo://p/a"}}`)
+	rewritten := tr.InterceptMessage("s1", subscribe)
+
+	var ping map[string]interface{}
+	if err := json.Unmarshal(rewritten, &ping); err != nil {
+		t.Fatalf("Rewritten message is not JSON: %s", rewritten)
+	}
+	if ping["method"] != "ping" || ping["id"] != "abc" {
+		t.Errorf("Expected ping with the original id, got %s", rewritten)
+	}
+	if !tr.subscribers["todo://p/a"]["s1"] {
+		t.Error("Expected s1 to be subscribed")
+	}
+
+	tr.InterceptMessage("s1", []byte(`{"jsonrpc":"2.0","id":2,"method":"resources/unsubscribe","params":{"uri":"todo://p/a"}}`))
+	if len(tr.subscribers) != 0 {
+		t.Errorf("Expected no subscriptions left, got %v", tr.subscribers)
+	}
+
+	// Everything else passes through untouched
+	for _, message := range []string{
+		`{"jsonrpc":"2.0","id":3,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`not json`,
+	} {
+		if got := tr.InterceptMessage("s1", []byte(message)); string(got) != message {
+			t.Errorf("Expected %s unchanged, got %s", message, got)
+		}
+	}
+}
@@ -2,16 +2,22 @@ package server
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"os/signal"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/user/mcp-todo-server/handlers"
 	ctxkeys "github.com/user/mcp-todo-server/internal/context"
+	interrors "github.com/user/mcp-todo-server/internal/errors"
 	"github.com/user/mcp-todo-server/internal/logging"
 	"github.com/user/mcp-todo-server/utils"
 )
@@ -20,6 +26,7 @@ import (
 type TodoServer struct {
 	mcpServer         *server.MCPServer
 	handlers          *handlers.TodoHandlers
+	resources         *TodoResources
 	transport         string
 	
 	// HTTP transport layers (each serves a specific purpose):
@@ -32,6 +39,7 @@ type TodoServer struct {
 	managerTimeout    time.Duration
 	heartbeatInterval time.Duration
 	noAutoArchive     bool
+	adminToken        string
 	
 	// HTTP timeout configurations
 	requestTimeout    time.Duration
@@ -109,6 +117,14 @@ func WithHTTPIdleTimeout(timeout time.Duration) ServerOption {
 	}
 }
 
+// WithAdminToken enables the /admin/index endpoint for requests carrying
+// "Authorization: Bearer <token>"; without a token it isn't served
+func WithAdminToken(token string) ServerOption {
+	return func(s *TodoServer) {
+		s.adminToken = token
+	}
+}
+
 // NewTodoServer creates a new MCP todo server with all tools registered
 func NewTodoServer(opts ...ServerOption) (*TodoServer, error) {
 	logging.Infof("Creating new TodoServer...")
@@ -130,6 +146,8 @@ func NewTodoServer(opts ...ServerOption) (*TodoServer, error) {
 		"MCP Todo Server",
 		"1.0.0",
 		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(true, true),
+		server.WithPromptCapabilities(false),
 	)
 
 	// Create todo server wrapper with default transport
@@ -164,6 +182,11 @@ func NewTodoServer(opts ...ServerOption) (*TodoServer, error) {
 	logging.Infof("Registering MCP tools...")
 	ts.registerTools()
 	logging.Infof("Tools registered successfully")
+	ts.registerPrompts()
+
+	// Publish todos as resources
+	ts.resources = NewTodoResources(s, todoHandlers)
+	ts.resources.Register()
 
 	// Create HTTP server if needed
 	if ts.transport == "http" {
@@ -209,8 +232,9 @@ func NewTodoServer(opts ...ServerOption) (*TodoServer, error) {
 			WithMaxRequestsPerConnection(1000),
 		)
 		
-		// Wrap with middleware for header extraction
-		ts.httpWrapper = NewStreamableHTTPServerWrapper(ts.stableTransport, ts.sessionTimeout)
+		// Wrap with middleware for header extraction; resource subscriptions
+		// are picked out of the request bodies first
+		ts.httpWrapper = NewStreamableHTTPServerWrapper(ts.resources.Middleware(ts.stableTransport), ts.sessionTimeout)
 	}
 
 	return ts, nil
@@ -225,7 +249,9 @@ func (ts *TodoServer) ListTools() []mcp.Tool {
 		mcp.NewTool("todo_create_multi", mcp.WithDescription("Plan a multi-phase project by creating a parent task and all its phases at once. Automatically links phases together for easy progress tracking.")),
 		mcp.NewTool("todo_read", mcp.WithDescription("View your tasks and their current status. Check a specific todo's details or see all active work with filtering options.")),
 		mcp.NewTool("todo_update", mcp.WithDescription("Add progress notes, test results, or findings to a todo. Update status when blocked or completed (auto-archives on completion).")),
+		mcp.NewTool("todo_agenda", mcp.WithDescription("See what's due: open todos grouped into overdue, today, this week and later by due or scheduled date.")),
 		mcp.NewTool("todo_search", mcp.WithDescription("Find past solutions, code snippets, or similar work across all your todos. Searches through task descriptions, findings, and test results.")),
+		mcp.NewTool("todo_similar", mcp.WithDescription("Find the todos most like a given todo or a description, archived ones included, ranked by the words in their task, findings and tests. Use it to reuse past solutions.")),
 	}
 	
 	// Only include todo_archive if auto-archive is disabled
@@ -234,10 +260,14 @@ func (ts *TodoServer) ListTools() []mcp.Tool {
 	}
 	
 	tools = append(tools, []mcp.Tool{
-		mcp.NewTool("todo_template", mcp.WithDescription("Start with a pre-structured todo for common tasks. Templates include sections and checklists tailored to specific workflows.")),
+		mcp.NewTool("todo_restore", mcp.WithDescription("Reopen an archived todo: moves it back to the active todos as in_progress and re-indexes it for search.")),
+		mcp.NewTool("todo_template", mcp.WithDescription("Start with a pre-structured todo for common tasks. Templates include sections and checklists tailored to specific workflows, and can create a parent with its phases in one call. Can also save a todo as a new template, validate a template or preview it.")),
+		mcp.NewTool("todo_sections", mcp.WithDescription("List a todo's sections with their schema metrics, or add, rename, reorder and remove sections. The markdown headings move with them.")),
 		mcp.NewTool("todo_link", mcp.WithDescription("Connect related tasks together. Useful for dependencies, blocking relationships, or grouping related work.")),
+		mcp.NewTool("todo_graph", mcp.WithDescription("Draw a todo tree with its dependency links as a Mermaid or Graphviz diagram, ready to paste into PRs and design docs.")),
 		mcp.NewTool("todo_stats", mcp.WithDescription("View your productivity metrics: completed tasks, time spent, task distribution, and work patterns.")),
-		mcp.NewTool("todo_clean", mcp.WithDescription("Maintain your todo system by archiving old incomplete tasks or finding potential duplicates.")),
+		mcp.NewTool("todo_clean", mcp.WithDescription("Maintain your todo system by archiving old incomplete tasks, finding near-duplicates, or merging duplicates into one todo.")),
+		mcp.NewTool("todo_index", mcp.WithDescription("Check the search index against the todo files on disk, and reindex, rebuild or compact it without restarting the server.")),
 	}...)
 	
 	return tools
@@ -262,6 +292,18 @@ func (ts *TodoServer) registerTools() {
 				mcp.Description("Use a pre-built structure (bug-fix, feature, research, refactor, tdd-cycle)")),
 			mcp.WithString("parent_id",
 				mcp.Description("Link to parent task when breaking down large projects into phases")),
+			mcp.WithString("due",
+				mcp.Description("Deadline (YYYY-MM-DD, 'YYYY-MM-DD HH:MM' or RFC3339)")),
+			mcp.WithString("scheduled",
+				mcp.Description("When you plan to start working on it (same formats as due)")),
+			mcp.WithString("recurrence",
+				mcp.Description("Repeat on completion using an RRULE subset, e.g. FREQ=DAILY;INTERVAL=2, FREQ=WEEKLY;BYDAY=MO,TH, FREQ=MONTHLY;BYMONTHDAY=-1")),
+			mcp.WithArray("tags",
+				mcp.Description("Labels for grouping work across projects (e.g., ['backend', 'area/auth'])"),
+				mcp.Items(map[string]any{"type": "string"})),
+			mcp.WithBoolean("check_similar",
+				mcp.Description("Don't create the todo if a similar one exists, active or archived; list it instead (default false)"),
+				mcp.DefaultBool(false)),
 		),
 		ts.handlers.HandleTodoCreate,
 	)
@@ -288,6 +330,11 @@ func (ts *TodoServer) registerTools() {
 						"description": "Usually 'multi-phase' for projects with multiple steps",
 						"default":     "multi-phase",
 					},
+					"tags": map[string]any{
+						"type":        "array",
+						"description": "Labels for the project",
+						"items":       map[string]any{"type": "string"},
+					},
 				})),
 			mcp.WithArray("children",
 				mcp.Required(),
@@ -309,6 +356,11 @@ func (ts *TodoServer) registerTools() {
 							"description": "Usually 'phase' for project phases",
 							"default":     "phase",
 						},
+						"tags": map[string]any{
+							"type":        "array",
+							"description": "Labels for this phase",
+							"items":       map[string]any{"type": "string"},
+						},
 					},
 					"required": []string{"task"},
 				})),
@@ -337,10 +389,31 @@ func (ts *TodoServer) registerTools() {
 						"type":        "number",
 						"description": "Show todos from the last N days (e.g., 7 for past week)",
 					},
+					"overdue": map[string]any{
+						"type":        "boolean",
+						"description": "Show only open todos past their due date",
+					},
+					"due_within_days": map[string]any{
+						"type":        "number",
+						"description": "Show only open todos due in the next N days (includes overdue)",
+					},
+					"tags": map[string]any{
+						"type":        "array",
+						"description": "Show only todos carrying all of these tags",
+						"items":       map[string]any{"type": "string"},
+					},
+					"any_tags": map[string]any{
+						"type":        "array",
+						"description": "Show only todos carrying at least one of these tags",
+						"items":       map[string]any{"type": "string"},
+					},
 				})),
 			mcp.WithString("format",
-				mcp.Description("How much detail? (full=everything, summary=overview, list=just titles)"),
+				mcp.Description("How much detail? (full=everything, summary=overview, list=just titles, mermaid/dot=diagram of the todo tree)"),
 				mcp.DefaultString("summary")),
+			mcp.WithString("sort",
+				mcp.Description("Order of listed todos (started=newest first, due=earliest deadline first)"),
+				mcp.DefaultString("started")),
 		),
 		ts.handlers.HandleTodoRead,
 	)
@@ -369,23 +442,58 @@ func (ts *TodoServer) registerTools() {
 						"type":        "string",
 						"description": "Track which test you're working on (e.g., 'Test 3: user validation')",
 					},
+					"due": map[string]any{
+						"type":        "string",
+						"description": "Set the deadline (YYYY-MM-DD or RFC3339, 'none' to clear)",
+					},
+					"scheduled": map[string]any{
+						"type":        "string",
+						"description": "Set when work is planned to start (YYYY-MM-DD or RFC3339, 'none' to clear)",
+					},
+					"recurrence": map[string]any{
+						"type":        "string",
+						"description": "Make the todo recurring (FREQ=DAILY|WEEKLY|MONTHLY with INTERVAL, BYDAY or BYMONTHDAY; 'none' to stop)",
+					},
+					"tags": map[string]any{
+						"type":        "array",
+						"description": "Replace all tags (empty array clears them)",
+						"items":       map[string]any{"type": "string"},
+					},
+					"add_tags": map[string]any{
+						"type":        "array",
+						"description": "Tags to add, keeping existing ones",
+						"items":       map[string]any{"type": "string"},
+					},
+					"remove_tags": map[string]any{
+						"type":        "array",
+						"description": "Tags to remove",
+						"items":       map[string]any{"type": "string"},
+					},
 				})),
 			mcp.WithString("section",
 				mcp.Description("Required when adding content. Where to add content (findings=research notes, tests=test results, checklist=task items, scratchpad=rough notes)")),
 			mcp.WithString("operation",
-				mcp.Description("How to add content (append=add to end, replace=overwrite, prepend=add to beginning, toggle=check/uncheck)"),
+				mcp.Description("How to add content (append=add to end, replace=overwrite, prepend=add to beginning, toggle=advance a checklist item named in content by ^id, position like 2.1, or its text)"),
 				mcp.DefaultString("append")),
 		),
 		ts.handlers.HandleTodoUpdate,
 	)
 
+	// Register todo_agenda
+	ts.mcpServer.AddTool(
+		mcp.NewTool("todo_agenda",
+			mcp.WithDescription("See what's due: open todos grouped into overdue, today, this week and later by due or scheduled date."),
+		),
+		ts.handlers.HandleTodoAgenda,
+	)
+
 	// Register todo_search
 	ts.mcpServer.AddTool(
 		mcp.NewTool("todo_search",
 			mcp.WithDescription("Find past solutions, code snippets, or similar work across all your todos. Searches through task descriptions, findings, and test results."),
 			mcp.WithString("query",
 				mcp.Required(),
-				mcp.Description("What to search for (e.g., 'authentication', 'timeout bug', 'JWT implementation')")),
+				mcp.Description("What to search for (e.g., 'authentication', 'timeout bug'). Supports \"exact phrases\", -exclusions, AND/OR with (grouping), field qualifiers status:, priority:, type:, tag:, id:, task:, findings:, tests:, content: and date comparisons like started:>2026-01-01 or completed:<=2026-03-31")),
 			mcp.WithArray("scope",
 				mcp.Description("Where to search (task=titles only, findings=research notes, tests=test code, all=everywhere)"),
 				mcp.Items(map[string]any{"type": "string"})),
@@ -396,6 +504,18 @@ func (ts *TodoServer) registerTools() {
 						"type":        "string",
 						"description": "Search only in todos with this state",
 					},
+					"priority": map[string]any{
+						"type":        "string",
+						"description": "Search only in todos with this priority",
+					},
+					"type": map[string]any{
+						"type":        "string",
+						"description": "Search only in todos of this type",
+					},
+					"month": map[string]any{
+						"type":        "string",
+						"description": "Search only in todos started in this month (YYYY-MM)",
+					},
 					"date_from": map[string]any{
 						"type":        "string",
 						"description": "Start date for search range (YYYY-MM-DD)",
@@ -404,15 +524,47 @@ func (ts *TodoServer) registerTools() {
 						"type":        "string",
 						"description": "End date for search range (YYYY-MM-DD)",
 					},
+					"tags": map[string]any{
+						"type":        "array",
+						"description": "Search only in todos carrying all of these tags",
+						"items":       map[string]any{"type": "string"},
+					},
+					"any_tags": map[string]any{
+						"type":        "array",
+						"description": "Search only in todos carrying at least one of these tags",
+						"items":       map[string]any{"type": "string"},
+					},
 				})),
 			mcp.WithNumber("limit",
 				mcp.Description("Maximum results to return (default 20, max 100)"),
 				mcp.DefaultNumber(20),
 				mcp.Max(100)),
+			mcp.WithBoolean("include_archived",
+				mcp.Description("Also search archived todos (default: active todos only)"),
+				mcp.DefaultBool(false)),
+			mcp.WithBoolean("archived_only",
+				mcp.Description("Search only archived todos"),
+				mcp.DefaultBool(false)),
 		),
 		ts.handlers.HandleTodoSearch,
 	)
 
+	// Register todo_similar
+	ts.mcpServer.AddTool(
+		mcp.NewTool("todo_similar",
+			mcp.WithDescription("Find the todos most like a given todo or a description, archived ones included, ranked by the words in their task, findings and tests. Use it to reuse past solutions."),
+			mcp.WithString("id",
+				mcp.Description("Todo to find look-alikes for (e.g., 'fix-login-timeout')")),
+			mcp.WithString("text",
+				mcp.Description("Or describe the work instead of giving an id (e.g., 'session expires during checkout')")),
+			mcp.WithNumber("limit",
+				mcp.Description("Maximum results to return (default 5, max 20)"),
+				mcp.DefaultNumber(5),
+				mcp.Max(20)),
+		),
+		ts.handlers.HandleTodoSimilar,
+	)
+
 	// Register todo_archive only if auto-archive is disabled
 	if ts.noAutoArchive {
 		ts.mcpServer.AddTool(
@@ -426,10 +578,29 @@ func (ts *TodoServer) registerTools() {
 		)
 	}
 
+	// Register todo_restore
+	ts.mcpServer.AddTool(
+		mcp.NewTool("todo_restore",
+			mcp.WithDescription("Reopen an archived todo: moves it back to the active todos as in_progress and re-indexes it for search."),
+			mcp.WithString("id",
+				mcp.Required(),
+				mcp.Description("Archived todo to restore (e.g., 'fix-login-bug')")),
+			mcp.WithBoolean("keep_completed",
+				mcp.Description("Keep the completed timestamp instead of clearing it (default false)"),
+				mcp.DefaultBool(false)),
+			mcp.WithBoolean("cascade",
+				mcp.Description("Also restore archived children, grandchildren, etc. (default false)"),
+				mcp.DefaultBool(false)),
+		),
+		ts.handlers.HandleTodoRestore,
+	)
+
 	// Register todo_template
 	ts.mcpServer.AddTool(
 		mcp.NewTool("todo_template",
-			mcp.WithDescription("Start with a pre-structured todo for common tasks. Templates include sections and checklists tailored to specific workflows."),
+			mcp.WithDescription("Start with a pre-structured todo for common tasks. Templates include sections and checklists tailored to specific workflows, and can create a parent with its phases in one call. Can also save a todo as a new template, validate a template or preview it."),
+			mcp.WithString("action",
+				mcp.Description("What to do (create=new todo from the template, list=templates with descriptions and variables, preview=render without creating anything, validate=check the template file, save=write a new template from the todo in id). Defaults to create when template is given, otherwise list")),
 			mcp.WithString("template",
 				mcp.Description("Template name (bug-fix, feature, research, refactor, tdd-cycle). Leave empty to see all available")),
 			mcp.WithString("task",
@@ -440,10 +611,47 @@ func (ts *TodoServer) registerTools() {
 			mcp.WithString("type",
 				mcp.Description("Work type (feature, bug, refactor, research, prd, multi-phase, phase, subtask)"),
 				mcp.DefaultString("feature")),
+			mcp.WithObject("variables",
+				mcp.Description("Values for the template's declared variables (e.g., {\"component\": \"auth\"}). task, priority, type and date are filled in automatically; variables with a default in the template may be left out")),
+			mcp.WithString("id",
+				mcp.Description("For action=save: the todo to turn into a template; its task text becomes {{.task}}")),
+			mcp.WithString("description",
+				mcp.Description("For action=save: what the new template is for")),
 		),
 		ts.handlers.HandleTodoTemplate,
 	)
 
+	// Register todo_sections
+	ts.mcpServer.AddTool(
+		mcp.NewTool("todo_sections",
+			mcp.WithDescription("List a todo's sections with their schema metrics, or add, rename, reorder and remove sections. The markdown headings move with them."),
+			mcp.WithString("id",
+				mcp.Required(),
+				mcp.Description("The todo whose sections to list or change")),
+			mcp.WithString("action",
+				mcp.Description("What to do (list, add=new custom section, rename=change key and/or title, reorder=move sections to the front, remove=drop an empty section that isn't required)"),
+				mcp.DefaultString("list")),
+			mcp.WithString("key",
+				mcp.Description("Section key for add, rename and remove (e.g., 'risks'); lowercase letters, digits and '_'")),
+			mcp.WithString("title",
+				mcp.Description("For add: the heading text (defaults to the key in title case). For rename: the new heading")),
+			mcp.WithString("schema",
+				mcp.Description("For add: how the section's content is checked (freeform, checklist, research, strategy, test_cases, results, decisions, table, links)"),
+				mcp.DefaultString("freeform")),
+			mcp.WithArray("columns",
+				mcp.Description("For add with schema=table: the column names; the section starts with a header row"),
+				mcp.Items(map[string]any{"type": "string"})),
+			mcp.WithNumber("position",
+				mcp.Description("For add: 1-based position among the sections; leave out to add it last")),
+			mcp.WithString("new_key",
+				mcp.Description("For rename: the section's new key")),
+			mcp.WithArray("order",
+				mcp.Description("For reorder: section keys in the order they should come first; sections not listed follow in their current order"),
+				mcp.Items(map[string]any{"type": "string"})),
+		),
+		ts.handlers.HandleTodoSections,
+	)
+
 	// Register todo_link
 	ts.mcpServer.AddTool(
 		mcp.NewTool("todo_link",
@@ -461,6 +669,22 @@ func (ts *TodoServer) registerTools() {
 		ts.handlers.HandleTodoLink,
 	)
 
+	// Register todo_graph
+	ts.mcpServer.AddTool(
+		mcp.NewTool("todo_graph",
+			mcp.WithDescription("Draw a todo tree with its dependency links as a Mermaid or Graphviz diagram, ready to paste into PRs and design docs."),
+			mcp.WithString("id",
+				mcp.Description("Root todo to draw with its ancestors and descendants. Leave empty to draw all active todos")),
+			mcp.WithString("format",
+				mcp.Description("Diagram format (mermaid=graph TD block, dot=Graphviz document)"),
+				mcp.DefaultString("mermaid")),
+			mcp.WithBoolean("include_archived",
+				mcp.Description("Also draw ancestors that have already been archived"),
+				mcp.DefaultBool(false)),
+		),
+		ts.handlers.HandleTodoGraph,
+	)
+
 	// Register todo_stats
 	ts.mcpServer.AddTool(
 		mcp.NewTool("todo_stats",
@@ -475,16 +699,67 @@ func (ts *TodoServer) registerTools() {
 	// Register todo_clean
 	ts.mcpServer.AddTool(
 		mcp.NewTool("todo_clean",
-			mcp.WithDescription("Maintain your todo system by archiving old incomplete tasks or finding potential duplicates."),
+			mcp.WithDescription("Maintain your todo system by archiving old incomplete tasks, finding near-duplicates, or merging duplicates into one todo."),
 			mcp.WithString("operation",
-				mcp.Description("What to clean (archive_old=move stale todos, find_duplicates=identify similar tasks)"),
+				mcp.Description("What to clean (archive_old=move stale todos, find_duplicates=score similar tasks by title and body, merge_duplicates=fold duplicates into a survivor)"),
 				mcp.DefaultString("archive_old")),
 			mcp.WithNumber("days",
 				mcp.Description("For archive_old: how many days before considering a todo stale (default 90)"),
 				mcp.DefaultNumber(90)),
+			mcp.WithNumber("threshold",
+				mcp.Description("For find_duplicates: minimum similarity (0-1) for a pair to be reported (default 0.6)"),
+				mcp.DefaultNumber(0.6)),
+			mcp.WithString("survivor",
+				mcp.Description("For merge_duplicates: the todo to keep")),
+			mcp.WithArray("duplicates",
+				mcp.Description("For merge_duplicates: todo IDs to merge into the survivor; their sections and checklist items are combined, children re-parented, and the duplicates archived with merged_into"),
+				mcp.Items(map[string]any{"type": "string"})),
 		),
 		ts.handlers.HandleTodoClean,
 	)
+
+	// Register todo_index
+	ts.mcpServer.AddTool(
+		mcp.NewTool("todo_index",
+			mcp.WithDescription("Check the search index against the todo files on disk, and reindex, rebuild or compact it without restarting the server."),
+			mcp.WithString("action",
+				mcp.Description("health=documents vs files on disk, stale entries and circuit breaker state; reindex=catch the index up in the background; rebuild=recreate a corrupted index from the files; compact=merge index segments"),
+				mcp.DefaultString("health")),
+			mcp.WithString("mode",
+				mcp.Description("For reindex: incremental (new and changed files only) or full (every file)"),
+				mcp.DefaultString("incremental")),
+		),
+		ts.handlers.HandleTodoIndex,
+	)
+}
+
+// registerPrompts registers the workflow prompts built from todo data
+func (ts *TodoServer) registerPrompts() {
+	ts.mcpServer.AddPrompt(
+		mcp.NewPrompt("standup",
+			mcp.WithPromptDescription("Daily standup: todos completed since yesterday, what is in progress today, and what is blocked."),
+		),
+		ts.handlers.HandleStandupPrompt,
+	)
+
+	ts.mcpServer.AddPrompt(
+		mcp.NewPrompt("handoff",
+			mcp.WithPromptDescription("Compact resume of one todo (findings, checklist state, latest test results, next steps) for picking it up in a fresh session."),
+			mcp.WithArgument("id",
+				mcp.ArgumentDescription("ID of the todo to hand off"),
+				mcp.RequiredArgument()),
+		),
+		ts.handlers.HandleHandoffPrompt,
+	)
+
+	ts.mcpServer.AddPrompt(
+		mcp.NewPrompt("retro",
+			mcp.WithPromptDescription("Retrospective over the todos completed in a period, with their test results."),
+			mcp.WithArgument("period",
+				mcp.ArgumentDescription("Time range: week (default), month, quarter, year or all")),
+		),
+		ts.handlers.HandleRetroPrompt,
+	)
 }
 
 // Close cleans up server resources
@@ -534,7 +809,17 @@ func (ts *TodoServer) Close() error {
 // StartStdio starts the MCP server in STDIO mode
 func (ts *TodoServer) StartStdio() error {
 	logging.Infof("StartStdio called, starting MCP STDIO server...")
-	err := server.ServeStdio(ts.mcpServer)
+
+	// Same signal handling as server.ServeStdio, but stdin passes through
+	// the resource subscription handling
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	var input io.Reader = os.Stdin
+	if ts.resources != nil {
+		input = ts.resources.interceptReader(os.Stdin, stdioSessionID)
+	}
+	err := server.NewStdioServer(ts.mcpServer).Listen(ctx, input, os.Stdout)
 	if err != nil {
 		logging.Errorf("STDIO server error: %v", err)
 	}
@@ -562,6 +847,11 @@ func (ts *TodoServer) StartHTTP(addr string) error {
 	http.HandleFunc("/debug/sessions", ts.handleDebugSessions)
 	http.HandleFunc("/debug/transport", ts.handleDebugTransport)
 	
+	// Search index health and maintenance, only with an admin token
+	if ts.adminToken != "" {
+		http.HandleFunc("/admin/index", ts.handleIndexAdmin)
+	}
+	
 	// Configure server with proper timeouts for connection resilience
 	server := &http.Server{
 		Addr:         addr,
@@ -681,6 +971,65 @@ func (ts *TodoServer) handleDebugTransport(w http.ResponseWriter, r *http.Reques
 	json.NewEncoder(w).Encode(metrics)
 }
 
+// handleIndexAdmin reports search index health on GET and runs
+// action=reindex|rebuild|compact (with mode=incremental|full) on POST.
+// dir selects the index of a project the server already has open; the
+// server's own index is the default. Requests need the admin token.
+func (ts *TodoServer) handleIndexAdmin(w http.ResponseWriter, r *http.Request) {
+	if !ts.isAdmin(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	action := "health"
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		action = query.Get("action")
+		if action == "" {
+			http.Error(w, "action is required", http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result, err := ts.handlers.IndexAdmin(query.Get("dir"), action, query.Get("mode"))
+	if err != nil {
+		status := http.StatusServiceUnavailable
+		switch {
+		case interrors.IsValidation(err):
+			status = http.StatusBadRequest
+		case interrors.IsNotFound(err):
+			status = http.StatusNotFound
+		case interrors.IsConflict(err):
+			status = http.StatusConflict
+		case interrors.IsOperation(err):
+			status = http.StatusInternalServerError
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if action == "reindex" || action == "rebuild" {
+		w.WriteHeader(http.StatusAccepted)
+	}
+	json.NewEncoder(w).Encode(result)
+}
+
+// isAdmin reports whether r carries the admin token
+func (ts *TodoServer) isAdmin(r *http.Request) bool {
+	if ts.adminToken == "" {
+		return false
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(ts.adminToken)) == 1
+}
+
 // handleHeartbeat handles heartbeat requests for the stable transport
 func (ts *TodoServer) handleHeartbeat(w http.ResponseWriter, r *http.Request) {
 	// This is handled by the stable transport's ServeHTTP method
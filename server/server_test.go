@@ -54,12 +54,18 @@ func TestServerInitialization(t *testing.T) {
 		"todo_create_multi",
 		"todo_read",
 		"todo_update",
+		"todo_agenda",
 		"todo_search",
+		"todo_similar",
 		// Note: todo_archive is no longer in default list due to auto-archive feature
+		"todo_restore",
 		"todo_template",
+		"todo_sections",
 		"todo_link",
+		"todo_graph",
 		"todo_stats",
 		"todo_clean",
+		"todo_index",
 	}
 
 	// Verify all expected tools are registered (minus 1 for todo_archive)